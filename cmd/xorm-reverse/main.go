@@ -0,0 +1,92 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command xorm-reverse generates Go structs with xorm tags from an existing database.
+//
+//	xorm-reverse -driver mysql -dsn "root:@/test" -pkg models -o models/models.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"xorm.io/xorm"
+	"xorm.io/xorm/names"
+	"xorm.io/xorm/reverse"
+
+	_ "github.com/denisenkom/go-mssqldb"
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+var (
+	driver       = flag.String("driver", "", "the database driver, i.e. mysql, postgres, sqlite3 or mssql")
+	dsn          = flag.String("dsn", "", "the data source name of the database")
+	pkg          = flag.String("pkg", "models", "the package name of the generated file")
+	output       = flag.String("o", "", "the generated file, default is the stdout")
+	tables       = flag.String("tables", "", "the comma separated table names, default is all the tables")
+	mapper       = flag.String("mapper", "snake", "the name mapper, could be snake, same or gonic")
+	templateFile = flag.String("template", "", "the template file, default is the builtin template")
+)
+
+func main() {
+	flag.Parse()
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	if *driver == "" || *dsn == "" {
+		flag.Usage()
+		return fmt.Errorf("driver and dsn are required")
+	}
+
+	engine, err := xorm.NewEngine(*driver, *dsn)
+	if err != nil {
+		return err
+	}
+	defer engine.Close()
+
+	opts := &reverse.Options{PackageName: *pkg}
+	switch *mapper {
+	case "snake":
+		opts.TableMapper, opts.ColumnMapper = names.SnakeMapper{}, names.SnakeMapper{}
+	case "same":
+		opts.TableMapper, opts.ColumnMapper = names.SameMapper{}, names.SameMapper{}
+	case "gonic":
+		opts.TableMapper, opts.ColumnMapper = names.LintGonicMapper, names.LintGonicMapper
+	default:
+		return fmt.Errorf("unknown mapper %s", *mapper)
+	}
+	if *tables != "" {
+		opts.Tables = strings.Split(*tables, ",")
+	}
+	if *templateFile != "" {
+		content, err := os.ReadFile(*templateFile)
+		if err != nil {
+			return err
+		}
+		if opts.Template, err = reverse.ParseTemplate(string(content)); err != nil {
+			return err
+		}
+		opts.SkipFormat = *output != "" && !strings.HasSuffix(*output, ".go")
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return reverse.Generate(engine, w, opts)
+}
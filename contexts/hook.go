@@ -55,6 +55,24 @@ func (h *Hooks) AddHook(hooks ...Hook) {
 	h.hooks = append(h.hooks, hooks...)
 }
 
+// RemoveHook removes the hooks which have been added, the hooks are compared by ==
+func (h *Hooks) RemoveHook(hooks ...Hook) {
+	var kept = make([]Hook, 0, len(h.hooks))
+	for _, hook := range h.hooks {
+		var removed bool
+		for _, r := range hooks {
+			if hook == r {
+				removed = true
+				break
+			}
+		}
+		if !removed {
+			kept = append(kept, hook)
+		}
+	}
+	h.hooks = kept
+}
+
 // BeforeProcess invoked before execute the process
 func (h *Hooks) BeforeProcess(c *ContextHook) (context.Context, error) {
 	ctx := c.Ctx
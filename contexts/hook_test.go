@@ -27,6 +27,23 @@ func (h *testHook) AfterProcess(c *ContextHook) error {
 
 var _ Hook = &testHook{}
 
+func TestRemoveHook(t *testing.T) {
+	var called []int
+	hook1 := &testHook{after: func(c *ContextHook) error { called = append(called, 1); return nil }}
+	hook2 := &testHook{after: func(c *ContextHook) error { called = append(called, 2); return nil }}
+
+	var hooks Hooks
+	hooks.AddHook(hook1, hook2)
+	hooks.RemoveHook(hook1)
+	hooks.RemoveHook(hook1)
+	if err := hooks.AfterProcess(&ContextHook{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(called) != 1 || called[0] != 2 {
+		t.Errorf("the hooks called should be [2] but got %v", called)
+	}
+}
+
 func TestBeforeProcess(t *testing.T) {
 	expectErr := errors.New("before error")
 	tests := []struct {
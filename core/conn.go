@@ -0,0 +1,66 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package core
+
+import (
+	"context"
+	"database/sql"
+
+	"xorm.io/xorm/contexts"
+)
+
+var (
+	_ QueryExecuter = &Conn{}
+)
+
+// Conn represents a single connection of the database, the SQLs which change the states
+// of the connection, like the PRAGMAs of sqlite, should be executed on the same connection
+type Conn struct {
+	*sql.Conn
+	db *DB
+}
+
+// AcquireConn returns a single connection from the connection pool, it should be closed after using.
+// Unlike sql.DB.Conn, the SQLs executed on the connection are logged and hooked as the others.
+func (db *DB) AcquireConn(ctx context.Context) (*Conn, error) {
+	conn, err := db.DB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Conn{conn, db}, nil
+}
+
+// ExecContext executes a query with args
+func (conn *Conn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	hookCtx := contexts.NewContextHook(ctx, query, args)
+	ctx, err := conn.db.beforeProcess(hookCtx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := conn.Conn.ExecContext(ctx, query, args...)
+	hookCtx.End(ctx, res, err)
+	if err := conn.db.afterProcess(hookCtx); err != nil {
+		return nil, err
+	}
+	return res, err
+}
+
+// QueryContext query with args
+func (conn *Conn) QueryContext(ctx context.Context, query string, args ...interface{}) (*Rows, error) {
+	hookCtx := contexts.NewContextHook(ctx, query, args)
+	ctx, err := conn.db.beforeProcess(hookCtx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := conn.Conn.QueryContext(ctx, query, args...)
+	hookCtx.End(ctx, nil, err)
+	if err := conn.db.afterProcess(hookCtx); err != nil {
+		if rows != nil {
+			rows.Close()
+		}
+		return nil, err
+	}
+	return &Rows{rows, conn.db}, nil
+}
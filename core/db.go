@@ -306,3 +306,8 @@ func (db *DB) afterProcess(c *contexts.ContextHook) error {
 func (db *DB) AddHook(h ...contexts.Hook) {
 	db.hooks.AddHook(h...)
 }
+
+// RemoveHook removes the hooks which have been added
+func (db *DB) RemoveHook(h ...contexts.Hook) {
+	db.hooks.RemoveHook(h...)
+}
@@ -550,18 +550,17 @@ func (db *dameng) Version(ctx context.Context, queryer core.Queryer) (*schemas.V
 
 func (db *dameng) Features() *DialectFeatures {
 	return &DialectFeatures{
-		AutoincrMode: SequenceAutoincrMode,
+		AutoincrMode:        SequenceAutoincrMode,
+		MaxIdentifierLength: 128,
 	}
 }
 
 // DropIndexSQL returns a SQL to drop index
 func (db *dameng) DropIndexSQL(tableName string, index *schemas.Index) string {
 	quote := db.dialect.Quoter().Quote
-	var name string
-	if index.IsRegular {
-		name = index.XName(tableName)
-	} else {
-		name = index.Name
+	name := IndexName(db, tableName, index)
+	if schema, _ := SplitTableName(tableName); schema != "" {
+		name = schema + "." + name
 	}
 	return fmt.Sprintf("DROP INDEX %v", quote(name))
 }
@@ -719,6 +718,8 @@ func (db *dameng) CreateTableSQL(ctx context.Context, queryer core.Queryer, tabl
 			return "", false, err
 		}
 	}
+	writeForeignKeys(&b, db, table, tableName)
+	writeChecks(&b, db, table, tableName)
 	if _, err := b.WriteString(")"); err != nil {
 		return "", false, err
 	}
@@ -744,18 +745,21 @@ func (db *dameng) SetQuotePolicy(quotePolicy QuotePolicy) {
 }
 
 func (db *dameng) IndexCheckSQL(tableName, idxName string) (string, []interface{}) {
+	owner, tableName := ownerOf(&db.Base, context.Background(), tableName)
 	args := []interface{}{tableName, idxName}
-	return `SELECT INDEX_NAME FROM USER_INDEXES ` +
-		`WHERE TABLE_NAME = ? AND INDEX_NAME = ?`, args
+	return `SELECT INDEX_NAME FROM ALL_INDEXES ` +
+		`WHERE TABLE_NAME = ? AND INDEX_NAME = ? AND TABLE_OWNER = ` + owner, args
 }
 
 func (db *dameng) IsTableExist(queryer core.Queryer, ctx context.Context, tableName string) (bool, error) {
-	return db.HasRecords(queryer, ctx, `SELECT table_name FROM user_tables WHERE table_name = ?`, tableName)
+	owner, tableName := ownerOf(&db.Base, ctx, tableName)
+	return db.HasRecords(queryer, ctx, `SELECT table_name FROM all_tables WHERE table_name = ? AND owner = `+owner, tableName)
 }
 
 func (db *dameng) IsSequenceExist(ctx context.Context, queryer core.Queryer, seqName string) (bool, error) {
 	var cnt int
-	rows, err := queryer.QueryContext(ctx, "SELECT COUNT(*) FROM user_sequences WHERE sequence_name = ?", seqName)
+	owner, seqName := ownerOf(&db.Base, ctx, seqName)
+	rows, err := queryer.QueryContext(ctx, "SELECT COUNT(*) FROM all_sequences WHERE sequence_name = ? AND sequence_owner = "+owner, seqName)
 	if err != nil {
 		return false, err
 	}
@@ -774,9 +778,10 @@ func (db *dameng) IsSequenceExist(ctx context.Context, queryer core.Queryer, seq
 }
 
 func (db *dameng) IsColumnExist(queryer core.Queryer, ctx context.Context, tableName, colName string) (bool, error) {
+	owner, tableName := ownerOf(&db.Base, ctx, tableName)
 	args := []interface{}{tableName, colName}
-	query := "SELECT column_name FROM USER_TAB_COLUMNS WHERE table_name = ?" +
-		" AND column_name = ?"
+	query := "SELECT column_name FROM ALL_TAB_COLUMNS WHERE table_name = ?" +
+		" AND column_name = ? AND owner = " + owner
 	return db.HasRecords(queryer, ctx, query, args...)
 }
 
@@ -841,9 +846,10 @@ func addSingleQuote(name string) string {
 }
 
 func (db *dameng) GetColumns(queryer core.Queryer, ctx context.Context, tableName string) ([]string, map[string]*schemas.Column, error) {
-	s := `select   column_name   from   user_cons_columns   
-  where   constraint_name   =   (select   constraint_name   from   user_constraints   
-			  where   table_name   =   ?  and   constraint_type   ='P')`
+	owner, tableName := ownerOf(&db.Base, ctx, tableName)
+	s := `select   column_name   from   all_cons_columns   
+  where   owner = ` + owner + ` and constraint_name   =   (select   constraint_name   from   all_constraints   
+			  where   owner = ` + owner + ` and table_name   =   ?  and   constraint_type   ='P')`
 	rows, err := queryer.QueryContext(ctx, s, tableName)
 	if err != nil {
 		return nil, nil, err
@@ -864,13 +870,13 @@ func (db *dameng) GetColumns(queryer core.Queryer, ctx context.Context, tableNam
 	}
 	rows.Close()
 
-	s = `SELECT USER_TAB_COLS.COLUMN_NAME, USER_TAB_COLS.DATA_DEFAULT, USER_TAB_COLS.DATA_TYPE, USER_TAB_COLS.DATA_LENGTH, 
-		USER_TAB_COLS.data_precision, USER_TAB_COLS.data_scale, USER_TAB_COLS.NULLABLE,
-		user_col_comments.comments
-		FROM USER_TAB_COLS 
-		LEFT JOIN user_col_comments on user_col_comments.TABLE_NAME=USER_TAB_COLS.TABLE_NAME 
-		AND user_col_comments.COLUMN_NAME=USER_TAB_COLS.COLUMN_NAME
-		WHERE USER_TAB_COLS.table_name = ?`
+	s = `SELECT ALL_TAB_COLS.COLUMN_NAME, ALL_TAB_COLS.DATA_DEFAULT, ALL_TAB_COLS.DATA_TYPE, ALL_TAB_COLS.DATA_LENGTH, 
+		ALL_TAB_COLS.data_precision, ALL_TAB_COLS.data_scale, ALL_TAB_COLS.NULLABLE,
+		all_col_comments.comments
+		FROM ALL_TAB_COLS 
+		LEFT JOIN all_col_comments on all_col_comments.OWNER=ALL_TAB_COLS.OWNER AND all_col_comments.TABLE_NAME=ALL_TAB_COLS.TABLE_NAME 
+		AND all_col_comments.COLUMN_NAME=ALL_TAB_COLS.COLUMN_NAME
+		WHERE ALL_TAB_COLS.table_name = ? AND ALL_TAB_COLS.owner = ` + owner
 	rows, err = queryer.QueryContext(ctx, s, tableName)
 	if err != nil {
 		return nil, nil, err
@@ -915,7 +921,7 @@ func (db *dameng) GetColumns(queryer core.Queryer, ctx context.Context, tableNam
 		}
 		if utils.IndexSlice(pkNames, col.Name) > -1 {
 			col.IsPrimaryKey = true
-			has, err := db.HasRecords(queryer, ctx, "SELECT * FROM USER_SEQUENCES WHERE SEQUENCE_NAME = ?", utils.SeqName(tableName))
+			has, err := db.HasRecords(queryer, ctx, "SELECT * FROM ALL_SEQUENCES WHERE SEQUENCE_NAME = ? AND SEQUENCE_OWNER = "+owner, SequenceName(db, tableName))
 			if err != nil {
 				return nil, nil, err
 			}
@@ -992,9 +998,15 @@ func (db *dameng) GetColumns(queryer core.Queryer, ctx context.Context, tableNam
 	return colSeq, cols, nil
 }
 
+func (db *dameng) GetViews(queryer core.Queryer, ctx context.Context) ([]*schemas.Table, error) {
+	owner, _ := ownerOf(&db.Base, ctx, "")
+	return queryViews(queryer, ctx, false, "SELECT view_name, text FROM all_views WHERE owner = "+owner)
+}
+
 func (db *dameng) GetTables(queryer core.Queryer, ctx context.Context) ([]*schemas.Table, error) {
-	s := "SELECT table_name FROM user_tables WHERE temporary = 'N' AND table_name NOT LIKE ?"
-	args := []interface{}{strings.ToUpper(db.uri.User), "%$%"}
+	owner, _ := ownerOf(&db.Base, ctx, "")
+	s := "SELECT table_name FROM all_tables WHERE owner = " + owner + " AND temporary = 'N' AND table_name NOT LIKE ?"
+	args := []interface{}{"%$%"}
 
 	rows, err := queryer.QueryContext(ctx, s, args...)
 	if err != nil {
@@ -1018,11 +1030,31 @@ func (db *dameng) GetTables(queryer core.Queryer, ctx context.Context) ([]*schem
 	return tables, nil
 }
 
+func (db *dameng) GetForeignKeys(queryer core.Queryer, ctx context.Context, tableName string) (map[string]*schemas.ForeignKey, error) {
+	owner, tableName := ownerOf(&db.Base, ctx, tableName)
+	args := []interface{}{tableName}
+	s := "SELECT c.constraint_name, cc.column_name, rc.table_name, rcc.column_name, 'NO ACTION', c.delete_rule" +
+		" FROM all_constraints c JOIN all_cons_columns cc ON cc.owner = c.owner AND cc.constraint_name = c.constraint_name" +
+		" JOIN all_constraints rc ON rc.owner = c.r_owner AND rc.constraint_name = c.r_constraint_name" +
+		" JOIN all_cons_columns rcc ON rcc.owner = rc.owner AND rcc.constraint_name = rc.constraint_name AND rcc.position = cc.position" +
+		" WHERE c.constraint_type = 'R' AND c.table_name = ? AND c.owner = " + owner + " ORDER BY c.constraint_name, cc.position"
+	return queryForeignKeys(queryer, ctx, s, args...)
+}
+
+func (db *dameng) GetChecks(queryer core.Queryer, ctx context.Context, tableName string) (map[string]*schemas.Check, error) {
+	owner, tableName := ownerOf(&db.Base, ctx, tableName)
+	args := []interface{}{tableName}
+	s := "SELECT constraint_name, search_condition, generated FROM all_constraints WHERE constraint_type = 'C' AND table_name = ? AND owner = " + owner
+	return queryOracleChecks(queryer, ctx, s, args...)
+}
+
 func (db *dameng) GetIndexes(queryer core.Queryer, ctx context.Context, tableName string) (map[string]*schemas.Index, error) {
+	owner, tableName := ownerOf(&db.Base, ctx, tableName)
 	args := []interface{}{tableName, tableName}
-	s := "SELECT t.column_name,i.uniqueness,i.index_name FROM user_ind_columns t,user_indexes i " +
-		"WHERE t.index_name = i.index_name and t.table_name = i.table_name and t.table_name =?" +
-		" AND t.index_name not in (SELECT index_name FROM ALL_CONSTRAINTS WHERE CONSTRAINT_TYPE='P' AND table_name = ?)"
+	s := "SELECT t.column_name,i.uniqueness,i.index_name FROM all_ind_columns t,all_indexes i " +
+		"WHERE t.index_owner = i.owner and t.index_name = i.index_name and t.table_name = i.table_name and t.table_name =?" +
+		" AND t.table_owner = " + owner +
+		" AND t.index_name not in (SELECT index_name FROM ALL_CONSTRAINTS WHERE CONSTRAINT_TYPE='P' AND table_name = ? AND owner = " + owner + ")"
 
 	rows, err := queryer.QueryContext(ctx, s, args...)
 	if err != nil {
@@ -1043,10 +1075,7 @@ func (db *dameng) GetIndexes(queryer core.Queryer, ctx context.Context, tableNam
 		indexName = strings.Trim(indexName, `" `)
 
 		var isRegular bool
-		if strings.HasPrefix(indexName, "IDX_"+tableName) || strings.HasPrefix(indexName, "UQE_"+tableName) {
-			indexName = indexName[5+len(tableName):]
-			isRegular = true
-		}
+		indexName, isRegular = regularIndexName(db, tableName, indexName)
 
 		if uniqueness == "UNIQUE" {
 			indexType = schemas.UniqueType
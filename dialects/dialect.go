@@ -6,11 +6,17 @@ package dialects
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"xorm.io/xorm/core"
+	"xorm.io/xorm/internal/utils"
+	"xorm.io/xorm/names"
 	"xorm.io/xorm/schemas"
 )
 
@@ -30,23 +36,26 @@ type URI struct {
 	Schema  string
 }
 
-// SetSchema set schema
+// SetSchema sets the default schema of the tables, it's the schema of postgres and mssql,
+// the owner of oracle and dameng, the database of mysql or the attached database of sqlite
 func (uri *URI) SetSchema(schema string) {
-	// hack me
-	if uri.DBType == schemas.POSTGRES {
-		uri.Schema = strings.TrimSpace(schema)
-	}
+	uri.Schema = strings.TrimSpace(schema)
 }
 
 // enumerates all autoincr mode
 const (
 	IncrAutoincrMode = iota
 	SequenceAutoincrMode
+	IdentityAutoincrMode // GENERATED BY DEFAULT AS IDENTITY
 )
 
 // DialectFeatures represents a dialect parameters
 type DialectFeatures struct {
-	AutoincrMode int // 0 autoincrement column, 1 sequence
+	AutoincrMode        int  // 0 autoincrement column, 1 sequence, 2 identity column
+	MaxIdentifierLength int  // the longer names of the indexes and constraints will be shortened, 0 means no limit
+	SupportReturning    bool // INSERT could return the ids of the inserted rows by RETURNING
+	AddColumnNullable   bool // the columns are added as nullable and NOT NULL is set by another SQL
+	UnnamedChecks       bool // the names of the check constraints are not kept, so the checks are matched by expressions
 }
 
 // Dialect represents a kind of database
@@ -54,6 +63,7 @@ type Dialect interface {
 	Init(*URI) error
 	URI() *URI
 	Version(ctx context.Context, queryer core.Queryer) (*schemas.Version, error)
+	SetQueryer(queryer core.Queryer)
 	Features() *DialectFeatures
 
 	SQLType(*schemas.Column) string
@@ -63,6 +73,8 @@ type Dialect interface {
 	IsReserved(string) bool
 	Quoter() schemas.Quoter
 	SetQuotePolicy(quotePolicy QuotePolicy)
+	NamingStrategy() names.NamingStrategy
+	SetNamingStrategy(strategy names.NamingStrategy)
 
 	AutoIncrStr() string
 
@@ -71,7 +83,32 @@ type Dialect interface {
 	CreateIndexSQL(tableName string, index *schemas.Index) string
 	DropIndexSQL(tableName string, index *schemas.Index) string
 
+	// AddForeignKeySQL and DropForeignKeySQL return an empty string if the database
+	// cannot alter the foreign keys, the table should be rebuilt instead.
+	GetForeignKeys(queryer core.Queryer, ctx context.Context, tableName string) (map[string]*schemas.ForeignKey, error)
+	AddForeignKeySQL(tableName string, fk *schemas.ForeignKey) string
+	DropForeignKeySQL(tableName string, fk *schemas.ForeignKey) string
+
+	// AddCheckSQL and DropCheckSQL return an empty string if the database cannot
+	// alter the check constraints, the table should be rebuilt instead.
+	GetChecks(queryer core.Queryer, ctx context.Context, tableName string) (map[string]*schemas.Check, error)
+	AddCheckSQL(tableName string, check *schemas.Check) string
+	DropCheckSQL(tableName string, check *schemas.Check) string
+
 	GetTables(queryer core.Queryer, ctx context.Context) ([]*schemas.Table, error)
+	// GetViews returns the views and the materialized views whose View fields are not nil
+	GetViews(queryer core.Queryer, ctx context.Context) ([]*schemas.Table, error)
+	CreateViewSQL(viewName string, view *schemas.View) (string, error)
+	// ReplaceViewSQL returns an empty string if the database cannot replace the view,
+	// the view should be dropped and created again instead.
+	ReplaceViewSQL(viewName string, view *schemas.View) string
+	DropViewSQL(viewName string, view *schemas.View) string
+	RefreshMaterializedViewSQL(viewName string, concurrently bool) (string, error)
+	// GetPartitions, CreatePartitionSQL and DropPartitionSQL return errors if the
+	// database doesn't support partitioning
+	GetPartitions(queryer core.Queryer, ctx context.Context, tableName string) ([]*schemas.Partition, error)
+	CreatePartitionSQL(tableName string, partition *schemas.Partition) (string, error)
+	DropPartitionSQL(tableName, partitionName string) (string, error)
 	IsTableExist(queryer core.Queryer, ctx context.Context, tableName string) (bool, error)
 	CreateTableSQL(ctx context.Context, queryer core.Queryer, table *schemas.Table, tableName string) (string, bool, error)
 	DropTableSQL(tableName string) (string, bool)
@@ -84,8 +121,18 @@ type Dialect interface {
 	IsColumnExist(queryer core.Queryer, ctx context.Context, tableName string, colName string) (bool, error)
 	AddColumnSQL(tableName string, col *schemas.Column) string
 	ModifyColumnSQL(tableName string, col *schemas.Column) string
+	DropColumnSQL(tableName, colName string) string
+	// RenameColumnSQL, AlterColumnNullableSQL and AlterColumnDefaultSQL return an empty string
+	// if the database cannot alter the column, the table should be rebuilt instead.
+	RenameColumnSQL(tableName, oldName string, col *schemas.Column) string
+	AlterColumnNullableSQL(tableName string, col *schemas.Column) string
+	AlterColumnDefaultSQL(tableName string, col *schemas.Column) string
+	RebuildTableSQLs(ctx context.Context, queryer core.Queryer, table *schemas.Table, tableName string) ([]string, error)
 
 	ForUpdateSQL(query string) string
+	// LimitOffsetSQL returns the select query which skips the offset records and returns at
+	// most limit records and its arguments
+	LimitOffsetSQL(query *SelectQuery) (string, []interface{}, error)
 
 	Filters() []Filter
 	SetParams(params map[string]string)
@@ -96,6 +143,25 @@ type Base struct {
 	dialect Dialect
 	uri     *URI
 	quoter  schemas.Quoter
+
+	namingStrategy names.NamingStrategy
+
+	queryer      core.Queryer // the queryer to detect the version and the states of the tables lazily
+	versionMutex sync.RWMutex
+	version      *schemas.Version // the version detected by detectedVersion
+}
+
+// tableSchema returns the schema and the name of the table, the schema comes from the table
+// name like schema.table, the context or the URI in order
+func (db *Base) tableSchema(ctx context.Context, tableName string) (string, string) {
+	schema, tableName := SplitTableName(tableName)
+	if schema == "" {
+		schema = SchemaFromContext(ctx)
+	}
+	if schema == "" {
+		schema = db.uri.Schema
+	}
+	return schema, tableName
 }
 
 // Alias returned col itself
@@ -114,6 +180,34 @@ func (db *Base) Init(dialect Dialect, uri *URI) error {
 	return nil
 }
 
+// SetQueryer sets the queryer of the database, the version of the database and the states of
+// the existing tables are detected by it the first time the SQLs depending on them are built.
+// It's set when the engine is created and nothing is queried by it then.
+func (db *Base) SetQueryer(queryer core.Queryer) {
+	db.queryer = queryer
+}
+
+// detectedVersion returns the version of the database which is detected by the queryer of the
+// dialect the first time, nil if it cannot be detected and it will be detected again next time.
+// All the SQLs depending on the version get it by this method.
+func (db *Base) detectedVersion() *schemas.Version {
+	db.versionMutex.RLock()
+	version := db.version
+	db.versionMutex.RUnlock()
+	if version != nil || db.queryer == nil {
+		return version
+	}
+
+	db.versionMutex.Lock()
+	defer db.versionMutex.Unlock()
+	if db.version == nil {
+		if v, err := db.dialect.Version(context.Background(), db.queryer); err == nil {
+			db.version = v
+		}
+	}
+	return db.version
+}
+
 // URI returns the uri of database
 func (db *Base) URI() *URI {
 	return db.uri
@@ -149,6 +243,9 @@ func (db *Base) CreateTableSQL(ctx context.Context, queryer core.Queryer, table
 		b.WriteString(")")
 	}
 
+	writeForeignKeys(&b, db.dialect, table, tableName)
+	writeChecks(&b, db.dialect, table, tableName)
+
 	b.WriteString(")")
 
 	return b.String(), false, nil
@@ -164,6 +261,26 @@ func (db *Base) CreateSequenceSQL(ctx context.Context, queryer core.Queryer, seq
 	nocache`, seqName), nil
 }
 
+// AutoincrNextvalSQL returns the expression of the next value which is inserted into the
+// autoincrement column explicitly like SEQ_USER.nextval, or an empty string if the database
+// generates the value by itself
+func AutoincrNextvalSQL(dialect Dialect, tableName string, col *schemas.Column) string {
+	seqName := AutoincrSequenceName(dialect, tableName, col)
+	if seqName == "" {
+		return ""
+	}
+	switch dialect.URI().DBType {
+	case schemas.ORACLE, schemas.DAMENG:
+		return seqName + ".nextval"
+	case schemas.POSTGRES:
+		// the sequence of a serial is the default value of the column
+		if col.Sequence != "" {
+			return "nextval('" + dialect.Quoter().Quote(seqName) + "')"
+		}
+	}
+	return ""
+}
+
 func (db *Base) IsSequenceExist(ctx context.Context, queryer core.Queryer, seqName string) (bool, error) {
 	return false, fmt.Errorf("unsupported sequence feature")
 }
@@ -213,7 +330,8 @@ func (db *Base) AddColumnSQL(tableName string, col *schemas.Column) string {
 	return fmt.Sprintf("ALTER TABLE %s ADD %s", db.dialect.Quoter().Quote(tableName), s)
 }
 
-// CreateIndexSQL returns a SQL to create index
+// CreateIndexSQL returns a SQL to create index, the index features which the
+// database doesn't support will be ignored
 func (db *Base) CreateIndexSQL(tableName string, index *schemas.Index) string {
 	quoter := db.dialect.Quoter()
 	var unique string
@@ -221,22 +339,126 @@ func (db *Base) CreateIndexSQL(tableName string, index *schemas.Index) string {
 	if index.Type == schemas.UniqueType {
 		unique = " UNIQUE"
 	}
-	idxName = index.XName(tableName)
-	return fmt.Sprintf("CREATE%s INDEX %v ON %v (%v)", unique,
-		quoter.Quote(idxName), quoter.Quote(tableName),
-		quoter.Join(index.Cols, ","))
+	idxName = IndexName(db.dialect, tableName, index)
+	index = SupportedIndex(db.dialect, index)
+	onTable := tableName
+	if schema, name := SplitTableName(tableName); schema != "" && db.uri.DBType == schemas.SQLITE {
+		// the index of sqlite is created in the schema of the index name
+		idxName, onTable = schema+"."+idxName, name
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "CREATE%s INDEX %v ON %v", unique, quoter.Quote(idxName), quoter.Quote(onTable))
+	if index.Method != "" && db.uri.DBType == schemas.POSTGRES {
+		b.WriteString(" USING ")
+		b.WriteString(index.Method)
+	}
+	b.WriteString(" (")
+	for i, col := range index.Cols {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		if schemas.IsIndexExpr(col) {
+			b.WriteString("(")
+			b.WriteString(col)
+			b.WriteString(")")
+		} else {
+			quoter.QuoteTo(&b, col)
+			if length := index.Lengths[col]; length > 0 {
+				fmt.Fprintf(&b, "(%d)", length)
+			}
+		}
+		if index.Desc[col] {
+			b.WriteString(" DESC")
+		}
+	}
+	b.WriteString(")")
+	if index.Method != "" && db.uri.DBType == schemas.MYSQL {
+		b.WriteString(" USING ")
+		b.WriteString(strings.ToUpper(index.Method))
+	}
+	if len(index.Include) > 0 {
+		b.WriteString(" INCLUDE (")
+		quoter.JoinWrite(&b, index.Include, ",")
+		b.WriteString(")")
+	}
+	if index.Where != "" {
+		b.WriteString(" WHERE ")
+		b.WriteString(index.Where)
+	}
+	return b.String()
 }
 
 // DropIndexSQL returns a SQL to drop index
 func (db *Base) DropIndexSQL(tableName string, index *schemas.Index) string {
 	quote := db.dialect.Quoter().Quote
-	var name string
-	if index.IsRegular {
-		name = index.XName(tableName)
-	} else {
-		name = index.Name
+	return fmt.Sprintf("DROP INDEX %v ON %s", quote(IndexName(db.dialect, tableName, index)), quote(tableName))
+}
+
+// AddForeignKeySQL returns a SQL to add a foreign key
+func (db *Base) AddForeignKeySQL(tableName string, fk *schemas.ForeignKey) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD %s", db.dialect.Quoter().Quote(tableName), ForeignKeyString(db.dialect, tableName, fk))
+}
+
+// DropForeignKeySQL returns a SQL to drop a foreign key
+func (db *Base) DropForeignKeySQL(tableName string, fk *schemas.ForeignKey) string {
+	quote := db.dialect.Quoter().Quote
+	return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", quote(tableName), quote(ForeignKeyName(db.dialect, tableName, fk)))
+}
+
+// AddCheckSQL returns a SQL to add a check constraint
+func (db *Base) AddCheckSQL(tableName string, check *schemas.Check) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD %s", db.dialect.Quoter().Quote(tableName), CheckString(db.dialect, tableName, check))
+}
+
+// DropCheckSQL returns a SQL to drop a check constraint
+func (db *Base) DropCheckSQL(tableName string, check *schemas.Check) string {
+	quote := db.dialect.Quoter().Quote
+	return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", quote(tableName), quote(CheckName(db.dialect, tableName, check)))
+}
+
+// CreateViewSQL returns a SQL to create the view, the materialized views are not supported by default
+func (db *Base) CreateViewSQL(viewName string, view *schemas.View) (string, error) {
+	if view.Materialized {
+		return "", fmt.Errorf("materialized views are not supported by %s", db.uri.DBType)
+	}
+	return fmt.Sprintf("CREATE VIEW %s AS %s", db.dialect.Quoter().Quote(viewName), view.Query), nil
+}
+
+// ReplaceViewSQL returns a SQL to create or replace the view
+func (db *Base) ReplaceViewSQL(viewName string, view *schemas.View) string {
+	if view.Materialized {
+		return ""
+	}
+	return fmt.Sprintf("CREATE OR REPLACE VIEW %s AS %s", db.dialect.Quoter().Quote(viewName), view.Query)
+}
+
+// DropViewSQL returns a SQL to drop the view
+func (db *Base) DropViewSQL(viewName string, view *schemas.View) string {
+	if view.Materialized {
+		return fmt.Sprintf("DROP MATERIALIZED VIEW %s", db.dialect.Quoter().Quote(viewName))
 	}
-	return fmt.Sprintf("DROP INDEX %v ON %s", quote(name), quote(tableName))
+	return fmt.Sprintf("DROP VIEW %s", db.dialect.Quoter().Quote(viewName))
+}
+
+// RefreshMaterializedViewSQL returns a SQL to refresh the materialized view
+func (db *Base) RefreshMaterializedViewSQL(viewName string, concurrently bool) (string, error) {
+	return "", fmt.Errorf("materialized views are not supported by %s", db.uri.DBType)
+}
+
+// GetPartitions returns the partitions of the table, partitioning is not supported by default
+func (db *Base) GetPartitions(queryer core.Queryer, ctx context.Context, tableName string) ([]*schemas.Partition, error) {
+	return nil, fmt.Errorf("partitioning is not supported by %s", db.uri.DBType)
+}
+
+// CreatePartitionSQL returns a SQL to create a partition of the table, partitioning is not supported by default
+func (db *Base) CreatePartitionSQL(tableName string, partition *schemas.Partition) (string, error) {
+	return "", fmt.Errorf("partitioning is not supported by %s", db.uri.DBType)
+}
+
+// DropPartitionSQL returns a SQL to drop a partition of the table, partitioning is not supported by default
+func (db *Base) DropPartitionSQL(tableName, partitionName string) (string, error) {
+	return "", fmt.Errorf("partitioning is not supported by %s", db.uri.DBType)
 }
 
 // ModifyColumnSQL returns a SQL to modify SQL
@@ -245,11 +467,120 @@ func (db *Base) ModifyColumnSQL(tableName string, col *schemas.Column) string {
 	return fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s", db.quoter.Quote(tableName), s)
 }
 
+// DropColumnSQL returns a SQL to drop a column
+func (db *Base) DropColumnSQL(tableName, colName string) string {
+	quote := db.dialect.Quoter().Quote
+	return fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", quote(tableName), quote(colName))
+}
+
+// RenameColumnSQL returns a SQL to rename a column
+func (db *Base) RenameColumnSQL(tableName, oldName string, col *schemas.Column) string {
+	quote := db.dialect.Quoter().Quote
+	return fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s", quote(tableName), quote(oldName), quote(col.Name))
+}
+
+// AlterColumnNullableSQL returns a SQL to change the nullability of a column
+func (db *Base) AlterColumnNullableSQL(tableName string, col *schemas.Column) string {
+	return db.dialect.ModifyColumnSQL(tableName, col)
+}
+
+// AlterColumnDefaultSQL returns a SQL to change the default value of a column
+func (db *Base) AlterColumnDefaultSQL(tableName string, col *schemas.Column) string {
+	quote := db.dialect.Quoter().Quote
+	if col.DefaultIsEmpty {
+		return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT", quote(tableName), quote(col.Name))
+	}
+	return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s", quote(tableName), quote(col.Name), defaultValue(col))
+}
+
+// RebuildTableSQLs returns SQLs to rebuild a table according the table definition, the data
+// of the columns which exist on the old table will be copied, and the data of the renamed
+// columns will be copied from the old columns.
+func (db *Base) RebuildTableSQLs(ctx context.Context, queryer core.Queryer, table *schemas.Table, tableName string) ([]string, error) {
+	if tableName == "" {
+		tableName = table.Name
+	}
+	quoter := db.dialect.Quoter()
+	cols, srcCols, err := db.rebuildColumns(ctx, queryer, table, tableName)
+	if err != nil {
+		return nil, err
+	}
+	schema, name := SplitTableName(tableName)
+	// the constraints of the temporary table are named as the rebuilt table's
+	tmpTableName := rebuildTableName(schema, name)
+
+	dropTmpSQL, _ := db.dialect.DropTableSQL(tmpTableName)
+	createSQL, _, err := db.dialect.CreateTableSQL(ctx, queryer, table, tmpTableName)
+	if err != nil {
+		return nil, err
+	}
+	dropSQL, _ := db.dialect.DropTableSQL(tableName)
+
+	var sqls = []string{
+		dropTmpSQL,
+		createSQL,
+		fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s", quoter.Quote(tmpTableName),
+			quoter.Join(cols, ", "), quoter.Join(srcCols, ", "), quoter.Quote(tableName)),
+		dropSQL,
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", quoter.Quote(tmpTableName), quoter.Quote(name)),
+	}
+	for _, index := range table.Indexes {
+		sqls = append(sqls, db.dialect.CreateIndexSQL(tableName, index))
+	}
+	return sqls, nil
+}
+
+// rebuildColumns returns the columns of the rebuilt table which are copied and their source
+// columns on the old table
+func (db *Base) rebuildColumns(ctx context.Context, queryer core.Queryer, table *schemas.Table, tableName string) ([]string, []string, error) {
+	oldCols, _, err := db.dialect.GetColumns(queryer, ctx, tableName)
+	if err != nil {
+		return nil, nil, err
+	}
+	var cols, srcCols []string
+	for _, col := range table.Columns() {
+		switch {
+		case utils.ContainsFold(oldCols, col.Name):
+			srcCols = append(srcCols, col.Name)
+		case col.RenamedFrom != "" && utils.ContainsFold(oldCols, col.RenamedFrom):
+			srcCols = append(srcCols, col.RenamedFrom)
+		default:
+			continue
+		}
+		cols = append(cols, col.Name)
+	}
+	return cols, srcCols, nil
+}
+
+// rebuildTableName returns the name of the temporary table to rebuild a table
+func rebuildTableName(schema, name string) string {
+	if schema != "" {
+		return schema + "." + rebuildTablePrefix + name
+	}
+	return rebuildTablePrefix + name
+}
+
 // ForUpdateSQL returns for updateSQL
 func (db *Base) ForUpdateSQL(query string) string {
 	return query + " FOR UPDATE"
 }
 
+// LimitOffsetSQL returns the select query paginated by LIMIT and OFFSET
+func (db *Base) LimitOffsetSQL(query *SelectQuery) (string, []interface{}, error) {
+	var clause string
+	if query.Offset > 0 {
+		if query.Limit != nil {
+			clause = fmt.Sprintf(" LIMIT %v OFFSET %v", *query.Limit, query.Offset)
+		} else {
+			clause = fmt.Sprintf(" LIMIT 0 OFFSET %v", query.Offset)
+		}
+	} else if query.Limit != nil {
+		clause = fmt.Sprint(" LIMIT ", *query.Limit)
+	}
+	sql, args := query.Build("", "", nil, clause)
+	return sql, args, nil
+}
+
 // SetParams set params
 func (db *Base) SetParams(params map[string]string) {
 }
@@ -290,6 +621,7 @@ func regDrvsNDialects() bool {
 		"sqlite":   {"sqlite3", func() Driver { return &sqlite3Driver{} }, func() Dialect { return &sqlite3{} }},
 		"oci8":     {"oracle", func() Driver { return &oci8Driver{} }, func() Dialect { return &oracle{} }},
 		"godror":   {"oracle", func() Driver { return &godrorDriver{} }, func() Dialect { return &oracle{} }},
+		"duckdb":   {"duckdb", func() Driver { return &duckdbDriver{} }, func() Dialect { return &duckdb{} }},
 	}
 
 	for driverName, v := range providedDrvsNDialects {
@@ -339,14 +671,8 @@ func ColumnString(dialect Dialect, col *schemas.Column, includePrimaryKey bool)
 		if _, err := bd.WriteString(" DEFAULT "); err != nil {
 			return "", err
 		}
-		if col.Default == "" {
-			if _, err := bd.WriteString("''"); err != nil {
-				return "", err
-			}
-		} else {
-			if _, err := bd.WriteString(col.Default); err != nil {
-				return "", err
-			}
+		if _, err := bd.WriteString(defaultValue(col)); err != nil {
+			return "", err
 		}
 	}
 
@@ -362,3 +688,327 @@ func ColumnString(dialect Dialect, col *schemas.Column, includePrimaryKey bool)
 
 	return bd.String(), nil
 }
+
+// SupportedIndex returns a copy of the index with only the features which the database
+// supports and reports back, so it could be compared with the index read from the database.
+func SupportedIndex(dialect Dialect, index *schemas.Index) *schemas.Index {
+	var res = *index
+	switch dialect.URI().DBType {
+	case schemas.POSTGRES:
+		res.Lengths = nil
+	case schemas.MYSQL:
+		res.Include, res.Where = nil, ""
+	case schemas.SQLITE:
+		res.Include, res.Lengths, res.Method = nil, nil, ""
+	case schemas.MSSQL:
+		res.Lengths, res.Method = nil, ""
+	case schemas.ORACLE:
+		res.Include, res.Lengths, res.Where, res.Method = nil, nil, "", ""
+	default:
+		res.Desc, res.Include, res.Lengths, res.Where, res.Method = nil, nil, nil, "", ""
+	}
+	return &res
+}
+
+// parseIndexSQL parses the columns, the method, the covering columns and the predicate
+// from a create index SQL like the ones CreateIndexSQL generated
+func parseIndexSQL(index *schemas.Index, sql string) {
+	onIdx := strings.Index(sql, " ON ")
+	if onIdx < 0 {
+		return
+	}
+	rest := sql[onIdx+4:]
+	colsIdx := strings.Index(rest, "(")
+	if colsIdx < 0 {
+		return
+	}
+	if usingIdx := strings.Index(rest[:colsIdx], " USING "); usingIdx >= 0 {
+		index.Method = strings.ToLower(strings.TrimSpace(rest[usingIdx+7 : colsIdx]))
+	}
+
+	cols, rest := cutParentheses(rest[colsIdx:])
+	for _, col := range splitColumnDefs(cols) {
+		col = strings.TrimSpace(col)
+		for _, suffix := range []string{" NULLS FIRST", " NULLS LAST"} {
+			if strings.HasSuffix(strings.ToUpper(col), suffix) {
+				col = strings.TrimSpace(col[:len(col)-len(suffix)])
+			}
+		}
+		var desc bool
+		if strings.HasSuffix(strings.ToUpper(col), " DESC") {
+			col, desc = strings.TrimSpace(col[:len(col)-5]), true
+		} else if strings.HasSuffix(strings.ToUpper(col), " ASC") {
+			col = strings.TrimSpace(col[:len(col)-4])
+		}
+		if schemas.IsIndexExpr(col) {
+			if inner, left := cutParentheses(col); left == "" && inner != "" {
+				col = strings.TrimSpace(inner)
+			}
+		} else {
+			col = strings.Trim(col, "`[]\"")
+		}
+		index.AddColumn(col)
+		if desc {
+			index.SetDesc(col)
+		}
+	}
+
+	rest = strings.TrimSpace(rest)
+	if strings.HasPrefix(strings.ToUpper(rest), "INCLUDE") {
+		var include string
+		include, rest = cutParentheses(strings.TrimSpace(rest[len("INCLUDE"):]))
+		for _, col := range splitColumnDefs(include) {
+			index.AddInclude(strings.Trim(strings.TrimSpace(col), "`[]\""))
+		}
+		rest = strings.TrimSpace(rest)
+	}
+	if strings.HasPrefix(strings.ToUpper(rest), "WHERE") {
+		index.Where = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(rest[len("WHERE"):]), ";"))
+	}
+}
+
+// partitionKey returns the partition key of the partitioning, the column names are
+// quoted and the expressions are kept as they are
+func partitionKey(quoter schemas.Quoter, partitioning *schemas.Partitioning) string {
+	var b strings.Builder
+	for i, col := range partitioning.Cols {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		if schemas.IsIndexExpr(col) {
+			b.WriteString(col)
+		} else {
+			quoter.QuoteTo(&b, col)
+		}
+	}
+	return b.String()
+}
+
+// cutParentheses returns the content in the parentheses at the beginning of s and the left
+// string after the parentheses
+func cutParentheses(s string) (string, string) {
+	if !strings.HasPrefix(s, "(") {
+		return "", s
+	}
+	var depth int
+	var quote rune
+	for i, c := range s {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return s[1:i], s[i+1:]
+			}
+		}
+	}
+	return s[1:], ""
+}
+
+// SupportedForeignKey returns a copy of the foreign key with only the referential actions
+// which the database supports, it's what the database will report after the foreign key created.
+func SupportedForeignKey(dialect Dialect, fk *schemas.ForeignKey) *schemas.ForeignKey {
+	var res = *fk
+	res.OnDelete = schemas.ReferentialAction(fk.OnDelete)
+	res.OnUpdate = schemas.ReferentialAction(fk.OnUpdate)
+	switch dialect.URI().DBType {
+	case schemas.ORACLE:
+		if res.OnDelete != schemas.Cascade && res.OnDelete != schemas.SetNull {
+			res.OnDelete = schemas.NoAction
+		}
+		res.OnUpdate = schemas.NoAction
+	case schemas.MSSQL:
+		if res.OnDelete == schemas.Restrict {
+			res.OnDelete = schemas.NoAction
+		}
+		if res.OnUpdate == schemas.Restrict {
+			res.OnUpdate = schemas.NoAction
+		}
+	case schemas.DUCKDB:
+		// duckdb doesn't support the referential actions
+		res.OnDelete, res.OnUpdate = schemas.NoAction, schemas.NoAction
+	}
+	return &res
+}
+
+// ForeignKeyString generates the foreign key constraint description according dialect
+func ForeignKeyString(dialect Dialect, tableName string, fk *schemas.ForeignKey) string {
+	quoter := dialect.Quoter()
+	fk = SupportedForeignKey(dialect, fk)
+
+	var b strings.Builder
+	b.WriteString("CONSTRAINT ")
+	quoter.QuoteTo(&b, ForeignKeyName(dialect, tableName, fk))
+	b.WriteString(" FOREIGN KEY (")
+	quoter.JoinWrite(&b, fk.Cols, ",")
+	b.WriteString(") REFERENCES ")
+	quoter.QuoteTo(&b, fk.RefTable)
+	b.WriteString(" (")
+	quoter.JoinWrite(&b, fk.RefCols, ",")
+	b.WriteString(")")
+	if fk.OnDelete != schemas.NoAction {
+		b.WriteString(" ON DELETE ")
+		b.WriteString(fk.OnDelete)
+	}
+	if fk.OnUpdate != schemas.NoAction {
+		b.WriteString(" ON UPDATE ")
+		b.WriteString(fk.OnUpdate)
+	}
+	return b.String()
+}
+
+// writeForeignKeys writes all the foreign keys of the table in name order as a part of
+// a create table SQL
+func writeForeignKeys(b *strings.Builder, dialect Dialect, table *schemas.Table, tableName string) {
+	names := make([]string, 0, len(table.ForeignKeys))
+	for name := range table.ForeignKeys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b.WriteString(", ")
+		b.WriteString(ForeignKeyString(dialect, tableName, table.ForeignKeys[name]))
+	}
+}
+
+// ReplaceViewSQLs returns the SQLs which replace the view from with the view to, the view is
+// dropped and created again if the database cannot replace it
+func ReplaceViewSQLs(dialect Dialect, viewName string, from, to *schemas.View) ([]string, error) {
+	if from.Materialized == to.Materialized {
+		if sqlStr := dialect.ReplaceViewSQL(viewName, to); sqlStr != "" {
+			return []string{sqlStr}, nil
+		}
+	}
+	sqlStr, err := dialect.CreateViewSQL(viewName, to)
+	if err != nil {
+		return nil, err
+	}
+	return []string{dialect.DropViewSQL(viewName, from), sqlStr}, nil
+}
+
+// AddColumnSQLs returns the SQLs which add the column, NOT NULL is set by a separated SQL
+// if the database cannot add a column with the constraint
+func AddColumnSQLs(dialect Dialect, tableName string, col *schemas.Column) []string {
+	sqls := []string{dialect.AddColumnSQL(tableName, col)}
+	if !col.Nullable && dialect.Features().AddColumnNullable {
+		sqls = append(sqls, dialect.AlterColumnNullableSQL(tableName, col))
+	}
+	return sqls
+}
+
+// CheckString generates the check constraint description according dialect
+func CheckString(dialect Dialect, tableName string, check *schemas.Check) string {
+	return fmt.Sprintf("CONSTRAINT %s CHECK (%s)", dialect.Quoter().Quote(CheckName(dialect, tableName, check)), check.Expr)
+}
+
+// writeChecks writes all the check constraints of the table in name order as a part of
+// a create table SQL
+func writeChecks(b *strings.Builder, dialect Dialect, table *schemas.Table, tableName string) {
+	names := make([]string, 0, len(table.Checks))
+	for name := range table.Checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b.WriteString(", ")
+		b.WriteString(CheckString(dialect, tableName, table.Checks[name]))
+	}
+}
+
+// queryChecks reads the check constraints from a query which returns the constraint
+// name and the expression
+func queryChecks(queryer core.Queryer, ctx context.Context, query string, args ...interface{}) (map[string]*schemas.Check, error) {
+	rows, err := queryer.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	checks := make(map[string]*schemas.Check)
+	for rows.Next() {
+		var name, expr string
+		if err = rows.Scan(&name, &expr); err != nil {
+			return nil, err
+		}
+		checks[name] = schemas.NewCheck(name, expr)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return checks, nil
+}
+
+var viewPrefixReg = regexp.MustCompile(`(?is)^\s*CREATE\s.*?\bVIEW\s.*?\sAS\s`)
+
+// queryViews reads the views from a query which returns the view name and the definition,
+// the definitions which begin with CREATE VIEW will be trimmed to the queries
+func queryViews(queryer core.Queryer, ctx context.Context, materialized bool, query string, args ...interface{}) ([]*schemas.Table, error) {
+	rows, err := queryer.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tables := make([]*schemas.Table, 0)
+	for rows.Next() {
+		var name string
+		var definition sql.NullString
+		if err = rows.Scan(&name, &definition); err != nil {
+			return nil, err
+		}
+		table := schemas.NewEmptyTable()
+		table.Name = name
+		table.View = schemas.NewView(strings.TrimSpace(viewPrefixReg.ReplaceAllString(definition.String, "")), materialized)
+		tables = append(tables, table)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return tables, nil
+}
+
+// queryForeignKeys reads the foreign keys from a query which returns the constraint name,
+// the column, the referenced table, the referenced column, the update rule and the delete rule
+// of every foreign key column in sequence
+func queryForeignKeys(queryer core.Queryer, ctx context.Context, query string, args ...interface{}) (map[string]*schemas.ForeignKey, error) {
+	rows, err := queryer.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fks := make(map[string]*schemas.ForeignKey)
+	for rows.Next() {
+		var name, colName, refTable, refColName, updateRule, deleteRule string
+		if err = rows.Scan(&name, &colName, &refTable, &refColName, &updateRule, &deleteRule); err != nil {
+			return nil, err
+		}
+		fk, ok := fks[name]
+		if !ok {
+			fk = schemas.NewForeignKey(name, refTable)
+			fk.OnUpdate = schemas.ReferentialAction(updateRule)
+			fk.OnDelete = schemas.ReferentialAction(deleteRule)
+			fks[name] = fk
+		}
+		fk.AddColumn(colName, refColName)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return fks, nil
+}
+
+// defaultValue returns the default value expression of the column
+func defaultValue(col *schemas.Column) string {
+	if col.Default == "" {
+		return "''"
+	}
+	return col.Default
+}
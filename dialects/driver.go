@@ -5,7 +5,9 @@
 package dialects
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -83,3 +85,15 @@ type baseDriver struct{}
 func (b *baseDriver) Scan(ctx *ScanContext, rows *core.Rows, types []*sql.ColumnType, v ...interface{}) error {
 	return rows.Scan(v...)
 }
+
+// ErrBulkLoadUnsupported is returned by a BulkLoader when the bulk loading is unavailable
+// for the connection, then the records should be inserted in the normal way
+var ErrBulkLoadUnsupported = errors.New("bulk loading is unsupported")
+
+// BulkLoader represents a driver which could load records into a table in bulk,
+// like COPY FROM STDIN of postgres
+type BulkLoader interface {
+	// BulkLoad loads the rows returned by next until io.EOF into the quoted table and columns,
+	// tx is nil when it's not in a transaction. It returns the number of loaded rows.
+	BulkLoad(ctx context.Context, db *core.DB, tx *core.Tx, tableName string, colNames []string, next func() ([]interface{}, error)) (int64, error)
+}
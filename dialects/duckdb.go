@@ -0,0 +1,963 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dialects
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"xorm.io/xorm/convert"
+	"xorm.io/xorm/core"
+	"xorm.io/xorm/internal/json"
+	"xorm.io/xorm/internal/utils"
+	"xorm.io/xorm/schemas"
+)
+
+// from https://duckdb.org/docs/sql/keywords_and_identifiers, the reserved keywords
+var (
+	duckdbReservedWords = map[string]bool{
+		"ALL":          true,
+		"ANALYSE":      true,
+		"ANALYZE":      true,
+		"AND":          true,
+		"ANY":          true,
+		"ARRAY":        true,
+		"AS":           true,
+		"ASC":          true,
+		"ASYMMETRIC":   true,
+		"BOTH":         true,
+		"CASE":         true,
+		"CAST":         true,
+		"CHECK":        true,
+		"COLLATE":      true,
+		"COLUMN":       true,
+		"CONSTRAINT":   true,
+		"CREATE":       true,
+		"DEFAULT":      true,
+		"DEFERRABLE":   true,
+		"DESC":         true,
+		"DESCRIBE":     true,
+		"DISTINCT":     true,
+		"DO":           true,
+		"ELSE":         true,
+		"END":          true,
+		"EXCEPT":       true,
+		"FALSE":        true,
+		"FETCH":        true,
+		"FOR":          true,
+		"FOREIGN":      true,
+		"FROM":         true,
+		"GRANT":        true,
+		"GROUP":        true,
+		"HAVING":       true,
+		"IN":           true,
+		"INITIALLY":    true,
+		"INTERSECT":    true,
+		"INTO":         true,
+		"LATERAL":      true,
+		"LEADING":      true,
+		"LIMIT":        true,
+		"NOT":          true,
+		"NULL":         true,
+		"OFFSET":       true,
+		"ON":           true,
+		"ONLY":         true,
+		"OR":           true,
+		"ORDER":        true,
+		"PIVOT":        true,
+		"PIVOT_LONGER": true,
+		"PIVOT_WIDER":  true,
+		"PLACING":      true,
+		"PRIMARY":      true,
+		"QUALIFY":      true,
+		"REFERENCES":   true,
+		"RETURNING":    true,
+		"SELECT":       true,
+		"SHOW":         true,
+		"SOME":         true,
+		"SUMMARIZE":    true,
+		"SYMMETRIC":    true,
+		"TABLE":        true,
+		"THEN":         true,
+		"TO":           true,
+		"TRAILING":     true,
+		"TRUE":         true,
+		"UNION":        true,
+		"UNIQUE":       true,
+		"UNPIVOT":      true,
+		"USING":        true,
+		"VARIADIC":     true,
+		"WHEN":         true,
+		"WHERE":        true,
+		"WINDOW":       true,
+		"WITH":         true,
+	}
+
+	duckdbQuoter = schemas.Quoter{
+		Prefix:     '"',
+		Suffix:     '"',
+		IsReserved: schemas.AlwaysReserve,
+	}
+)
+
+// DefaultDuckDBSchema default duckdb schema
+var DefaultDuckDBSchema = "main"
+
+// duckdb represents the dialect of duckdb. Because of the index limitations of duckdb, the
+// tables with indexes may not be altered and the indexed columns may not be updated in place.
+type duckdb struct {
+	Base
+}
+
+func (db *duckdb) Init(uri *URI) error {
+	db.quoter = duckdbQuoter
+	return db.Base.Init(db, uri)
+}
+
+func (db *duckdb) Version(ctx context.Context, queryer core.Queryer) (*schemas.Version, error) {
+	rows, err := queryer.QueryContext(ctx, "SELECT version()")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var version string
+	if !rows.Next() {
+		if rows.Err() != nil {
+			return nil, rows.Err()
+		}
+		return nil, errors.New("unknow version")
+	}
+
+	if err := rows.Scan(&version); err != nil {
+		return nil, err
+	}
+
+	// v1.1.3
+	return &schemas.Version{
+		Number:  strings.TrimPrefix(version, "v"),
+		Edition: "DuckDB",
+	}, nil
+}
+
+func (db *duckdb) Features() *DialectFeatures {
+	return &DialectFeatures{
+		AutoincrMode:      SequenceAutoincrMode,
+		SupportReturning:  true,
+		AddColumnNullable: true,
+		UnnamedChecks:     true,
+	}
+}
+
+// schemaOf returns the schema and the name of the table, the default schema is main
+func (db *duckdb) schemaOf(ctx context.Context, tableName string) (string, string) {
+	schema, tableName := db.tableSchema(ctx, tableName)
+	if schema == "" {
+		schema = DefaultDuckDBSchema
+	}
+	return schema, tableName
+}
+
+func (db *duckdb) SetQuotePolicy(quotePolicy QuotePolicy) {
+	switch quotePolicy {
+	case QuotePolicyNone:
+		q := duckdbQuoter
+		q.IsReserved = schemas.AlwaysNoReserve
+		db.quoter = q
+	case QuotePolicyReserved:
+		q := duckdbQuoter
+		q.IsReserved = db.IsReserved
+		db.quoter = q
+	case QuotePolicyAlways:
+		fallthrough
+	default:
+		db.quoter = duckdbQuoter
+	}
+}
+
+func (db *duckdb) SQLType(c *schemas.Column) string {
+	var res string
+	switch t := c.SQLType.Name; t {
+	case schemas.Bit, schemas.Bool, schemas.Boolean:
+		return schemas.Boolean
+	case schemas.TinyInt:
+		return schemas.TinyInt
+	case schemas.UnsignedTinyInt:
+		return "UTINYINT"
+	case schemas.SmallInt:
+		return schemas.SmallInt
+	case schemas.UnsignedSmallInt:
+		return "USMALLINT"
+	case schemas.MediumInt, schemas.Int, schemas.Integer, schemas.Year:
+		return schemas.Integer
+	case schemas.UnsignedMediumInt, schemas.UnsignedInt:
+		return "UINTEGER"
+	case schemas.BigInt:
+		return schemas.BigInt
+	case schemas.UnsignedBigInt:
+		return "UBIGINT"
+	case schemas.Serial:
+		c.IsAutoIncrement = true
+		c.Nullable = false
+		return schemas.Integer
+	case schemas.BigSerial:
+		c.IsAutoIncrement = true
+		c.Nullable = false
+		return schemas.BigInt
+	case schemas.Float, schemas.Real:
+		return schemas.Float
+	case schemas.Double:
+		return schemas.Double
+	case schemas.Decimal, schemas.Numeric, schemas.Money, schemas.SmallMoney:
+		res = schemas.Decimal
+	case schemas.Char, schemas.NChar, schemas.Varchar, schemas.NVarchar, schemas.VARCHAR2,
+		schemas.TinyText, schemas.Text, schemas.NText, schemas.MediumText, schemas.LongText,
+		schemas.Clob, schemas.SysName, schemas.Enum, schemas.Set, schemas.XML:
+		// the length of varchar is ignored by duckdb
+		return schemas.Varchar
+	case schemas.Binary, schemas.VarBinary, schemas.TinyBlob, schemas.Blob, schemas.MediumBlob,
+		schemas.LongBlob, schemas.Bytea, schemas.UniqueIdentifier:
+		return schemas.Blob
+	case schemas.DateTime, schemas.TimeStamp, schemas.SmallDateTime:
+		return schemas.TimeStamp
+	case schemas.TimeStampz:
+		return "TIMESTAMPTZ"
+	case schemas.Json, schemas.Jsonb:
+		return schemas.Json
+	case schemas.List:
+		if len(c.TypeParams) == 0 {
+			return schemas.Varchar + "[]"
+		}
+		return c.TypeParams[0] + "[]"
+	case schemas.Struct:
+		return schemas.Struct + "(" + strings.Join(c.TypeParams, ", ") + ")"
+	case schemas.Map:
+		if len(c.TypeParams) != 2 {
+			return schemas.Map + "(" + schemas.Varchar + ", " + schemas.Varchar + ")"
+		}
+		return schemas.Map + "(" + c.TypeParams[0] + ", " + c.TypeParams[1] + ")"
+	default:
+		res = t
+	}
+
+	hasLen1 := (c.Length > 0)
+	hasLen2 := (c.Length2 > 0)
+
+	if hasLen2 {
+		res += "(" + strconv.Itoa(c.Length) + "," + strconv.Itoa(c.Length2) + ")"
+	} else if hasLen1 {
+		res += "(" + strconv.Itoa(c.Length) + ")"
+	}
+	return res
+}
+
+func (db *duckdb) ColumnTypeKind(t string) int {
+	switch strings.ToUpper(t) {
+	case "DATE", "TIME", "TIMESTAMP", "TIMESTAMPTZ", "TIMESTAMP WITH TIME ZONE":
+		return schemas.TIME_TYPE
+	case "VARCHAR", "UUID", "JSON":
+		return schemas.TEXT_TYPE
+	case "TINYINT", "SMALLINT", "INTEGER", "BIGINT", "HUGEINT", "UTINYINT", "USMALLINT", "UINTEGER",
+		"UBIGINT", "FLOAT", "DOUBLE", "DECIMAL":
+		return schemas.NUMERIC_TYPE
+	case "BOOLEAN":
+		return schemas.BOOL_TYPE
+	case "BLOB":
+		return schemas.BLOB_TYPE
+	default:
+		return schemas.UNKNOW_TYPE
+	}
+}
+
+func (db *duckdb) IsReserved(name string) bool {
+	_, ok := duckdbReservedWords[strings.ToUpper(name)]
+	return ok
+}
+
+func (db *duckdb) AutoIncrStr() string {
+	return ""
+}
+
+func (db *duckdb) IndexCheckSQL(tableName, idxName string) (string, []interface{}) {
+	schema, tableName := db.schemaOf(context.Background(), tableName)
+	args := []interface{}{schema, tableName, idxName}
+	return `SELECT index_name FROM duckdb_indexes() WHERE schema_name = ? AND table_name = ? AND index_name = ?`, args
+}
+
+func (db *duckdb) IsTableExist(queryer core.Queryer, ctx context.Context, tableName string) (bool, error) {
+	schema, tableName := db.schemaOf(ctx, tableName)
+	return db.HasRecords(queryer, ctx, `SELECT table_name FROM information_schema.tables WHERE table_catalog = current_database()
+AND table_type = 'BASE TABLE' AND table_schema = ? AND table_name = ?`, schema, tableName)
+}
+
+func (db *duckdb) IsColumnExist(queryer core.Queryer, ctx context.Context, tableName, colName string) (bool, error) {
+	schema, tableName := db.schemaOf(ctx, tableName)
+	return db.HasRecords(queryer, ctx, `SELECT column_name FROM information_schema.columns WHERE table_catalog = current_database()
+AND table_schema = ? AND table_name = ? AND column_name = ?`, schema, tableName, colName)
+}
+
+// AddColumnSQL returns the SQL to add a column as nullable, duckdb cannot add a column with
+// constraints so the not null constraint is set by another SQL, see AddColumnSQLs
+func (db *duckdb) AddColumnSQL(tableName string, col *schemas.Column) string {
+	nullableCol := *col
+	nullableCol.Nullable = true
+	s, _ := ColumnString(db, &nullableCol, false)
+	return fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", db.quoter.Quote(tableName), s)
+}
+
+func (db *duckdb) ModifyColumnSQL(tableName string, col *schemas.Column) string {
+	return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s", db.quoter.Quote(tableName), db.quoter.Quote(col.Name), db.SQLType(col))
+}
+
+func (db *duckdb) AlterColumnNullableSQL(tableName string, col *schemas.Column) string {
+	action := "DROP NOT NULL"
+	if !col.Nullable {
+		action = "SET NOT NULL"
+	}
+	return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s", db.quoter.Quote(tableName), db.quoter.Quote(col.Name), action)
+}
+
+func (db *duckdb) DropIndexSQL(tableName string, index *schemas.Index) string {
+	idxName := IndexName(db, tableName, index)
+	schema, _ := db.schemaOf(context.Background(), tableName)
+	return fmt.Sprintf("DROP INDEX %v", db.quoter.Quote(schema+"."+idxName))
+}
+
+// AddForeignKeySQL returns an empty string since duckdb cannot add foreign keys by altering tables
+func (db *duckdb) AddForeignKeySQL(tableName string, fk *schemas.ForeignKey) string {
+	return ""
+}
+
+// DropForeignKeySQL returns an empty string since duckdb cannot drop foreign keys by altering tables
+func (db *duckdb) DropForeignKeySQL(tableName string, fk *schemas.ForeignKey) string {
+	return ""
+}
+
+// AddCheckSQL returns an empty string since duckdb cannot add check constraints by altering tables
+func (db *duckdb) AddCheckSQL(tableName string, check *schemas.Check) string {
+	return ""
+}
+
+// DropCheckSQL returns an empty string since duckdb cannot drop check constraints by altering tables
+func (db *duckdb) DropCheckSQL(tableName string, check *schemas.Check) string {
+	return ""
+}
+
+// CreateSequenceSQL returns a SQL to create the sequence of the autoincrement column
+func (db *duckdb) CreateSequenceSQL(ctx context.Context, queryer core.Queryer, seqName string) (string, error) {
+	return fmt.Sprintf("CREATE SEQUENCE IF NOT EXISTS %s START 1", db.quoter.Quote(seqName)), nil
+}
+
+func (db *duckdb) IsSequenceExist(ctx context.Context, queryer core.Queryer, seqName string) (bool, error) {
+	schema, seqName := db.schemaOf(ctx, seqName)
+	return db.HasRecords(queryer, ctx, `SELECT sequence_name FROM duckdb_sequences() WHERE database_name = current_database()
+AND schema_name = ? AND lower(sequence_name) = lower(?)`, schema, seqName)
+}
+
+func (db *duckdb) DropSequenceSQL(seqName string) (string, error) {
+	return fmt.Sprintf("DROP SEQUENCE IF EXISTS %s", db.quoter.Quote(seqName)), nil
+}
+
+// sequenceOf returns the sequence of the table, the temporary table to rebuild the table
+// shares the sequence of the rebuilt table
+func (db *duckdb) sequenceOf(tableName string) string {
+	schema, name := SplitTableName(tableName)
+	return SequenceName(db, TableNameInSchema(schema, strings.TrimPrefix(name, rebuildTablePrefix)))
+}
+
+// CreateTableSQL returns a SQL to create the table, the autoincrement column takes the next
+// value of the sequence of the table by default
+func (db *duckdb) CreateTableSQL(ctx context.Context, queryer core.Queryer, table *schemas.Table, tableName string) (string, bool, error) {
+	if tableName == "" {
+		tableName = table.Name
+	}
+
+	quoter := db.Quoter()
+	var b strings.Builder
+	b.WriteString("CREATE TABLE IF NOT EXISTS ")
+	if err := quoter.QuoteTo(&b, tableName); err != nil {
+		return "", false, err
+	}
+	b.WriteString(" (")
+
+	for i, colName := range table.ColumnsSeq() {
+		col := table.GetColumn(colName)
+		if col.IsAutoIncrement {
+			seqCol := *col
+			seqCol.Default = fmt.Sprintf("nextval('%s')", db.sequenceOf(tableName))
+			seqCol.DefaultIsEmpty = false
+			seqCol.IsAutoIncrement = false
+			col = &seqCol
+		}
+		s, err := ColumnString(db, col, col.IsPrimaryKey && len(table.PrimaryKeys) == 1)
+		if err != nil {
+			return "", false, err
+		}
+		b.WriteString(s)
+
+		if i != len(table.ColumnsSeq())-1 {
+			b.WriteString(", ")
+		}
+	}
+
+	if len(table.PrimaryKeys) > 1 {
+		b.WriteString(", PRIMARY KEY (")
+		b.WriteString(quoter.Join(table.PrimaryKeys, ","))
+		b.WriteString(")")
+	}
+
+	writeForeignKeys(&b, db, table, tableName)
+	writeChecks(&b, db, table, tableName)
+
+	b.WriteString(")")
+
+	return b.String(), true, nil
+}
+
+// RebuildTableSQLs returns SQLs to rebuild a table, the data are copied to a temporary table
+// and back to the recreated table since duckdb keeps the foreign keys of a renamed table
+// pointing at its old name, so the referenced tables could not be dropped anymore
+func (db *duckdb) RebuildTableSQLs(ctx context.Context, queryer core.Queryer, table *schemas.Table, tableName string) ([]string, error) {
+	if tableName == "" {
+		tableName = table.Name
+	}
+	cols, srcCols, err := db.rebuildColumns(ctx, queryer, table, tableName)
+	if err != nil {
+		return nil, err
+	}
+	tmpTableName := rebuildTableName(SplitTableName(tableName))
+
+	dropTmpSQL, _ := db.DropTableSQL(tmpTableName)
+	createSQL, _, err := db.CreateTableSQL(ctx, queryer, table, tableName)
+	if err != nil {
+		return nil, err
+	}
+	dropSQL, _ := db.DropTableSQL(tableName)
+
+	var sqls = []string{
+		dropTmpSQL,
+		fmt.Sprintf("CREATE TABLE %s AS SELECT * FROM %s", db.quoter.Quote(tmpTableName), db.quoter.Quote(tableName)),
+		dropSQL,
+		createSQL,
+		fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s", db.quoter.Quote(tableName),
+			db.quoter.Join(cols, ", "), db.quoter.Join(srcCols, ", "), db.quoter.Quote(tmpTableName)),
+		dropTmpSQL,
+	}
+	for _, index := range table.Indexes {
+		sqls = append(sqls, db.CreateIndexSQL(tableName, index))
+	}
+	return sqls, nil
+}
+
+func (db *duckdb) GetColumns(queryer core.Queryer, ctx context.Context, tableName string) ([]string, map[string]*schemas.Column, error) {
+	schema, tableName := db.schemaOf(ctx, tableName)
+	pks, err := db.primaryKeys(queryer, ctx, schema, tableName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s := `SELECT column_name, column_default, is_nullable, data_type FROM information_schema.columns
+WHERE table_catalog = current_database() AND table_schema = ? AND table_name = ? ORDER BY ordinal_position`
+	rows, err := queryer.QueryContext(ctx, s, schema, tableName)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	cols := make(map[string]*schemas.Column)
+	colSeq := make([]string, 0)
+	for rows.Next() {
+		col := new(schemas.Column)
+		col.Indexes = make(map[string]int)
+
+		var colName, isNullable, dataType string
+		var colDefault *string
+		if err = rows.Scan(&colName, &colDefault, &isNullable, &dataType); err != nil {
+			return nil, nil, err
+		}
+
+		col.Name = colName
+		col.Nullable = (isNullable == "YES")
+		col.IsPrimaryKey = utils.ContainsFold(pks, colName)
+		if err = parseDuckDBType(col, dataType); err != nil {
+			return nil, nil, err
+		}
+
+		col.DefaultIsEmpty = true
+		if colDefault != nil {
+			if strings.HasPrefix(*colDefault, "nextval(") {
+				col.IsAutoIncrement = true
+			} else {
+				col.Default = *colDefault
+				col.DefaultIsEmpty = false
+				// the default values are like CAST('a' AS VARCHAR)
+				if strings.HasPrefix(col.Default, "CAST(") && strings.HasSuffix(col.Default, ")") {
+					if idx := strings.LastIndex(col.Default, " AS "); idx > 0 {
+						col.Default = col.Default[len("CAST("):idx]
+					}
+				}
+				// the boolean default values are like CAST('t' AS BOOLEAN)
+				if col.SQLType.Name == schemas.Boolean {
+					switch col.Default {
+					case "'t'":
+						col.Default = "true"
+					case "'f'":
+						col.Default = "false"
+					}
+				}
+			}
+		}
+
+		cols[col.Name] = col
+		colSeq = append(colSeq, col.Name)
+	}
+	if rows.Err() != nil {
+		return nil, nil, rows.Err()
+	}
+	return colSeq, cols, nil
+}
+
+func (db *duckdb) primaryKeys(queryer core.Queryer, ctx context.Context, schema, tableName string) ([]string, error) {
+	rows, err := queryer.QueryContext(ctx, `SELECT unnest(constraint_column_names) FROM duckdb_constraints()
+WHERE database_name = current_database() AND schema_name = ? AND table_name = ? AND constraint_type = 'PRIMARY KEY'`, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pks []string
+	for rows.Next() {
+		var pk string
+		if err = rows.Scan(&pk); err != nil {
+			return nil, err
+		}
+		pks = append(pks, pk)
+	}
+	return pks, rows.Err()
+}
+
+// parseDuckDBType parses the data type reported by duckdb like DECIMAL(18,3), INTEGER[],
+// STRUCT(a INTEGER, b VARCHAR) or MAP(VARCHAR, INTEGER) as the column type
+func parseDuckDBType(col *schemas.Column, dataType string) error {
+	upperType := strings.ToUpper(strings.TrimSpace(dataType))
+	switch {
+	case strings.HasSuffix(upperType, "[]"):
+		col.SQLType = schemas.SQLType{Name: schemas.List}
+		col.TypeParams = []string{strings.TrimSpace(dataType[:len(dataType)-2])}
+		col.IsJSON = true
+		return nil
+	case strings.HasPrefix(upperType, schemas.Struct+"("), strings.HasPrefix(upperType, schemas.Map+"("):
+		name := upperType[:strings.IndexByte(upperType, '(')]
+		params, _ := cutParentheses(strings.TrimSpace(dataType[len(name):]))
+		col.SQLType = schemas.SQLType{Name: name}
+		for _, param := range splitColumnDefs(params) {
+			col.TypeParams = append(col.TypeParams, strings.TrimSpace(param))
+		}
+		col.IsJSON = true
+		return nil
+	case strings.HasPrefix(upperType, schemas.Decimal+"("):
+		params, _ := cutParentheses(upperType[len(schemas.Decimal):])
+		col.SQLType = schemas.SQLType{Name: schemas.Decimal}
+		if _, err := fmt.Sscanf(params, "%d,%d", &col.Length, &col.Length2); err != nil {
+			return fmt.Errorf("unknown colType: %s", dataType)
+		}
+		return nil
+	}
+
+	switch upperType {
+	case "BOOLEAN":
+		col.SQLType = schemas.SQLType{Name: schemas.Boolean}
+	case "UTINYINT":
+		col.SQLType = schemas.SQLType{Name: schemas.UnsignedTinyInt}
+	case "USMALLINT":
+		col.SQLType = schemas.SQLType{Name: schemas.UnsignedSmallInt}
+	case "UINTEGER":
+		col.SQLType = schemas.SQLType{Name: schemas.UnsignedInt}
+	case "UBIGINT":
+		col.SQLType = schemas.SQLType{Name: schemas.UnsignedBigInt}
+	case "HUGEINT", "UHUGEINT":
+		col.SQLType = schemas.SQLType{Name: schemas.Decimal}
+		col.Length = 38
+	case "TIMESTAMP":
+		col.SQLType = schemas.SQLType{Name: schemas.DateTime}
+	case "TIMESTAMP WITH TIME ZONE", "TIMESTAMPTZ":
+		col.SQLType = schemas.SQLType{Name: schemas.TimeStampz}
+	case "JSON":
+		col.SQLType = schemas.SQLType{Name: schemas.Json}
+		col.IsJSON = true
+	default:
+		col.SQLType = schemas.SQLType{Name: upperType}
+	}
+	if _, ok := schemas.SqlTypes[col.SQLType.Name]; !ok {
+		return fmt.Errorf("unknown colType: %s", dataType)
+	}
+	return nil
+}
+
+func (db *duckdb) GetTables(queryer core.Queryer, ctx context.Context) ([]*schemas.Table, error) {
+	schema, _ := db.schemaOf(ctx, "")
+	rows, err := queryer.QueryContext(ctx, `SELECT table_name FROM information_schema.tables WHERE table_catalog = current_database()
+AND table_type = 'BASE TABLE' AND table_schema = ? ORDER BY table_name`, schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tables := make([]*schemas.Table, 0)
+	for rows.Next() {
+		table := schemas.NewEmptyTable()
+		var name string
+		if err = rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		table.Name = name
+		tables = append(tables, table)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return tables, nil
+}
+
+func (db *duckdb) GetViews(queryer core.Queryer, ctx context.Context) ([]*schemas.Table, error) {
+	schema, _ := db.schemaOf(ctx, "")
+	views, err := queryViews(queryer, ctx, false, `SELECT view_name, sql FROM duckdb_views()
+WHERE NOT internal AND database_name = current_database() AND schema_name = ?`, schema)
+	if err != nil {
+		return nil, err
+	}
+	// the definitions are like CREATE VIEW v AS SELECT ...;
+	for _, view := range views {
+		view.View.Query = strings.TrimSpace(strings.TrimSuffix(view.View.Query, ";"))
+	}
+	return views, nil
+}
+
+func (db *duckdb) GetIndexes(queryer core.Queryer, ctx context.Context, tableName string) (map[string]*schemas.Index, error) {
+	schema, tableName := db.schemaOf(ctx, tableName)
+	rows, err := queryer.QueryContext(ctx, `SELECT index_name, sql, is_unique FROM duckdb_indexes()
+WHERE database_name = current_database() AND schema_name = ? AND table_name = ?`, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	indexes := make(map[string]*schemas.Index)
+	for rows.Next() {
+		var indexName string
+		var indexSQL sql.NullString
+		var isUnique bool
+		if err = rows.Scan(&indexName, &indexSQL, &isUnique); err != nil {
+			return nil, err
+		}
+
+		index := schemas.NewIndex("", schemas.IndexType)
+		if isUnique {
+			index.Type = schemas.UniqueType
+		}
+		parseIndexSQL(index, strings.TrimSuffix(strings.TrimSpace(indexSQL.String), ";"))
+
+		indexName, index.IsRegular = regularIndexName(db, tableName, indexName)
+		index.Name = indexName
+		indexes[index.Name] = index
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return indexes, nil
+}
+
+// GetForeignKeys returns the foreign keys of the table, duckdb doesn't keep the names of
+// the constraints so the foreign keys are named after their first columns
+func (db *duckdb) GetForeignKeys(queryer core.Queryer, ctx context.Context, tableName string) (map[string]*schemas.ForeignKey, error) {
+	schema, tableName := db.schemaOf(ctx, tableName)
+	rows, err := queryer.QueryContext(ctx, `SELECT constraint_text FROM duckdb_constraints()
+WHERE database_name = current_database() AND schema_name = ? AND table_name = ? AND constraint_type = 'FOREIGN KEY'
+ORDER BY constraint_index`, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[string]*schemas.ForeignKey)
+	for rows.Next() {
+		var text string
+		if err = rows.Scan(&text); err != nil {
+			return nil, err
+		}
+		fk := parseDuckDBForeignKey(schema, text)
+		if fk == nil {
+			continue
+		}
+		fk.Name = ForeignKeyName(db, tableName, schemas.NewForeignKey(fk.Cols[0], fk.RefTable))
+		res[fk.Name] = fk
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return res, nil
+}
+
+var duckdbForeignKeyReg = regexp.MustCompile(`(?s)^FOREIGN KEY\s*\((.*?)\)\s*REFERENCES\s+(.*)\((.*)\)$`)
+
+// parseDuckDBForeignKey parses the constraint text like FOREIGN KEY (user_id) REFERENCES user(id),
+// the referenced columns are not in the catalog of duckdb before 1.1. The referenced table in
+// the schema of the table is returned without the schema.
+func parseDuckDBForeignKey(schema, text string) *schemas.ForeignKey {
+	matches := duckdbForeignKeyReg.FindStringSubmatch(strings.TrimSpace(text))
+	if matches == nil {
+		return nil
+	}
+	cols := strings.Split(matches[1], ",")
+	refCols := strings.Split(matches[3], ",")
+	if len(cols) != len(refCols) {
+		return nil
+	}
+	refTable := strings.TrimSpace(matches[2])
+	if refSchema, name := SplitTableName(refTable); refSchema != "" && refSchema == schema {
+		refTable = name
+	}
+
+	fk := schemas.NewForeignKey("", refTable)
+	fk.OnUpdate = schemas.NoAction
+	fk.OnDelete = schemas.NoAction
+	for i, col := range cols {
+		fk.AddColumn(strings.TrimSpace(col), strings.TrimSpace(refCols[i]))
+	}
+	return fk
+}
+
+// GetChecks returns the check constraints of the table, duckdb doesn't keep the names of
+// the constraints so the checks on one column are named after the column
+func (db *duckdb) GetChecks(queryer core.Queryer, ctx context.Context, tableName string) (map[string]*schemas.Check, error) {
+	schema, tableName := db.schemaOf(ctx, tableName)
+	checks, err := queryChecks(queryer, ctx, `SELECT CASE WHEN len(constraint_column_names) = 1 THEN constraint_column_names[1]
+ELSE CAST(constraint_index AS VARCHAR) END, expression FROM duckdb_constraints()
+WHERE database_name = current_database() AND schema_name = ? AND table_name = ? AND constraint_type = 'CHECK'`, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]*schemas.Check, len(checks))
+	for _, check := range checks {
+		check.Name = CheckName(db, tableName, check)
+		res[check.Name] = check
+	}
+	return res, nil
+}
+
+func (db *duckdb) Filters() []Filter {
+	return []Filter{}
+}
+
+type duckdbDriver struct {
+	baseDriver
+}
+
+func (p *duckdbDriver) Features() *DriverFeatures {
+	return &DriverFeatures{
+		SupportReturnInsertedID: false,
+	}
+}
+
+// Parse parses the datasource like /path/to/file.db?access_mode=read_only, an empty path
+// or :memory: means an in-memory database
+func (p *duckdbDriver) Parse(driverName, dataSourceName string) (*URI, error) {
+	if idx := strings.Index(dataSourceName, "?"); idx >= 0 {
+		dataSourceName = dataSourceName[:idx]
+	}
+	if dataSourceName == "" {
+		dataSourceName = ":memory:"
+	}
+	return &URI{DBType: schemas.DUCKDB, DBName: dataSourceName}, nil
+}
+
+func (p *duckdbDriver) GenScanResult(colType string) (interface{}, error) {
+	switch colType {
+	case "VARCHAR", "UUID", "JSON", "HUGEINT", "UHUGEINT", "UBIGINT":
+		var s sql.NullString
+		return &s, nil
+	case "BIGINT", "INTEGER", "SMALLINT", "TINYINT", "UINTEGER", "USMALLINT", "UTINYINT":
+		var s sql.NullInt64
+		return &s, nil
+	case "FLOAT", "DOUBLE":
+		var s sql.NullFloat64
+		return &s, nil
+	case "DATE", "TIMESTAMP", "TIMESTAMPTZ", "TIMESTAMP WITH TIME ZONE":
+		var s sql.NullTime
+		return &s, nil
+	case "BOOLEAN":
+		var s sql.NullBool
+		return &s, nil
+	case "BLOB":
+		var r sql.RawBytes
+		return &r, nil
+	default:
+		var r sql.NullString
+		return &r, nil
+	}
+}
+
+// isDuckDBComplexType returns true if the values of the type are not the basic driver values,
+// they should be converted before assigned
+func isDuckDBComplexType(typeName string) bool {
+	switch {
+	case strings.HasSuffix(typeName, "[]"),
+		strings.HasPrefix(typeName, schemas.List),
+		strings.HasPrefix(typeName, schemas.Struct),
+		strings.HasPrefix(typeName, schemas.Map),
+		strings.HasPrefix(typeName, schemas.Decimal),
+		typeName == "UUID", typeName == "HUGEINT", typeName == "UHUGEINT", typeName == "INTERVAL":
+		return true
+	}
+	return false
+}
+
+// Scan converts the nested values to JSON, the UUIDs and the big numbers to strings
+func (p *duckdbDriver) Scan(ctx *ScanContext, rows *core.Rows, types []*sql.ColumnType, vv ...interface{}) error {
+	var scanResults = make([]interface{}, 0, len(types))
+	var replaces = make([]bool, 0, len(types))
+	for i, v := range vv {
+		if isDuckDBComplexType(types[i].DatabaseTypeName()) {
+			var scanResult interface{}
+			scanResults = append(scanResults, &scanResult)
+			replaces = append(replaces, true)
+		} else {
+			scanResults = append(scanResults, v)
+			replaces = append(replaces, false)
+		}
+	}
+
+	if err := rows.Scan(scanResults...); err != nil {
+		return err
+	}
+
+	for i, replaced := range replaces {
+		if !replaced {
+			continue
+		}
+		v, err := duckdbValue(types[i].DatabaseTypeName(), *(scanResults[i].(*interface{})))
+		if err != nil {
+			return err
+		}
+		if err := convert.Assign(vv[i], v, ctx.DBLocation, ctx.UserLocation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func duckdbValue(typeName string, v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	rv := reflect.ValueOf(v)
+	if typeName == "UUID" && (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && rv.Len() == 16 {
+		b := make([]byte, 16)
+		reflect.Copy(reflect.ValueOf(b), rv)
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+	}
+	switch t := v.(type) {
+	case string, []byte, time.Time:
+		return t, nil
+	}
+	if s, ok := duckdbDecimalString(rv); ok {
+		return s, nil
+	}
+	if !isDuckDBNestedValue(rv) {
+		// the values like *big.Int or the decimals which have String methods
+		if s, ok := v.(fmt.Stringer); ok {
+			return s.String(), nil
+		}
+		ptr := reflect.New(rv.Type())
+		ptr.Elem().Set(rv)
+		if s, ok := ptr.Interface().(fmt.Stringer); ok {
+			return s.String(), nil
+		}
+	}
+	bs, err := json.DefaultJSONHandler.Marshal(jsonCompatible(rv))
+	if err != nil {
+		return nil, err
+	}
+	return bs, nil
+}
+
+// duckdbDecimalString formats the Decimal value of go-duckdb, the unscaled value is kept in
+// a *big.Int and the old versions of the driver have no String method on it
+func duckdbDecimalString(v reflect.Value) (string, bool) {
+	if v.Kind() != reflect.Struct {
+		return "", false
+	}
+	scale, value := v.FieldByName("Scale"), v.FieldByName("Value")
+	if !scale.IsValid() || scale.Kind() != reflect.Uint8 || !value.IsValid() || !value.CanInterface() {
+		return "", false
+	}
+	unscaled, ok := value.Interface().(*big.Int)
+	if !ok || unscaled == nil {
+		return "", false
+	}
+
+	digits := new(big.Int).Abs(unscaled).String()
+	if n := int(scale.Uint()); n > 0 {
+		if len(digits) <= n {
+			digits = strings.Repeat("0", n-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-n] + "." + digits[len(digits)-n:]
+	}
+	if unscaled.Sign() < 0 {
+		digits = "-" + digits
+	}
+	return digits, true
+}
+
+func isDuckDBNestedValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// jsonCompatible converts the maps with non-string keys like the MAP values of duckdb to the
+// maps with string keys so that they could be marshaled as JSON
+func jsonCompatible(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		return jsonCompatible(v.Elem())
+	case reflect.Map:
+		m := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			m[fmt.Sprint(iter.Key().Interface())] = jsonCompatible(iter.Value())
+		}
+		return m
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Interface()
+		}
+		fallthrough
+	case reflect.Array:
+		s := make([]interface{}, v.Len())
+		for i := range s {
+			s[i] = jsonCompatible(v.Index(i))
+		}
+		return s
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dialects
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"xorm.io/xorm/schemas"
+)
+
+func TestDuckDBSQLType(t *testing.T) {
+	dialect := QueryDialect(schemas.DUCKDB)
+	assert.NoError(t, dialect.Init(&URI{DBType: schemas.DUCKDB}))
+
+	var kases = []struct {
+		col      *schemas.Column
+		expected string
+	}{
+		{&schemas.Column{SQLType: schemas.SQLType{Name: schemas.Int}}, "INTEGER"},
+		{&schemas.Column{SQLType: schemas.SQLType{Name: schemas.UnsignedBigInt}}, "UBIGINT"},
+		{&schemas.Column{SQLType: schemas.SQLType{Name: schemas.Varchar}, Length: 255}, "VARCHAR"},
+		{&schemas.Column{SQLType: schemas.SQLType{Name: schemas.Decimal}, Length: 10, Length2: 2}, "DECIMAL(10,2)"},
+		{&schemas.Column{SQLType: schemas.SQLType{Name: schemas.DateTime}}, "TIMESTAMP"},
+		{&schemas.Column{SQLType: schemas.SQLType{Name: schemas.Bytea}}, "BLOB"},
+		{&schemas.Column{SQLType: schemas.SQLType{Name: schemas.List}, TypeParams: []string{"INTEGER"}}, "INTEGER[]"},
+		{&schemas.Column{SQLType: schemas.SQLType{Name: schemas.Struct}, TypeParams: []string{"a INTEGER", "b VARCHAR"}}, "STRUCT(a INTEGER, b VARCHAR)"},
+		{&schemas.Column{SQLType: schemas.SQLType{Name: schemas.Map}, TypeParams: []string{"VARCHAR", "INTEGER"}}, "MAP(VARCHAR, INTEGER)"},
+	}
+	for _, kase := range kases {
+		assert.EqualValues(t, kase.expected, dialect.SQLType(kase.col))
+	}
+}
+
+func TestParseDuckDBType(t *testing.T) {
+	var kases = []struct {
+		dataType   string
+		name       string
+		length     int
+		length2    int
+		typeParams []string
+	}{
+		{"INTEGER", schemas.Integer, 0, 0, nil},
+		{"TIMESTAMP WITH TIME ZONE", schemas.TimeStampz, 0, 0, nil},
+		{"DECIMAL(18,3)", schemas.Decimal, 18, 3, nil},
+		{"VARCHAR[]", schemas.List, 0, 0, []string{"VARCHAR"}},
+		{"STRUCT(a INTEGER, b MAP(VARCHAR, INTEGER))", schemas.Struct, 0, 0, []string{"a INTEGER", "b MAP(VARCHAR, INTEGER)"}},
+		{"MAP(VARCHAR, INTEGER[])", schemas.Map, 0, 0, []string{"VARCHAR", "INTEGER[]"}},
+	}
+	for _, kase := range kases {
+		col := new(schemas.Column)
+		assert.NoError(t, parseDuckDBType(col, kase.dataType))
+		assert.EqualValues(t, kase.name, col.SQLType.Name, kase.dataType)
+		assert.EqualValues(t, kase.length, col.Length, kase.dataType)
+		assert.EqualValues(t, kase.length2, col.Length2, kase.dataType)
+		assert.EqualValues(t, kase.typeParams, col.TypeParams, kase.dataType)
+	}
+	assert.Error(t, parseDuckDBType(new(schemas.Column), "GEOMETRY"))
+}
+
+func TestDuckDBCreateTableSQL(t *testing.T) {
+	dialect := QueryDialect(schemas.DUCKDB)
+	assert.NoError(t, dialect.Init(&URI{DBType: schemas.DUCKDB}))
+
+	table := schemas.NewEmptyTable()
+	table.Name = "user"
+	id := schemas.NewColumn("id", "", schemas.SQLType{Name: schemas.BigInt}, 0, 0, false)
+	id.IsPrimaryKey, id.IsAutoIncrement = true, true
+	table.AddColumn(id)
+	table.AddColumn(schemas.NewColumn("name", "", schemas.SQLType{Name: schemas.Varchar}, 255, 0, true))
+
+	sql, _, err := dialect.CreateTableSQL(context.Background(), nil, table, "")
+	assert.NoError(t, err)
+	assert.EqualValues(t, `CREATE TABLE IF NOT EXISTS "user" ("id" BIGINT PRIMARY KEY DEFAULT nextval('SEQ_USER') NOT NULL, "name" VARCHAR NULL)`, sql)
+
+	// the temporary table of rebuilding shares the sequence
+	sql, _, err = dialect.CreateTableSQL(context.Background(), nil, table, "main.xorm_rebuild_user")
+	assert.NoError(t, err)
+	assert.Contains(t, sql, `DEFAULT nextval('main.SEQ_USER')`)
+
+	seqSQL, err := dialect.CreateSequenceSQL(context.Background(), nil, SequenceName(dialect, "user"))
+	assert.NoError(t, err)
+	assert.EqualValues(t, `CREATE SEQUENCE IF NOT EXISTS "SEQ_USER" START 1`, seqSQL)
+}
+
+func TestDuckDBValue(t *testing.T) {
+	v, err := duckdbValue("INTEGER[]", []interface{}{int32(1), int32(2)})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "[1,2]", string(v.([]byte)))
+
+	v, err = duckdbValue("MAP(INTEGER, VARCHAR)", map[interface{}]interface{}{int32(1): "a"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, `{"1":"a"}`, string(v.([]byte)))
+
+	v, err = duckdbValue("UUID", []byte{0x55, 0x0e, 0x84, 0x00, 0xe2, 0x9b, 0x41, 0xd4, 0xa7, 0x16, 0x44, 0x66, 0x55, 0x44, 0x00, 0x00})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "550e8400-e29b-41d4-a716-446655440000", v)
+
+	v, err = duckdbValue("HUGEINT", big.NewInt(12345))
+	assert.NoError(t, err)
+	assert.EqualValues(t, "12345", v)
+
+	type decimal struct {
+		Width uint8
+		Scale uint8
+		Value *big.Int
+	}
+	v, err = duckdbValue("DECIMAL(22,2)", decimal{Width: 22, Scale: 2, Value: big.NewInt(99999999)})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "999999.99", v)
+	v, err = duckdbValue("DECIMAL(10,3)", decimal{Width: 10, Scale: 3, Value: big.NewInt(-5)})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "-0.005", v)
+}
+
+func TestDuckDBDriverParse(t *testing.T) {
+	driver := QueryDriver("duckdb")
+	uri, err := driver.Parse("duckdb", "/tmp/xorm.duckdb?access_mode=read_only")
+	assert.NoError(t, err)
+	assert.EqualValues(t, schemas.DUCKDB, uri.DBType)
+	assert.EqualValues(t, "/tmp/xorm.duckdb", uri.DBName)
+
+	uri, err = driver.Parse("duckdb", "")
+	assert.NoError(t, err)
+	assert.EqualValues(t, ":memory:", uri.DBName)
+}
+
+func TestParseDuckDBForeignKey(t *testing.T) {
+	fk := parseDuckDBForeignKey("main", "FOREIGN KEY (user_id) REFERENCES test_user(id)")
+	assert.NotNil(t, fk)
+	assert.EqualValues(t, "test_user", fk.RefTable)
+	assert.EqualValues(t, []string{"user_id"}, fk.Cols)
+	assert.EqualValues(t, []string{"id"}, fk.RefCols)
+
+	fk = parseDuckDBForeignKey("main", "FOREIGN KEY (uId, k) REFERENCES main.U x(Id, k)")
+	assert.NotNil(t, fk)
+	assert.EqualValues(t, "U x", fk.RefTable)
+	assert.EqualValues(t, []string{"uId", "k"}, fk.Cols)
+	assert.EqualValues(t, []string{"Id", "k"}, fk.RefCols)
+
+	fk = parseDuckDBForeignKey("main", "FOREIGN KEY (user_id) REFERENCES other.test_user(id)")
+	assert.NotNil(t, fk)
+	assert.EqualValues(t, "other.test_user", fk.RefTable)
+
+	assert.Nil(t, parseDuckDBForeignKey("main", "CHECK((id > 0))"))
+}
+
+func TestDuckDBAddColumnSQLs(t *testing.T) {
+	dialect := QueryDialect(schemas.DUCKDB)
+	assert.NoError(t, dialect.Init(&URI{DBType: schemas.DUCKDB}))
+
+	col := &schemas.Column{Name: "name", SQLType: schemas.SQLType{Name: schemas.Varchar}, Nullable: false, Default: "''"}
+	assert.EqualValues(t, []string{
+		`ALTER TABLE "user" ADD COLUMN "name" VARCHAR DEFAULT '' NULL`,
+		`ALTER TABLE "user" ALTER COLUMN "name" SET NOT NULL`,
+	}, AddColumnSQLs(dialect, "user", col))
+
+	col.Nullable = true
+	assert.EqualValues(t, []string{`ALTER TABLE "user" ADD COLUMN "name" VARCHAR DEFAULT '' NULL`}, AddColumnSQLs(dialect, "user", col))
+}
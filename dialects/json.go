@@ -0,0 +1,264 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dialects
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"xorm.io/xorm/internal/json"
+	"xorm.io/xorm/schemas"
+)
+
+// parseJSONPath splits a JSON path like $.a.b[0] or $."a b" into its keys and array indexes
+func parseJSONPath(path string) ([]string, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("invalid json path %q, it should begin with $", path)
+	}
+
+	var elems []string
+	for i := 1; i < len(path); {
+		switch path[i] {
+		case '.':
+			i++
+			if i < len(path) && path[i] == '"' {
+				end := strings.IndexByte(path[i+1:], '"')
+				if end < 0 {
+					return nil, fmt.Errorf("invalid json path %q, unclosed quoted key", path)
+				}
+				elems = append(elems, path[i+1:i+1+end])
+				i += end + 2
+				continue
+			}
+			start := i
+			for i < len(path) && path[i] != '.' && path[i] != '[' {
+				i++
+			}
+			if start == i {
+				return nil, fmt.Errorf("invalid json path %q, empty key", path)
+			}
+			elems = append(elems, path[start:i])
+		case '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid json path %q, unclosed array index", path)
+			}
+			idx := path[i+1 : i+end]
+			if _, err := strconv.ParseUint(idx, 10, 64); err != nil {
+				return nil, fmt.Errorf("invalid json path %q, array index %q", path, idx)
+			}
+			elems = append(elems, idx)
+			i += end + 1
+		default:
+			return nil, fmt.Errorf("invalid json path %q", path)
+		}
+	}
+	if len(elems) == 0 {
+		return nil, fmt.Errorf("invalid json path %q, no keys", path)
+	}
+	return elems, nil
+}
+
+func quoteSQLString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+var pgArrayElemEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// pgJSONPathOp returns the postgres operator and its operand of the path elements,
+// ->> 'a' or #>> '{a,b}' when text is true, otherwise -> 'a' or #> '{a,b}'
+func pgJSONPathOp(elems []string, text bool) string {
+	op := "->"
+	if len(elems) > 1 {
+		op = "#>"
+	}
+	if text {
+		op += ">"
+	}
+	if len(elems) == 1 {
+		if _, err := strconv.ParseUint(elems[0], 10, 64); err == nil {
+			return op + elems[0]
+		}
+		return op + quoteSQLString(elems[0])
+	}
+	return op + quoteSQLString(pgJSONPathArray(elems))
+}
+
+// pgJSONPathArray returns the text array literal of the path elements, like {a,b,0}
+func pgJSONPathArray(elems []string) string {
+	quoted := make([]string, 0, len(elems))
+	for _, elem := range elems {
+		if elem == "" || strings.ContainsAny(elem, ",{}\"\\ ") {
+			elem = `"` + pgArrayElemEscaper.Replace(elem) + `"`
+		}
+		quoted = append(quoted, elem)
+	}
+	return "{" + strings.Join(quoted, ",") + "}"
+}
+
+func jsonArg(value interface{}) (string, error) {
+	bs, err := json.DefaultJSONHandler.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+// isJSONContainer returns true if the value is marshaled as a JSON object or array
+func isJSONContainer(value interface{}) bool {
+	v := reflect.Indirect(reflect.ValueOf(value))
+	switch v.Kind() {
+	case reflect.Map, reflect.Struct, reflect.Array:
+		return true
+	case reflect.Slice:
+		return v.Type().Elem().Kind() != reflect.Uint8
+	}
+	return false
+}
+
+// JSONExtractSQL returns the expression of the scalar value at the path of the JSON column,
+// ->> on postgres, JSON_UNQUOTE(JSON_EXTRACT) on mysql, json_extract on sqlite and JSON_VALUE
+// on mssql
+func JSONExtractSQL(dialect Dialect, colName, path string) (string, error) {
+	elems, err := parseJSONPath(path)
+	if err != nil {
+		return "", err
+	}
+	quotedCol := dialect.Quoter().Quote(colName)
+	switch dialect.URI().DBType {
+	case schemas.POSTGRES:
+		// the column may be declared as TEXT or JSON, so it's always converted to jsonb
+		return quotedCol + "::jsonb" + pgJSONPathOp(elems, true), nil
+	case schemas.MYSQL:
+		// JSON_EXTRACT returns the quoted text of the strings on mariadb whose JSON is LONGTEXT
+		return fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(%s, %s))", quotedCol, quoteSQLString(path)), nil
+	case schemas.SQLITE:
+		return fmt.Sprintf("json_extract(%s, %s)", quotedCol, quoteSQLString(path)), nil
+	case schemas.MSSQL:
+		return fmt.Sprintf("JSON_VALUE(%s, %s)", quotedCol, quoteSQLString(path)), nil
+	}
+	return "", fmt.Errorf("json path is unsupported by %s", dialect.URI().DBType)
+}
+
+// JSONCompareSQL returns the condition comparing the scalar value at the path of the JSON column
+// with the value by the operator and the arguments of the condition
+func JSONCompareSQL(dialect Dialect, colName, path, op string, value interface{}) (string, []interface{}, error) {
+	expr, err := JSONExtractSQL(dialect, colName, path)
+	if err != nil {
+		return "", nil, err
+	}
+	if value == nil {
+		switch op {
+		case "=":
+			return expr + " IS NULL", nil, nil
+		case "<>":
+			return expr + " IS NOT NULL", nil, nil
+		}
+	}
+	switch dialect.URI().DBType {
+	case schemas.POSTGRES:
+		// ->> returns the text of the value, the numbers are compared as numerics and the
+		// booleans as their JSON text
+		if isJSONNumber(value) {
+			return fmt.Sprintf("(%s)::numeric %s ?", expr, op), []interface{}{value}, nil
+		}
+		if _, ok := value.(string); !ok {
+			if value, err = jsonArg(value); err != nil {
+				return "", nil, err
+			}
+		}
+	case schemas.MYSQL:
+		// the unquoted text of the booleans is true or false
+		if b, ok := value.(bool); ok {
+			value = strconv.FormatBool(b)
+		}
+	}
+	return fmt.Sprintf("%s %s ?", expr, op), []interface{}{value}, nil
+}
+
+// isJSONNumber returns true if the value is marshaled as a JSON number
+func isJSONNumber(value interface{}) bool {
+	switch reflect.Indirect(reflect.ValueOf(value)).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// JSONContainsSQL returns the condition that the JSON array at the path of the JSON column
+// contains the value and the arguments of the condition
+func JSONContainsSQL(dialect Dialect, colName, path string, value interface{}) (string, []interface{}, error) {
+	elems, err := parseJSONPath(path)
+	if err != nil {
+		return "", nil, err
+	}
+	quotedCol := dialect.Quoter().Quote(colName)
+	switch dialect.URI().DBType {
+	case schemas.POSTGRES:
+		arg, err := jsonArg([]interface{}{value})
+		if err != nil {
+			return "", nil, err
+		}
+		return quotedCol + "::jsonb" + pgJSONPathOp(elems, false) + " @> ?::jsonb", []interface{}{arg}, nil
+	case schemas.MYSQL:
+		arg, err := jsonArg(value)
+		if err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("JSON_CONTAINS(%s, ?, %s)", quotedCol, quoteSQLString(path)), []interface{}{arg}, nil
+	case schemas.SQLITE:
+		return fmt.Sprintf("EXISTS (SELECT 1 FROM json_each(%s, %s) WHERE value = ?)", quotedCol, quoteSQLString(path)), []interface{}{value}, nil
+	case schemas.MSSQL:
+		return fmt.Sprintf("EXISTS (SELECT 1 FROM OPENJSON(%s, %s) WHERE value = ?)", quotedCol, quoteSQLString(path)), []interface{}{value}, nil
+	}
+	return "", nil, fmt.Errorf("json path is unsupported by %s", dialect.URI().DBType)
+}
+
+// JSONSetSQL returns the expression which sets the value at the path of the JSON column and
+// the arguments of the expression, jsonb_set on postgres, JSON_SET on mysql, json_set on sqlite
+// and JSON_MODIFY on mssql. target is the SQL of the JSON document to be modified, it's the
+// quoted column or the expression of the previous modification.
+func JSONSetSQL(dialect Dialect, target, path string, value interface{}) (string, []interface{}, error) {
+	elems, err := parseJSONPath(path)
+	if err != nil {
+		return "", nil, err
+	}
+	switch dialect.URI().DBType {
+	case schemas.POSTGRES:
+		arg, err := jsonArg(value)
+		if err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("jsonb_set((%s)::jsonb, %s, ?::jsonb)", target, quoteSQLString(pgJSONPathArray(elems))), []interface{}{arg}, nil
+	case schemas.MYSQL:
+		arg, err := jsonArg(value)
+		if err != nil {
+			return "", nil, err
+		}
+		// JSON_EXTRACT(?, '$') converts the argument to JSON on both mysql and mariadb
+		return fmt.Sprintf("JSON_SET(%s, %s, JSON_EXTRACT(?, '$'))", target, quoteSQLString(path)), []interface{}{arg}, nil
+	case schemas.SQLITE:
+		arg, err := jsonArg(value)
+		if err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("json_set(%s, %s, json(?))", target, quoteSQLString(path)), []interface{}{arg}, nil
+	case schemas.MSSQL:
+		// JSON_QUERY only accepts objects and arrays, the scalar values are passed as they are
+		if isJSONContainer(value) {
+			arg, err := jsonArg(value)
+			if err != nil {
+				return "", nil, err
+			}
+			return fmt.Sprintf("JSON_MODIFY(%s, %s, JSON_QUERY(?))", target, quoteSQLString(path)), []interface{}{arg}, nil
+		}
+		return fmt.Sprintf("JSON_MODIFY(%s, %s, ?)", target, quoteSQLString(path)), []interface{}{value}, nil
+	}
+	return "", nil, fmt.Errorf("json path is unsupported by %s", dialect.URI().DBType)
+}
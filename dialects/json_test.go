@@ -0,0 +1,112 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dialects
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"xorm.io/xorm/schemas"
+)
+
+func TestParseJSONPath(t *testing.T) {
+	elems, err := parseJSONPath(`$.a.b[0]."c d"`)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"a", "b", "0", "c d"}, elems)
+
+	for _, path := range []string{"", "a.b", "$", "$.", "$.a[x]", "$.a[0", `$."a`, "$a"} {
+		_, err := parseJSONPath(path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestJSONPathSQL(t *testing.T) {
+	var kases = []struct {
+		dbType   schemas.DBType
+		compare  string
+		contains string
+		set      string
+	}{
+		{
+			schemas.POSTGRES,
+			`"attrs"::jsonb->>'color' = ?`,
+			`"attrs"::jsonb#>'{sizes,0}' @> ?::jsonb`,
+			`jsonb_set(("attrs")::jsonb, '{color}', ?::jsonb)`,
+		},
+		{
+			schemas.MYSQL,
+			"JSON_UNQUOTE(JSON_EXTRACT(`attrs`, '$.color')) = ?",
+			"JSON_CONTAINS(`attrs`, ?, '$.sizes[0]')",
+			"JSON_SET(`attrs`, '$.color', JSON_EXTRACT(?, '$'))",
+		},
+		{
+			schemas.SQLITE,
+			"json_extract(`attrs`, '$.color') = ?",
+			"EXISTS (SELECT 1 FROM json_each(`attrs`, '$.sizes[0]') WHERE value = ?)",
+			"json_set(`attrs`, '$.color', json(?))",
+		},
+		{
+			schemas.MSSQL,
+			"JSON_VALUE([attrs], '$.color') = ?",
+			"EXISTS (SELECT 1 FROM OPENJSON([attrs], '$.sizes[0]') WHERE value = ?)",
+			"JSON_MODIFY([attrs], '$.color', ?)",
+		},
+	}
+
+	for _, kase := range kases {
+		dialect := QueryDialect(kase.dbType)
+		assert.NoError(t, dialect.Init(&URI{DBType: kase.dbType}))
+
+		sql, _, err := JSONCompareSQL(dialect, "attrs", "$.color", "=", "red")
+		assert.NoError(t, err)
+		assert.EqualValues(t, kase.compare, sql)
+
+		sql, _, err = JSONContainsSQL(dialect, "attrs", "$.sizes[0]", "M")
+		assert.NoError(t, err)
+		assert.EqualValues(t, kase.contains, sql)
+
+		sql, _, err = JSONSetSQL(dialect, dialect.Quoter().Quote("attrs"), "$.color", "red")
+		assert.NoError(t, err)
+		assert.EqualValues(t, kase.set, sql)
+	}
+
+	pg := QueryDialect(schemas.POSTGRES)
+	assert.NoError(t, pg.Init(&URI{DBType: schemas.POSTGRES}))
+	// the values are compared with the text returned by ->>
+	sql, args, err := JSONCompareSQL(pg, "attrs", `$.sizes[1]."o'k"`, "=", true)
+	assert.NoError(t, err)
+	assert.EqualValues(t, `"attrs"::jsonb#>>'{sizes,1,o''k}' = ?`, sql)
+	assert.EqualValues(t, []interface{}{"true"}, args)
+	// the numbers are compared as numerics
+	sql, args, err = JSONCompareSQL(pg, "attrs", "$.price", ">", 9.5)
+	assert.NoError(t, err)
+	assert.EqualValues(t, `("attrs"::jsonb->>'price')::numeric > ?`, sql)
+	assert.EqualValues(t, []interface{}{9.5}, args)
+	sql, args, err = JSONCompareSQL(pg, "attrs", "$.color", "<>", nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, `"attrs"::jsonb->>'color' IS NOT NULL`, sql)
+	assert.Empty(t, args)
+	_, args, err = JSONContainsSQL(pg, "attrs", "$.tags", "a")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []interface{}{`["a"]`}, args)
+
+	mysql := QueryDialect(schemas.MYSQL)
+	assert.NoError(t, mysql.Init(&URI{DBType: schemas.MYSQL}))
+	_, args, err = JSONCompareSQL(mysql, "attrs", "$.active", "=", false)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []interface{}{"false"}, args)
+
+	mssql := QueryDialect(schemas.MSSQL)
+	assert.NoError(t, mssql.Init(&URI{DBType: schemas.MSSQL}))
+	sql, args, err = JSONSetSQL(mssql, "[attrs]", "$.sizes", []string{"S", "M"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "JSON_MODIFY([attrs], '$.sizes', JSON_QUERY(?))", sql)
+	assert.EqualValues(t, []interface{}{`["S","M"]`}, args)
+
+	ora := QueryDialect(schemas.ORACLE)
+	assert.NoError(t, ora.Init(&URI{DBType: schemas.ORACLE}))
+	_, err = JSONExtractSQL(ora, "attrs", "$.color")
+	assert.Error(t, err)
+}
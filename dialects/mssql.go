@@ -282,9 +282,64 @@ func (db *mssql) Version(ctx context.Context, queryer core.Queryer) (*schemas.Ve
 	}, nil
 }
 
+// supportOffsetFetch returns true if the detected server is SQL Server 2012 or later
+func (db *mssql) supportOffsetFetch() bool {
+	version := db.detectedVersion()
+	// the product version of SQL Server 2012 is 11.0
+	return version != nil && versionAtLeast(version.Number, 11, 0)
+}
+
+// LimitOffsetSQL returns the query paginated by OFFSET FETCH on SQL Server 2012 and later,
+// the query is ordered by nothing if it has no ORDER BY which OFFSET requires. Otherwise TOP
+// is used and the offset records are skipped by a NOT IN subquery of the key column.
+func (db *mssql) LimitOffsetSQL(query *SelectQuery) (string, []interface{}, error) {
+	// TOP is enough and needs no ORDER BY when there is no offset
+	if query.Offset > 0 && db.supportOffsetFetch() {
+		var clause string
+		if query.OrderBy == "" {
+			clause = " ORDER BY (SELECT NULL)"
+		}
+		clause += fmt.Sprintf(" OFFSET %d ROWS", query.Offset)
+		if query.Limit != nil {
+			clause += fmt.Sprintf(" FETCH NEXT %d ROWS ONLY", *query.Limit)
+		}
+		sql, args := query.Build("", "", nil, clause)
+		return sql, args, nil
+	}
+
+	var top string
+	if query.Limit != nil {
+		top = fmt.Sprintf("TOP %d ", *query.Limit)
+	}
+	if query.Offset <= 0 {
+		sql, args := query.Build(top, "", nil, "")
+		return sql, args, nil
+	}
+	if query.KeyColumn == "" {
+		return "", nil, errors.New("Unsupported query limit without reference table")
+	}
+
+	var cond strings.Builder
+	fmt.Fprintf(&cond, "(%s NOT IN (SELECT TOP %d %s%s", query.KeyColumn, query.Offset, query.KeyColumn, query.From)
+	condArgs := append([]interface{}{}, query.FromArgs...)
+	if query.Where != "" {
+		cond.WriteString(" WHERE ")
+		cond.WriteString(query.Where)
+		condArgs = append(condArgs, query.WhereArgs...)
+	}
+	cond.WriteString(query.OrderBy)
+	condArgs = append(condArgs, query.OrderByArgs...)
+	cond.WriteString(query.GroupBy)
+	cond.WriteString("))")
+
+	sql, args := query.Build(top, cond.String(), condArgs, "")
+	return sql, args, nil
+}
+
 func (db *mssql) Features() *DialectFeatures {
 	return &DialectFeatures{
-		AutoincrMode: IncrAutoincrMode,
+		AutoincrMode:        IncrAutoincrMode,
+		MaxIdentifierLength: 128,
 	}
 }
 
@@ -421,10 +476,17 @@ func (db *mssql) AutoIncrStr() string {
 	return "IDENTITY"
 }
 
+// objectName returns the quoted table name with the schema of the table, it's the name of
+// the table to OBJECT_ID
+func (db *mssql) objectName(ctx context.Context, tableName string) string {
+	return db.quoter.Quote(TableNameInSchema(db.tableSchema(ctx, tableName)))
+}
+
 func (db *mssql) DropTableSQL(tableName string) (string, bool) {
+	objectName := db.objectName(context.Background(), tableName)
 	return fmt.Sprintf("IF EXISTS (SELECT * FROM sysobjects WHERE id = "+
-		"object_id(N'%s') and OBJECTPROPERTY(id, N'IsUserTable') = 1) "+
-		"DROP TABLE \"%s\"", tableName, tableName), true
+		"object_id(N%s) and OBJECTPROPERTY(id, N'IsUserTable') = 1) "+
+		"DROP TABLE %s", quoteSQLString(objectName), objectName), true
 }
 
 func (db *mssql) ModifyColumnSQL(tableName string, col *schemas.Column) string {
@@ -432,20 +494,60 @@ func (db *mssql) ModifyColumnSQL(tableName string, col *schemas.Column) string {
 	return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s", db.quoter.Quote(tableName), s)
 }
 
+func (db *mssql) AlterColumnNullableSQL(tableName string, col *schemas.Column) string {
+	nullable := "NULL"
+	if !col.Nullable {
+		nullable = "NOT NULL"
+	}
+	return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s %s", db.quoter.Quote(tableName), db.quoter.Quote(col.Name), db.SQLType(col), nullable)
+}
+
+// dropDefaultConstraintSQL returns a SQL to drop the default constraint of a column because
+// a column with a default value cannot be altered or dropped on mssql.
+func (db *mssql) dropDefaultConstraintSQL(tableName, colName string) string {
+	return fmt.Sprintf("DECLARE @xorm_default_name NVARCHAR(256); "+
+		"SELECT @xorm_default_name = d.name FROM sys.default_constraints d "+
+		"JOIN sys.columns c ON d.parent_object_id = c.object_id AND d.parent_column_id = c.column_id "+
+		"WHERE d.parent_object_id = OBJECT_ID(%s) AND c.name = %s; "+
+		"IF @xorm_default_name IS NOT NULL EXEC(%s + QUOTENAME(@xorm_default_name))",
+		quoteSQLString(db.objectName(context.Background(), tableName)), quoteSQLString(colName),
+		quoteSQLString("ALTER TABLE "+db.quoter.Quote(tableName)+" DROP CONSTRAINT "))
+}
+
+func (db *mssql) AlterColumnDefaultSQL(tableName string, col *schemas.Column) string {
+	sql := db.dropDefaultConstraintSQL(tableName, col.Name)
+	if col.DefaultIsEmpty {
+		return sql
+	}
+	return sql + fmt.Sprintf("; ALTER TABLE %s ADD DEFAULT %s FOR %s", db.quoter.Quote(tableName), defaultValue(col), db.quoter.Quote(col.Name))
+}
+
+func (db *mssql) RenameColumnSQL(tableName, oldName string, col *schemas.Column) string {
+	return fmt.Sprintf("EXEC sp_rename %s, %s, 'COLUMN'",
+		quoteSQLString(db.quoter.Quote(tableName)+"."+db.quoter.Quote(oldName)), quoteSQLString(col.Name))
+}
+
+func (db *mssql) DropColumnSQL(tableName, colName string) string {
+	return db.dropDefaultConstraintSQL(tableName, colName) + "; " + db.Base.DropColumnSQL(tableName, colName)
+}
+
 func (db *mssql) IndexCheckSQL(tableName, idxName string) (string, []interface{}) {
 	args := []interface{}{idxName}
-	sql := "select name from sysindexes where id=object_id('" + tableName + "') and name=?"
+	sql := "select name from sysindexes where id=object_id(" + quoteSQLString(db.objectName(context.Background(), tableName)) + ") and name=?"
 	return sql, args
 }
 
 func (db *mssql) IsColumnExist(queryer core.Queryer, ctx context.Context, tableName, colName string) (bool, error) {
 	query := `SELECT "COLUMN_NAME" FROM "INFORMATION_SCHEMA"."COLUMNS" WHERE "TABLE_NAME" = ? AND "COLUMN_NAME" = ?`
-
-	return db.HasRecords(queryer, ctx, query, tableName, colName)
+	schema, tableName := db.tableSchema(ctx, tableName)
+	if schema == "" {
+		return db.HasRecords(queryer, ctx, query, tableName, colName)
+	}
+	return db.HasRecords(queryer, ctx, query+` AND "TABLE_SCHEMA" = ?`, tableName, colName, schema)
 }
 
 func (db *mssql) IsTableExist(queryer core.Queryer, ctx context.Context, tableName string) (bool, error) {
-	sql := "select * from sysobjects where id = object_id(N'" + tableName + "') and OBJECTPROPERTY(id, N'IsUserTable') = 1"
+	sql := "select * from sysobjects where id = object_id(N" + quoteSQLString(db.objectName(ctx, tableName)) + ") and OBJECTPROPERTY(id, N'IsUserTable') = 1"
 	return db.HasRecords(queryer, ctx, sql)
 }
 
@@ -463,7 +565,7 @@ func (db *mssql) GetColumns(queryer core.Queryer, ctx context.Context, tableName
 		  LEFT JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
 			WHERE i.is_primary_key = 1
 		) as p on p.object_id = a.object_id AND p.column_id = a.column_id
-          where a.object_id=object_id('` + tableName + `')`
+          where a.object_id=object_id(` + quoteSQLString(db.objectName(ctx, tableName)) + `)`
 
 	rows, err := queryer.QueryContext(ctx, s, args...)
 	if err != nil {
@@ -539,9 +641,40 @@ func (db *mssql) GetColumns(queryer core.Queryer, ctx context.Context, tableName
 	return colSeq, cols, nil
 }
 
+// ReplaceViewSQL returns a CREATE OR ALTER VIEW SQL on SQL Server 2016 SP1 or later
+func (db *mssql) ReplaceViewSQL(viewName string, view *schemas.View) string {
+	version := db.detectedVersion()
+	if view.Materialized || version == nil || !versionAtLeast(version.Number, 13, 0) {
+		return ""
+	}
+	// the product version of SQL Server 2016 SP1 is 13.0.4001
+	if !versionAtLeast(version.Number, 13, 1) {
+		parts := strings.SplitN(version.Number, ".", 4)
+		if len(parts) < 3 {
+			return ""
+		}
+		if build, _ := strconv.Atoi(parts[2]); build < 4001 {
+			return ""
+		}
+	}
+	return fmt.Sprintf("CREATE OR ALTER VIEW %s AS %s", db.quoter.Quote(viewName), view.Query)
+}
+
+func (db *mssql) GetViews(queryer core.Queryer, ctx context.Context) ([]*schemas.Table, error) {
+	s := "SELECT v.name, m.definition FROM sys.views v LEFT JOIN sys.sql_modules m ON m.object_id = v.object_id"
+	if schema, _ := db.tableSchema(ctx, ""); schema != "" {
+		return queryViews(queryer, ctx, false, s+" WHERE SCHEMA_NAME(v.schema_id) = ?", schema)
+	}
+	return queryViews(queryer, ctx, false, s)
+}
+
 func (db *mssql) GetTables(queryer core.Queryer, ctx context.Context) ([]*schemas.Table, error) {
 	args := []interface{}{}
 	s := `select name from sysobjects where xtype ='U'`
+	if schema, _ := db.tableSchema(ctx, ""); schema != "" {
+		s = `SELECT name FROM sys.tables WHERE SCHEMA_NAME(schema_id) = ?`
+		args = append(args, schema)
+	}
 
 	rows, err := queryer.QueryContext(ctx, s, args...)
 	if err != nil {
@@ -567,17 +700,22 @@ func (db *mssql) GetTables(queryer core.Queryer, ctx context.Context) ([]*schema
 }
 
 func (db *mssql) GetIndexes(queryer core.Queryer, ctx context.Context, tableName string) (map[string]*schemas.Index, error) {
-	args := []interface{}{tableName}
+	args := []interface{}{db.objectName(ctx, tableName)}
+	_, tableName = SplitTableName(tableName)
 	s := `SELECT
 IXS.NAME                    AS  [INDEX_NAME],
 C.NAME                      AS  [COLUMN_NAME],
-IXS.is_unique AS [IS_UNIQUE]
+IXS.is_unique AS [IS_UNIQUE],
+IXCS.is_descending_key AS [IS_DESC],
+IXCS.is_included_column AS [IS_INCLUDED],
+IXS.filter_definition AS [FILTER]
 FROM SYS.INDEXES IXS
 INNER JOIN SYS.INDEX_COLUMNS   IXCS
 ON IXS.OBJECT_ID=IXCS.OBJECT_ID  AND IXS.INDEX_ID = IXCS.INDEX_ID
 INNER   JOIN SYS.COLUMNS C  ON IXS.OBJECT_ID=C.OBJECT_ID
 AND IXCS.COLUMN_ID=C.COLUMN_ID
-WHERE IXS.TYPE_DESC='NONCLUSTERED' and OBJECT_NAME(IXS.OBJECT_ID) =?
+WHERE IXS.TYPE_DESC='NONCLUSTERED' and IXS.OBJECT_ID = OBJECT_ID(?)
+ORDER BY IXCS.INDEX_COLUMN_ID
 `
 
 	rows, err := queryer.QueryContext(ctx, s, args...)
@@ -590,8 +728,10 @@ WHERE IXS.TYPE_DESC='NONCLUSTERED' and OBJECT_NAME(IXS.OBJECT_ID) =?
 	for rows.Next() {
 		var indexType int
 		var indexName, colName, isUnique string
+		var isDesc, isIncluded bool
+		var filter sql.NullString
 
-		err = rows.Scan(&indexName, &colName, &isUnique)
+		err = rows.Scan(&indexName, &colName, &isUnique, &isDesc, &isIncluded, &filter)
 		if err != nil {
 			return nil, err
 		}
@@ -609,10 +749,7 @@ WHERE IXS.TYPE_DESC='NONCLUSTERED' and OBJECT_NAME(IXS.OBJECT_ID) =?
 
 		colName = strings.Trim(colName, "` ")
 		var isRegular bool
-		if (strings.HasPrefix(indexName, "IDX_"+tableName) || strings.HasPrefix(indexName, "UQE_"+tableName)) && len(indexName) > (5+len(tableName)) {
-			indexName = indexName[5+len(tableName):]
-			isRegular = true
-		}
+		indexName, isRegular = regularIndexName(db, tableName, indexName)
 
 		var index *schemas.Index
 		var ok bool
@@ -621,9 +758,17 @@ WHERE IXS.TYPE_DESC='NONCLUSTERED' and OBJECT_NAME(IXS.OBJECT_ID) =?
 			index.Type = indexType
 			index.Name = indexName
 			index.IsRegular = isRegular
+			index.Where = filter.String
 			indexes[indexName] = index
 		}
+		if isIncluded {
+			index.AddInclude(colName)
+			continue
+		}
 		index.AddColumn(colName)
+		if isDesc {
+			index.SetDesc(colName)
+		}
 	}
 	if rows.Err() != nil {
 		return nil, rows.Err()
@@ -631,6 +776,27 @@ WHERE IXS.TYPE_DESC='NONCLUSTERED' and OBJECT_NAME(IXS.OBJECT_ID) =?
 	return indexes, nil
 }
 
+func (db *mssql) GetForeignKeys(queryer core.Queryer, ctx context.Context, tableName string) (map[string]*schemas.ForeignKey, error) {
+	args := []interface{}{db.objectName(ctx, tableName)}
+	s := `SELECT FK.NAME, PC.NAME, RT.NAME, RC.NAME,
+REPLACE(FK.UPDATE_REFERENTIAL_ACTION_DESC, '_', ' '), REPLACE(FK.DELETE_REFERENTIAL_ACTION_DESC, '_', ' ')
+FROM SYS.FOREIGN_KEYS FK
+INNER JOIN SYS.FOREIGN_KEY_COLUMNS FKC ON FK.OBJECT_ID = FKC.CONSTRAINT_OBJECT_ID
+INNER JOIN SYS.COLUMNS PC ON PC.OBJECT_ID = FKC.PARENT_OBJECT_ID AND PC.COLUMN_ID = FKC.PARENT_COLUMN_ID
+INNER JOIN SYS.TABLES RT ON RT.OBJECT_ID = FKC.REFERENCED_OBJECT_ID
+INNER JOIN SYS.COLUMNS RC ON RC.OBJECT_ID = FKC.REFERENCED_OBJECT_ID AND RC.COLUMN_ID = FKC.REFERENCED_COLUMN_ID
+WHERE FK.PARENT_OBJECT_ID = OBJECT_ID(?)
+ORDER BY FK.NAME, FKC.CONSTRAINT_COLUMN_ID
+`
+	return queryForeignKeys(queryer, ctx, s, args...)
+}
+
+func (db *mssql) GetChecks(queryer core.Queryer, ctx context.Context, tableName string) (map[string]*schemas.Check, error) {
+	args := []interface{}{db.objectName(ctx, tableName)}
+	s := "SELECT NAME, DEFINITION FROM SYS.CHECK_CONSTRAINTS WHERE PARENT_OBJECT_ID = OBJECT_ID(?)"
+	return queryChecks(queryer, ctx, s, args...)
+}
+
 func (db *mssql) CreateTableSQL(ctx context.Context, queryer core.Queryer, table *schemas.Table, tableName string) (string, bool, error) {
 	if tableName == "" {
 		tableName = table.Name
@@ -639,7 +805,12 @@ func (db *mssql) CreateTableSQL(ctx context.Context, queryer core.Queryer, table
 	quoter := db.dialect.Quoter()
 	var b strings.Builder
 	b.WriteString("IF NOT EXISTS (SELECT [name] FROM sys.tables WHERE [name] = '")
-	quoter.QuoteTo(&b, tableName)
+	schema, name := db.tableSchema(ctx, tableName)
+	quoter.QuoteTo(&b, name)
+	if schema != "" {
+		b.WriteString("' AND SCHEMA_NAME([schema_id]) = '")
+		b.WriteString(strings.ReplaceAll(schema, "'", "''"))
+	}
 	b.WriteString("' ) CREATE TABLE ")
 	quoter.QuoteTo(&b, tableName)
 	b.WriteString(" (")
@@ -660,6 +831,9 @@ func (db *mssql) CreateTableSQL(ctx context.Context, queryer core.Queryer, table
 		b.WriteString(")")
 	}
 
+	writeForeignKeys(&b, db.dialect, table, tableName)
+	writeChecks(&b, db.dialect, table, tableName)
+
 	b.WriteString(")")
 
 	return b.String(), true, nil
@@ -7,6 +7,9 @@ package dialects
 import (
 	"reflect"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"xorm.io/xorm/schemas"
 )
 
 func TestParseMSSQL(t *testing.T) {
@@ -31,3 +34,110 @@ func TestParseMSSQL(t *testing.T) {
 		}
 	}
 }
+
+func TestMSSQLLimitOffsetSQL(t *testing.T) {
+	dialect := QueryDialect("mssql")
+	assert.NoError(t, dialect.Init(&URI{DBType: "mssql"}))
+	limit := 10
+	query := &SelectQuery{
+		Columns:   "*",
+		From:      " FROM [user]",
+		Where:     "[age]>?",
+		WhereArgs: []interface{}{18},
+		OrderBy:   " ORDER BY [name]",
+		KeyColumn: "id",
+		Limit:     &limit,
+		Offset:    20,
+	}
+
+	// TOP and NOT IN are used when the version is unknown
+	sql, args, err := dialect.LimitOffsetSQL(query)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT TOP 10 * FROM [user] WHERE [age]>? AND (id NOT IN (SELECT TOP 20 id FROM [user] WHERE [age]>? ORDER BY [name])) ORDER BY [name]", sql)
+	assert.EqualValues(t, []interface{}{18, 18}, args)
+
+	dialect.(*mssql).version = &schemas.Version{Number: "10.50.6000.34"}
+	sql, _, err = dialect.LimitOffsetSQL(query)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT TOP 10 * FROM [user] WHERE [age]>? AND (id NOT IN (SELECT TOP 20 id FROM [user] WHERE [age]>? ORDER BY [name])) ORDER BY [name]", sql)
+
+	query.KeyColumn = ""
+	_, _, err = dialect.LimitOffsetSQL(query)
+	assert.Error(t, err)
+
+	dialect.(*mssql).version = &schemas.Version{Number: "11.0.2100.60"}
+	sql, args, err = dialect.LimitOffsetSQL(query)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT * FROM [user] WHERE [age]>? ORDER BY [name] OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY", sql)
+	assert.EqualValues(t, []interface{}{18}, args)
+
+	query.OrderBy = ""
+	sql, _, err = dialect.LimitOffsetSQL(query)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT * FROM [user] WHERE [age]>? ORDER BY (SELECT NULL) OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY", sql)
+
+	query.Offset = 0
+	sql, _, err = dialect.LimitOffsetSQL(query)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT TOP 10 * FROM [user] WHERE [age]>?", sql)
+}
+
+func TestMSSQLReplaceViewSQL(t *testing.T) {
+	dialect := QueryDialect("mssql")
+	assert.NoError(t, dialect.Init(&URI{DBType: "mssql"}))
+	view := schemas.NewView("SELECT id FROM [user]", false)
+
+	var kases = []struct {
+		version string
+		sql     string
+	}{
+		{"12.0.6024.0", ""},
+		{"13.0.1601.5", ""},
+		{"13.0.4001.0", "CREATE OR ALTER VIEW [user_ids] AS SELECT id FROM [user]"},
+		{"15.0.2000.5", "CREATE OR ALTER VIEW [user_ids] AS SELECT id FROM [user]"},
+	}
+	for _, kase := range kases {
+		dialect.(*mssql).version = &schemas.Version{Number: kase.version}
+		assert.EqualValues(t, kase.sql, dialect.ReplaceViewSQL("user_ids", view), kase.version)
+	}
+
+	sqls, err := ReplaceViewSQLs(dialect, "user_ids", view, view)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"CREATE OR ALTER VIEW [user_ids] AS SELECT id FROM [user]"}, sqls)
+
+	dialect.(*mssql).version = &schemas.Version{Number: "12.0.6024.0"}
+	sqls, err = ReplaceViewSQLs(dialect, "user_ids", view, view)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"DROP VIEW [user_ids]", "CREATE VIEW [user_ids] AS SELECT id FROM [user]"}, sqls)
+}
+
+func TestMSSQLDropTableSQL(t *testing.T) {
+	dialect := QueryDialect("mssql")
+	assert.NoError(t, dialect.Init(&URI{DBType: "mssql", Schema: "dbo"}))
+
+	sql, _ := dialect.DropTableSQL("user's")
+	assert.EqualValues(t, "IF EXISTS (SELECT * FROM sysobjects WHERE id = object_id(N'[dbo].[user''s]') and "+
+		"OBJECTPROPERTY(id, N'IsUserTable') = 1) DROP TABLE [dbo].[user's]", sql)
+	sql, _ = dialect.DropTableSQL("[other].[user]")
+	assert.EqualValues(t, "IF EXISTS (SELECT * FROM sysobjects WHERE id = object_id(N'[other].[user]') and "+
+		"OBJECTPROPERTY(id, N'IsUserTable') = 1) DROP TABLE [other].[user]", sql)
+}
+
+func TestMSSQLRenameColumnSQL(t *testing.T) {
+	col := &schemas.Column{Name: "name", SQLType: schemas.SQLType{Name: schemas.Varchar}, Length: 64, Nullable: false, DefaultIsEmpty: true}
+
+	dialect := QueryDialect("mssql")
+	assert.NoError(t, dialect.Init(&URI{DBType: "mssql"}))
+	assert.EqualValues(t, "EXEC sp_rename '[user].[nick]', 'name', 'COLUMN'", dialect.RenameColumnSQL("user", "nick", col))
+}
+
+func TestMSSQLDropColumnSQL(t *testing.T) {
+	dialect := QueryDialect("mssql")
+	assert.NoError(t, dialect.Init(&URI{DBType: "mssql"}))
+	assert.EqualValues(t, "DECLARE @xorm_default_name NVARCHAR(256); "+
+		"SELECT @xorm_default_name = d.name FROM sys.default_constraints d "+
+		"JOIN sys.columns c ON d.parent_object_id = c.object_id AND d.parent_column_id = c.column_id "+
+		"WHERE d.parent_object_id = OBJECT_ID('[o''brien]') AND c.name = 'it''s'; "+
+		"IF @xorm_default_name IS NOT NULL EXEC('ALTER TABLE [o''brien] DROP CONSTRAINT ' + QUOTENAME(@xorm_default_name)); "+
+		"ALTER TABLE [o'brien] DROP COLUMN [it's]", dialect.DropColumnSQL("o'brien", "it's"))
+}
@@ -222,7 +222,10 @@ func (db *mysql) Version(ctx context.Context, queryer core.Queryer) (*schemas.Ve
 	}
 
 	var edition string
-	if len(fields) == 2 {
+	if strings.Contains(version, "MariaDB") {
+		// 10.6.12-MariaDB or 10.6.12-MariaDB-1:10.6.12+maria~ubu2004
+		edition = "MariaDB"
+	} else if len(fields) == 2 {
 		edition = fields[1]
 	}
 
@@ -232,9 +235,37 @@ func (db *mysql) Version(ctx context.Context, queryer core.Queryer) (*schemas.Ve
 	}, nil
 }
 
+// isMariaDB returns true if the detected server is MariaDB and its version is at least major.minor
+func (db *mysql) isMariaDB(major, minor int) bool {
+	version := db.detectedVersion()
+	if version == nil || version.Edition != "MariaDB" {
+		return false
+	}
+	return versionAtLeast(version.Number, major, minor)
+}
+
+// versionAtLeast returns true if the version number like 10.6.12 is not less than major.minor
+func versionAtLeast(number string, major, minor int) bool {
+	parts := strings.SplitN(number, ".", 3)
+	v1, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	var v2 int
+	if len(parts) > 1 {
+		v2, _ = strconv.Atoi(parts[1])
+	}
+	return v1 > major || (v1 == major && v2 >= minor)
+}
+
+// Features returns the features of the server, the ones of MariaDB are switched on by
+// the detected version
 func (db *mysql) Features() *DialectFeatures {
 	return &DialectFeatures{
-		AutoincrMode: IncrAutoincrMode,
+		AutoincrMode:        IncrAutoincrMode,
+		MaxIdentifierLength: 64,
+		// INSERT ... RETURNING is supported since MariaDB 10.5, it returns the ids of InsertMulti
+		SupportReturning: db.isMariaDB(10, 5),
 	}
 }
 
@@ -301,7 +332,12 @@ func (db *mysql) SQLType(c *schemas.Column) string {
 		res = schemas.Varchar
 		c.Length = 40
 	case schemas.Json:
-		res = schemas.Text
+		// JSON of MariaDB is an alias of LONGTEXT with a json_valid check
+		if db.isMariaDB(10, 2) {
+			res = schemas.Json
+		} else {
+			res = schemas.Text
+		}
 	case schemas.UnsignedInt:
 		res = schemas.Int
 		isUnsigned = true
@@ -366,8 +402,18 @@ func (db *mysql) AutoIncrStr() string {
 	return "AUTO_INCREMENT"
 }
 
+// schemaOf returns the database and the name of the table, the database of mysql is the schema
+func (db *mysql) schemaOf(ctx context.Context, tableName string) (string, string) {
+	schema, tableName := db.tableSchema(ctx, tableName)
+	if schema == "" {
+		schema = db.uri.DBName
+	}
+	return schema, tableName
+}
+
 func (db *mysql) IndexCheckSQL(tableName, idxName string) (string, []interface{}) {
-	args := []interface{}{db.uri.DBName, tableName, idxName}
+	schema, tableName := db.schemaOf(context.Background(), tableName)
+	args := []interface{}{schema, tableName, idxName}
 	sql := "SELECT `INDEX_NAME` FROM `INFORMATION_SCHEMA`.`STATISTICS`"
 	sql += " WHERE `TABLE_SCHEMA` = ? AND `TABLE_NAME` = ? AND `INDEX_NAME`=?"
 	return sql, args
@@ -375,21 +421,51 @@ func (db *mysql) IndexCheckSQL(tableName, idxName string) (string, []interface{}
 
 func (db *mysql) IsTableExist(queryer core.Queryer, ctx context.Context, tableName string) (bool, error) {
 	sql := "SELECT `TABLE_NAME` from `INFORMATION_SCHEMA`.`TABLES` WHERE `TABLE_SCHEMA`=? and `TABLE_NAME`=?"
-	return db.HasRecords(queryer, ctx, sql, db.uri.DBName, tableName)
+	schema, tableName := db.schemaOf(ctx, tableName)
+	return db.HasRecords(queryer, ctx, sql, schema, tableName)
 }
 
 func (db *mysql) AddColumnSQL(tableName string, col *schemas.Column) string {
 	quoter := db.dialect.Quoter()
 	s, _ := ColumnString(db, col, true)
-	sql := fmt.Sprintf("ALTER TABLE %v ADD %v", quoter.Quote(tableName), s)
+	query := fmt.Sprintf("ALTER TABLE %v ADD %v", quoter.Quote(tableName), s)
+	if len(col.Comment) > 0 {
+		query += " COMMENT " + quoteSQLString(col.Comment)
+	}
+	return query
+}
+
+// ModifyColumnSQL returns a SQL to modify a column, the column comment will be kept
+func (db *mysql) ModifyColumnSQL(tableName string, col *schemas.Column) string {
+	s, _ := ColumnString(db, col, false)
+	query := fmt.Sprintf("ALTER TABLE %v MODIFY COLUMN %v", db.dialect.Quoter().Quote(tableName), s)
+	if len(col.Comment) > 0 {
+		query += " COMMENT " + quoteSQLString(col.Comment)
+	}
+	return query
+}
+
+// RenameColumnSQL returns a SQL to rename a column, CHANGE is used because RENAME COLUMN
+// is not supported before MySQL 8.0
+func (db *mysql) RenameColumnSQL(tableName, oldName string, col *schemas.Column) string {
+	s, _ := ColumnString(db, col, false)
+	query := fmt.Sprintf("ALTER TABLE %v CHANGE %v %v", db.dialect.Quoter().Quote(tableName), db.dialect.Quoter().Quote(oldName), s)
 	if len(col.Comment) > 0 {
-		sql += " COMMENT '" + col.Comment + "'"
+		query += " COMMENT " + quoteSQLString(col.Comment)
 	}
-	return sql
+	return query
 }
 
 func (db *mysql) GetColumns(queryer core.Queryer, ctx context.Context, tableName string) ([]string, map[string]*schemas.Column, error) {
-	args := []interface{}{db.uri.DBName, tableName}
+	schema, tableName := db.schemaOf(ctx, tableName)
+	args := []interface{}{schema, tableName}
+	var jsonColumns map[string]bool
+	if db.isMariaDB(10, 2) {
+		var err error
+		if jsonColumns, err = db.mariaDBJSONColumns(queryer, ctx, schema, tableName); err != nil {
+			return nil, nil, err
+		}
+	}
 	alreadyQuoted := "(INSTR(VERSION(), 'maria') > 0 && " +
 		"(SUBSTRING_INDEX(VERSION(), '.', 1) > 10 || " +
 		"(SUBSTRING_INDEX(VERSION(), '.', 1) = 10 && " +
@@ -488,6 +564,9 @@ func (db *mysql) GetColumns(queryer core.Queryer, ctx context.Context, tableName
 		if isUnsigned {
 			colType = "UNSIGNED " + colType
 		}
+		if colType == "LONGTEXT" && jsonColumns[col.Name] {
+			colType, len1 = schemas.Json, 0
+		}
 		col.Length = len1
 		col.Length2 = len2
 		if _, ok := schemas.SqlTypes[colType]; ok {
@@ -523,8 +602,14 @@ func (db *mysql) GetColumns(queryer core.Queryer, ctx context.Context, tableName
 	return colSeq, cols, nil
 }
 
+func (db *mysql) GetViews(queryer core.Queryer, ctx context.Context) ([]*schemas.Table, error) {
+	schema, _ := db.schemaOf(ctx, "")
+	return queryViews(queryer, ctx, false, "SELECT `TABLE_NAME`, `VIEW_DEFINITION` FROM `INFORMATION_SCHEMA`.`VIEWS` WHERE `TABLE_SCHEMA` = ?", schema)
+}
+
 func (db *mysql) GetTables(queryer core.Queryer, ctx context.Context) ([]*schemas.Table, error) {
-	args := []interface{}{db.uri.DBName}
+	schema, _ := db.schemaOf(ctx, "")
+	args := []interface{}{schema}
 	s := "SELECT `TABLE_NAME`, `ENGINE`, `AUTO_INCREMENT`, `TABLE_COMMENT` from " +
 		"`INFORMATION_SCHEMA`.`TABLES` WHERE `TABLE_SCHEMA`=? AND (`ENGINE`='MyISAM' OR `ENGINE` = 'InnoDB' OR `ENGINE` = 'TokuDB')"
 
@@ -575,8 +660,19 @@ func (db *mysql) SetQuotePolicy(quotePolicy QuotePolicy) {
 }
 
 func (db *mysql) GetIndexes(queryer core.Queryer, ctx context.Context, tableName string) (map[string]*schemas.Index, error) {
-	args := []interface{}{db.uri.DBName, tableName}
-	s := "SELECT `INDEX_NAME`, `NON_UNIQUE`, `COLUMN_NAME` FROM `INFORMATION_SCHEMA`.`STATISTICS` WHERE `TABLE_SCHEMA` = ? AND `TABLE_NAME` = ? ORDER BY `SEQ_IN_INDEX`"
+	// the functional key parts are only available since MySQL 8.0.13
+	hasExpression, err := db.HasRecords(queryer, ctx, "SELECT `COLUMN_NAME` FROM `INFORMATION_SCHEMA`.`COLUMNS` WHERE `TABLE_SCHEMA` = 'information_schema' AND `TABLE_NAME` = 'STATISTICS' AND `COLUMN_NAME` = 'EXPRESSION'")
+	if err != nil {
+		return nil, err
+	}
+	expression := "NULL"
+	if hasExpression {
+		expression = "`EXPRESSION`"
+	}
+
+	schema, tableName := db.schemaOf(ctx, tableName)
+	args := []interface{}{schema, tableName}
+	s := "SELECT `INDEX_NAME`, `NON_UNIQUE`, `COLUMN_NAME`, " + expression + ", `SUB_PART`, `COLLATION`, `INDEX_TYPE` FROM `INFORMATION_SCHEMA`.`STATISTICS` WHERE `TABLE_SCHEMA` = ? AND `TABLE_NAME` = ? ORDER BY `SEQ_IN_INDEX`"
 
 	rows, err := queryer.QueryContext(ctx, s, args...)
 	if err != nil {
@@ -587,8 +683,10 @@ func (db *mysql) GetIndexes(queryer core.Queryer, ctx context.Context, tableName
 	indexes := make(map[string]*schemas.Index)
 	for rows.Next() {
 		var indexType int
-		var indexName, colName, nonUnique string
-		err = rows.Scan(&indexName, &nonUnique, &colName)
+		var indexName, nonUnique string
+		var colName, expr, collation, indexMethod sql.NullString
+		var subPart sql.NullInt64
+		err = rows.Scan(&indexName, &nonUnique, &colName, &expr, &subPart, &collation, &indexMethod)
 		if err != nil {
 			return nil, err
 		}
@@ -603,12 +701,12 @@ func (db *mysql) GetIndexes(queryer core.Queryer, ctx context.Context, tableName
 			indexType = schemas.UniqueType
 		}
 
-		colName = strings.Trim(colName, "` ")
-		var isRegular bool
-		if strings.HasPrefix(indexName, "IDX_"+tableName) || strings.HasPrefix(indexName, "UQE_"+tableName) {
-			indexName = indexName[5+len(tableName):]
-			isRegular = true
+		col := strings.Trim(colName.String, "` ")
+		if !colName.Valid {
+			col = expr.String
 		}
+		var isRegular bool
+		indexName, isRegular = regularIndexName(db, tableName, indexName)
 
 		var index *schemas.Index
 		var ok bool
@@ -617,9 +715,16 @@ func (db *mysql) GetIndexes(queryer core.Queryer, ctx context.Context, tableName
 			index.IsRegular = isRegular
 			index.Type = indexType
 			index.Name = indexName
+			index.Method = strings.ToLower(indexMethod.String)
 			indexes[indexName] = index
 		}
-		index.AddColumn(colName)
+		index.AddColumn(col)
+		if subPart.Valid && subPart.Int64 > 0 {
+			index.SetLength(col, int(subPart.Int64))
+		}
+		if collation.String == "D" {
+			index.SetDesc(col)
+		}
 	}
 	if rows.Err() != nil {
 		return nil, rows.Err()
@@ -627,6 +732,106 @@ func (db *mysql) GetIndexes(queryer core.Queryer, ctx context.Context, tableName
 	return indexes, nil
 }
 
+func (db *mysql) GetForeignKeys(queryer core.Queryer, ctx context.Context, tableName string) (map[string]*schemas.ForeignKey, error) {
+	schema, tableName := db.schemaOf(ctx, tableName)
+	args := []interface{}{schema, tableName}
+	s := "SELECT k.`CONSTRAINT_NAME`, k.`COLUMN_NAME`, k.`REFERENCED_TABLE_NAME`, k.`REFERENCED_COLUMN_NAME`, r.`UPDATE_RULE`, r.`DELETE_RULE`" +
+		" FROM `INFORMATION_SCHEMA`.`KEY_COLUMN_USAGE` k JOIN `INFORMATION_SCHEMA`.`REFERENTIAL_CONSTRAINTS` r" +
+		" ON k.`CONSTRAINT_SCHEMA` = r.`CONSTRAINT_SCHEMA` AND k.`CONSTRAINT_NAME` = r.`CONSTRAINT_NAME`" +
+		" WHERE k.`TABLE_SCHEMA` = ? AND k.`TABLE_NAME` = ? AND k.`REFERENCED_TABLE_NAME` IS NOT NULL" +
+		" ORDER BY k.`CONSTRAINT_NAME`, k.`ORDINAL_POSITION`"
+	return queryForeignKeys(queryer, ctx, s, args...)
+}
+
+func (db *mysql) GetChecks(queryer core.Queryer, ctx context.Context, tableName string) (map[string]*schemas.Check, error) {
+	// CHECK_CONSTRAINTS is only available since MySQL 8.0.16 and MariaDB 10.2
+	exist, err := db.HasRecords(queryer, ctx, "SELECT `TABLE_NAME` FROM `INFORMATION_SCHEMA`.`TABLES` WHERE `TABLE_SCHEMA` = 'information_schema' AND `TABLE_NAME` = 'CHECK_CONSTRAINTS'")
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return make(map[string]*schemas.Check), nil
+	}
+
+	schema, tableName := db.schemaOf(ctx, tableName)
+	args := []interface{}{schema, tableName}
+	s := "SELECT tc.`CONSTRAINT_NAME`, cc.`CHECK_CLAUSE` FROM `INFORMATION_SCHEMA`.`TABLE_CONSTRAINTS` tc" +
+		" JOIN `INFORMATION_SCHEMA`.`CHECK_CONSTRAINTS` cc" +
+		" ON tc.`CONSTRAINT_SCHEMA` = cc.`CONSTRAINT_SCHEMA` AND tc.`CONSTRAINT_NAME` = cc.`CONSTRAINT_NAME`" +
+		" WHERE tc.`TABLE_SCHEMA` = ? AND tc.`TABLE_NAME` = ? AND tc.`CONSTRAINT_TYPE` = 'CHECK'"
+	checks, err := queryChecks(queryer, ctx, s, args...)
+	if err != nil {
+		return nil, err
+	}
+	if db.isMariaDB(10, 2) {
+		// the checks of the JSON columns are a part of the column type
+		for name, check := range checks {
+			if isJSONValidCheck(name, check.Expr) {
+				delete(checks, name)
+			}
+		}
+	}
+	return checks, nil
+}
+
+// mariaDBJSONColumns returns the columns declared as JSON, MariaDB stores them as LONGTEXT
+// with a json_valid check which is named by the column
+func (db *mysql) mariaDBJSONColumns(queryer core.Queryer, ctx context.Context, schema, tableName string) (map[string]bool, error) {
+	checks, err := queryChecks(queryer, ctx, "SELECT `CONSTRAINT_NAME`, `CHECK_CLAUSE` FROM `INFORMATION_SCHEMA`.`CHECK_CONSTRAINTS`"+
+		" WHERE `CONSTRAINT_SCHEMA` = ? AND `TABLE_NAME` = ?", schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]bool)
+	for name, check := range checks {
+		if isJSONValidCheck(name, check.Expr) {
+			columns[name] = true
+		}
+	}
+	return columns, nil
+}
+
+// isJSONValidCheck returns true if the check is json_valid(`name`) which is added by MariaDB for a JSON column
+func isJSONValidCheck(name, expr string) bool {
+	expr = strings.NewReplacer("`", "", " ", "").Replace(expr)
+	return strings.EqualFold(expr, "json_valid("+name+")")
+}
+
+// CreateSequenceSQL returns a SQL to create a sequence, sequences are supported since MariaDB 10.3
+func (db *mysql) CreateSequenceSQL(ctx context.Context, queryer core.Queryer, seqName string) (string, error) {
+	if !db.isMariaDB(10, 3) {
+		return "", errors.New("unsupported sequence feature")
+	}
+	return fmt.Sprintf("CREATE SEQUENCE IF NOT EXISTS %s START WITH 1 INCREMENT BY 1", db.quoter.Quote(seqName)), nil
+}
+
+func (db *mysql) IsSequenceExist(ctx context.Context, queryer core.Queryer, seqName string) (bool, error) {
+	if !db.isMariaDB(10, 3) {
+		return false, errors.New("unsupported sequence feature")
+	}
+	schema, seqName := db.schemaOf(ctx, seqName)
+	return db.HasRecords(queryer, ctx, "SELECT `TABLE_NAME` FROM `INFORMATION_SCHEMA`.`TABLES` WHERE `TABLE_SCHEMA` = ? AND `TABLE_NAME` = ? AND `TABLE_TYPE` = 'SEQUENCE'", schema, seqName)
+}
+
+func (db *mysql) DropSequenceSQL(seqName string) (string, error) {
+	if !db.isMariaDB(10, 3) {
+		return "", errors.New("unsupported sequence feature")
+	}
+	return fmt.Sprintf("DROP SEQUENCE IF EXISTS %s", db.quoter.Quote(seqName)), nil
+}
+
+// DropCheckSQL returns a SQL to drop a check constraint
+func (db *mysql) DropCheckSQL(tableName string, check *schemas.Check) string {
+	quote := db.dialect.Quoter().Quote
+	return fmt.Sprintf("ALTER TABLE %s DROP CHECK %s", quote(tableName), quote(CheckName(db.dialect, tableName, check)))
+}
+
+// DropForeignKeySQL returns a SQL to drop a foreign key
+func (db *mysql) DropForeignKeySQL(tableName string, fk *schemas.ForeignKey) string {
+	quote := db.dialect.Quoter().Quote
+	return fmt.Sprintf("ALTER TABLE %s DROP FOREIGN KEY %s", quote(tableName), quote(ForeignKeyName(db.dialect, tableName, fk)))
+}
+
 func (db *mysql) CreateTableSQL(ctx context.Context, queryer core.Queryer, table *schemas.Table, tableName string) (string, bool, error) {
 	if tableName == "" {
 		tableName = table.Name
@@ -644,9 +849,8 @@ func (db *mysql) CreateTableSQL(ctx context.Context, queryer core.Queryer, table
 		b.WriteString(s)
 
 		if len(col.Comment) > 0 {
-			b.WriteString(" COMMENT '")
-			b.WriteString(col.Comment)
-			b.WriteString("'")
+			b.WriteString(" COMMENT ")
+			b.WriteString(quoteSQLString(col.Comment))
 		}
 
 		if i != len(table.ColumnsSeq())-1 {
@@ -660,6 +864,9 @@ func (db *mysql) CreateTableSQL(ctx context.Context, queryer core.Queryer, table
 		b.WriteString(")")
 	}
 
+	writeForeignKeys(&b, db.dialect, table, tableName)
+	writeChecks(&b, db.dialect, table, tableName)
+
 	b.WriteString(")")
 
 	if table.StoreEngine != "" {
@@ -682,14 +889,120 @@ func (db *mysql) CreateTableSQL(ctx context.Context, queryer core.Queryer, table
 	}
 
 	if table.Comment != "" {
-		b.WriteString(" COMMENT='")
-		b.WriteString(table.Comment)
-		b.WriteString("'")
+		b.WriteString(" COMMENT=")
+		b.WriteString(quoteSQLString(table.Comment))
+	}
+
+	if table.IsPartitioned() {
+		if err := db.writePartitioning(&b, table.Partitioning); err != nil {
+			return "", false, err
+		}
 	}
 
 	return b.String(), true, nil
 }
 
+// writePartitioning writes the PARTITION BY clause, the COLUMNS variants are used if the
+// partition key has no expressions so that the dates and strings could be the bounds
+func (db *mysql) writePartitioning(b *strings.Builder, partitioning *schemas.Partitioning) error {
+	b.WriteString(" PARTITION BY ")
+	b.WriteString(partitioning.Type)
+	if partitioning.Type != schemas.HashPartitioning {
+		var hasExpr bool
+		for _, col := range partitioning.Cols {
+			hasExpr = hasExpr || schemas.IsIndexExpr(col)
+		}
+		if !hasExpr {
+			b.WriteString(" COLUMNS")
+		}
+	}
+	b.WriteString("(")
+	b.WriteString(partitionKey(db.quoter, partitioning))
+	b.WriteString(")")
+
+	if len(partitioning.Partitions) == 0 {
+		return nil
+	}
+	b.WriteString(" (")
+	for i, partition := range partitioning.Partitions {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		def, err := db.partitionDefinition(partition)
+		if err != nil {
+			return err
+		}
+		b.WriteString(def)
+	}
+	b.WriteString(")")
+	return nil
+}
+
+// partitionDefinition returns the definition of the partition, the lower bounds of the
+// range partitions are ignored since they are the upper bounds of the previous partitions
+func (db *mysql) partitionDefinition(partition *schemas.Partition) (string, error) {
+	name := db.quoter.Quote(partition.Name)
+	switch {
+	case partition.Default:
+		return "", fmt.Errorf("default partitions are not supported by %s", db.uri.DBType)
+	case len(partition.In) > 0:
+		return fmt.Sprintf("PARTITION %s VALUES IN (%s)", name, strings.Join(partition.In, ", ")), nil
+	case partition.Modulus > 0:
+		return "PARTITION " + name, nil
+	default:
+		to := partition.To
+		if to == "" {
+			to = "MAXVALUE"
+		}
+		return fmt.Sprintf("PARTITION %s VALUES LESS THAN (%s)", name, to), nil
+	}
+}
+
+func (db *mysql) GetPartitions(queryer core.Queryer, ctx context.Context, tableName string) ([]*schemas.Partition, error) {
+	s := "SELECT `PARTITION_NAME`, `PARTITION_METHOD`, `PARTITION_DESCRIPTION` FROM `INFORMATION_SCHEMA`.`PARTITIONS` " +
+		"WHERE `TABLE_SCHEMA` = ? AND `TABLE_NAME` = ? AND `PARTITION_NAME` IS NOT NULL ORDER BY `PARTITION_ORDINAL_POSITION`"
+	schema, tableName := db.schemaOf(ctx, tableName)
+	rows, err := queryer.QueryContext(ctx, s, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	partitions := make([]*schemas.Partition, 0)
+	for rows.Next() {
+		var partition schemas.Partition
+		var method, description sql.NullString
+		if err = rows.Scan(&partition.Name, &method, &description); err != nil {
+			return nil, err
+		}
+		switch {
+		case strings.HasPrefix(method.String, schemas.RangePartitioning):
+			partition.To = description.String
+		case strings.HasPrefix(method.String, schemas.ListPartitioning):
+			for _, value := range splitColumnDefs(description.String) {
+				partition.In = append(partition.In, strings.TrimSpace(value))
+			}
+		}
+		partitions = append(partitions, &partition)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return partitions, nil
+}
+
+func (db *mysql) CreatePartitionSQL(tableName string, partition *schemas.Partition) (string, error) {
+	def, err := db.partitionDefinition(partition)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("ALTER TABLE %s ADD PARTITION (%s)", db.quoter.Quote(tableName), def), nil
+}
+
+func (db *mysql) DropPartitionSQL(tableName, partitionName string) (string, error) {
+	return fmt.Sprintf("ALTER TABLE %s DROP PARTITION %s", db.quoter.Quote(tableName), db.quoter.Quote(partitionName)), nil
+}
+
 func (db *mysql) Filters() []Filter {
 	return []Filter{}
 }
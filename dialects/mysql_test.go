@@ -0,0 +1,94 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dialects
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"xorm.io/xorm/schemas"
+)
+
+func TestMySQLPartitionSQL(t *testing.T) {
+	dialect := QueryDialect("mysql")
+	assert.NoError(t, dialect.Init(&URI{DBType: "mysql"}))
+
+	table := schemas.NewTable("event", nil)
+	table.AddColumn(&schemas.Column{Name: "created", SQLType: schemas.SQLType{Name: schemas.Date}, Nullable: false, DefaultIsEmpty: true})
+	table.Partitioning = schemas.NewPartitioning(schemas.RangePartitioning, "created").AddPartition(
+		schemas.RangePartition("p2024_01", "'2024-01-01'", "'2024-02-01'"),
+		schemas.RangePartition("p_max", "'2024-02-01'", ""),
+	)
+	s, _, err := dialect.CreateTableSQL(context.Background(), nil, table, "event")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "CREATE TABLE IF NOT EXISTS `event` (`created` DATE NOT NULL) PARTITION BY RANGE COLUMNS(`created`) "+
+		"(PARTITION `p2024_01` VALUES LESS THAN ('2024-02-01'), PARTITION `p_max` VALUES LESS THAN (MAXVALUE))", s)
+
+	table.Partitioning = schemas.NewPartitioning(schemas.HashPartitioning, "YEAR(created)")
+	s, _, err = dialect.CreateTableSQL(context.Background(), nil, table, "event")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "CREATE TABLE IF NOT EXISTS `event` (`created` DATE NOT NULL) PARTITION BY HASH(YEAR(created))", s)
+
+	s, err = dialect.CreatePartitionSQL("event", schemas.ListPartition("p_eu", "'de'", "'fr'"))
+	assert.NoError(t, err)
+	assert.EqualValues(t, "ALTER TABLE `event` ADD PARTITION (PARTITION `p_eu` VALUES IN ('de', 'fr'))", s)
+	s, err = dialect.DropPartitionSQL("event", "p_eu")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "ALTER TABLE `event` DROP PARTITION `p_eu`", s)
+	_, err = dialect.CreatePartitionSQL("event", schemas.DefaultPartition("p_default"))
+	assert.Error(t, err)
+
+	sqlite := QueryDialect("sqlite3")
+	assert.NoError(t, sqlite.Init(&URI{DBType: "sqlite3"}))
+	_, err = sqlite.CreatePartitionSQL("event", schemas.ListPartition("p_eu", "'de'"))
+	assert.Error(t, err)
+}
+
+func TestMySQLRenameColumnSQL(t *testing.T) {
+	col := &schemas.Column{Name: "name", SQLType: schemas.SQLType{Name: schemas.Varchar}, Length: 64, Nullable: false, DefaultIsEmpty: true, Comment: "the user's name"}
+
+	dialect := QueryDialect("mysql")
+	assert.NoError(t, dialect.Init(&URI{DBType: "mysql"}))
+	assert.EqualValues(t, "ALTER TABLE `user` CHANGE `nick` `name` VARCHAR(64) NOT NULL COMMENT 'the user''s name'", dialect.RenameColumnSQL("user", "nick", col))
+}
+
+func TestMariaDBFeatures(t *testing.T) {
+	dialect := QueryDialect("mysql")
+	assert.NoError(t, dialect.Init(&URI{DBType: "mysql"}))
+	jsonCol := &schemas.Column{Name: "attrs", SQLType: schemas.SQLType{Name: schemas.Json}}
+
+	assert.False(t, dialect.Features().SupportReturning)
+	assert.EqualValues(t, "TEXT", dialect.SQLType(jsonCol))
+	_, err := dialect.DropSequenceSQL("SEQ_USER")
+	assert.Error(t, err)
+
+	dialect.(*mysql).version = &schemas.Version{Number: "10.6.12", Edition: "MariaDB"}
+	assert.True(t, dialect.Features().SupportReturning)
+	assert.EqualValues(t, "JSON", dialect.SQLType(jsonCol))
+	s, err := dialect.DropSequenceSQL("SEQ_USER")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "DROP SEQUENCE IF EXISTS `SEQ_USER`", s)
+	// the SQLs with a queryer depend on the same detected version
+	s, err = dialect.CreateSequenceSQL(context.Background(), nil, "SEQ_USER")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "CREATE SEQUENCE IF NOT EXISTS `SEQ_USER` START WITH 1 INCREMENT BY 1", s)
+
+	dialect.(*mysql).version = &schemas.Version{Number: "10.4.30", Edition: "MariaDB"}
+	assert.False(t, dialect.Features().SupportReturning)
+
+	dialect.(*mysql).version = &schemas.Version{Number: "8.0.36"}
+	assert.False(t, dialect.Features().SupportReturning)
+	assert.EqualValues(t, "TEXT", dialect.SQLType(jsonCol))
+	_, err = dialect.CreateSequenceSQL(context.Background(), nil, "SEQ_USER")
+	assert.Error(t, err)
+}
+
+func TestIsJSONValidCheck(t *testing.T) {
+	assert.True(t, isJSONValidCheck("attrs", "json_valid(`attrs`)"))
+	assert.True(t, isJSONValidCheck("attrs", "JSON_VALID( `attrs` )"))
+	assert.False(t, isJSONValidCheck("attrs", "json_valid(`other`)"))
+	assert.False(t, isJSONValidCheck("attrs", "`attrs` > 0"))
+}
@@ -0,0 +1,178 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dialects
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"xorm.io/xorm/names"
+	"xorm.io/xorm/schemas"
+)
+
+// SetNamingStrategy sets the naming strategy of the indexes, constraints and sequences
+func (db *Base) SetNamingStrategy(strategy names.NamingStrategy) {
+	db.namingStrategy = strategy
+}
+
+// NamingStrategy returns the naming strategy, it's names.PrefixNamingStrategy by default
+func (db *Base) NamingStrategy() names.NamingStrategy {
+	if db.namingStrategy == nil {
+		return names.PrefixNamingStrategy{}
+	}
+	return db.namingStrategy
+}
+
+// rebuildTablePrefix is the prefix of the temporary table when rebuilding a table
+const rebuildTablePrefix = "xorm_rebuild_"
+
+// constraintTableName returns the table name without the schema which the constraints are
+// named after, the constraints of the temporary table are named after the rebuilt one
+func constraintTableName(tableName string) string {
+	_, tableName = SplitTableName(tableName)
+	return strings.TrimPrefix(tableName, rebuildTablePrefix)
+}
+
+// shortenName shortens the name which is longer than the max length by replacing the tail
+// with the hash of the whole name, the same name is always shortened to the same one
+func shortenName(name string, maxLen int) string {
+	if maxLen <= 0 || len(name) <= maxLen {
+		return name
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	suffix := fmt.Sprintf("_%08x", h.Sum32())
+	return name[:maxLen-len(suffix)] + suffix
+}
+
+// IndexName returns the name of the index on the table, the names of the irregular indexes
+// read from the database are returned directly
+func IndexName(dialect Dialect, tableName string, index *schemas.Index) string {
+	if !index.IsRegular {
+		return index.Name
+	}
+	_, tableName = SplitTableName(tableName)
+	var name string
+	if index.Type == schemas.UniqueType {
+		name = dialect.NamingStrategy().UniqueName(tableName, index.Name)
+	} else {
+		name = dialect.NamingStrategy().IndexName(tableName, index.Name)
+	}
+	return shortenName(name, dialect.Features().MaxIdentifierLength)
+}
+
+// ForeignKeyName returns the name of the foreign key on the table, the names which have been
+// generated by the naming strategy are returned directly
+func ForeignKeyName(dialect Dialect, tableName string, fk *schemas.ForeignKey) string {
+	if IsRegularForeignKeyName(dialect, tableName, fk.Name) {
+		return fk.Name
+	}
+	tableName = constraintTableName(tableName)
+	return shortenName(dialect.NamingStrategy().ForeignKeyName(tableName, fk.Name), dialect.Features().MaxIdentifierLength)
+}
+
+// CheckName returns the name of the check constraint on the table, the names which have been
+// generated by the naming strategy are returned directly
+func CheckName(dialect Dialect, tableName string, check *schemas.Check) string {
+	if IsRegularCheckName(dialect, tableName, check.Name) {
+		return check.Name
+	}
+	tableName = constraintTableName(tableName)
+	return shortenName(dialect.NamingStrategy().CheckName(tableName, check.Name), dialect.Features().MaxIdentifierLength)
+}
+
+// SequenceName returns the name of the sequence of the table, the sequence is in the schema
+// of the table
+func SequenceName(dialect Dialect, tableName string) string {
+	schema, tableName := SplitTableName(tableName)
+	return TableNameInSchema(schema, shortenName(dialect.NamingStrategy().SequenceName(tableName), dialect.Features().MaxIdentifierLength))
+}
+
+// AutoincrSequenceName returns the sequence of the autoincrement column, it's the sequence bound
+// by the seq tag on oracle, dameng and postgres or the one named by the naming strategy if the
+// database generates the values by sequences, otherwise it returns an empty string
+func AutoincrSequenceName(dialect Dialect, tableName string, col *schemas.Column) string {
+	if col != nil && col.Sequence != "" && IsSequenceBindable(dialect) {
+		if schema, _ := SplitTableName(col.Sequence); schema != "" {
+			return col.Sequence
+		}
+		schema, _ := SplitTableName(tableName)
+		return TableNameInSchema(schema, col.Sequence)
+	}
+	if TableAutoincrMode(dialect, tableName) != SequenceAutoincrMode {
+		return ""
+	}
+	return SequenceName(dialect, tableName)
+}
+
+// tableAutoincrModer represents a dialect whose existing tables could generate the autoincrement
+// values in different modes, like the Oracle tables created before and after 12c
+type tableAutoincrModer interface {
+	tableAutoincrMode(tableName string) (int, bool)
+	resetTableAutoincrMode(tableName string)
+}
+
+// TableAutoincrMode returns the autoincrement mode of the table, it's the mode of the existing
+// table read from the database if the dialect records it, otherwise the mode of the dialect
+func TableAutoincrMode(dialect Dialect, tableName string) int {
+	if moder, ok := dialect.(tableAutoincrModer); ok {
+		if mode, ok := moder.tableAutoincrMode(tableName); ok {
+			return mode
+		}
+	}
+	return dialect.Features().AutoincrMode
+}
+
+// ResetTableAutoincrMode forgets the recorded autoincrement mode of the table after the table
+// is created or dropped, so the mode will be read from the database again
+func ResetTableAutoincrMode(dialect Dialect, tableName string) {
+	if moder, ok := dialect.(tableAutoincrModer); ok {
+		moder.resetTableAutoincrMode(tableName)
+	}
+}
+
+// IsSequenceBindable returns true if the autoincrement column could be bound to a named sequence
+// by the seq tag, the tag is ignored on the other databases
+func IsSequenceBindable(dialect Dialect) bool {
+	switch dialect.URI().DBType {
+	case schemas.ORACLE, schemas.DAMENG, schemas.POSTGRES:
+		return true
+	}
+	return false
+}
+
+// IsRegularForeignKeyName returns true if the name of the foreign key read from the database
+// is generated by the naming strategy
+func IsRegularForeignKeyName(dialect Dialect, tableName, fkName string) bool {
+	tableName = constraintTableName(tableName)
+	return hasNamePrefix(fkName, dialect.NamingStrategy().ForeignKeyName(tableName, ""))
+}
+
+// IsRegularCheckName returns true if the name of the check constraint read from the database
+// is generated by the naming strategy
+func IsRegularCheckName(dialect Dialect, tableName, checkName string) bool {
+	tableName = constraintTableName(tableName)
+	return hasNamePrefix(checkName, dialect.NamingStrategy().CheckName(tableName, ""))
+}
+
+// regularIndexName returns the name of the index without the prefix generated by the naming
+// strategy and true, or the name itself and false if the index is not named by the strategy
+func regularIndexName(dialect Dialect, tableName, indexName string) (string, bool) {
+	_, tableName = SplitTableName(tableName)
+	for _, prefix := range []string{
+		dialect.NamingStrategy().IndexName(tableName, ""),
+		dialect.NamingStrategy().UniqueName(tableName, ""),
+	} {
+		if hasNamePrefix(indexName, prefix) {
+			return indexName[len(prefix):], true
+		}
+	}
+	return indexName, false
+}
+
+func hasNamePrefix(name, prefix string) bool {
+	return prefix != "" && len(name) > len(prefix) && strings.HasPrefix(name, prefix)
+}
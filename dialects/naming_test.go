@@ -0,0 +1,132 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dialects
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"xorm.io/xorm/names"
+	"xorm.io/xorm/schemas"
+)
+
+type lowerNamingStrategy struct{}
+
+func (lowerNamingStrategy) IndexName(tableName, indexName string) string {
+	return "ix_" + tableName + "_" + indexName
+}
+
+func (lowerNamingStrategy) UniqueName(tableName, indexName string) string {
+	return "uq_" + tableName + "_" + indexName
+}
+
+func (lowerNamingStrategy) ForeignKeyName(tableName, fkName string) string {
+	return "fk_" + tableName + "_" + fkName
+}
+
+func (lowerNamingStrategy) CheckName(tableName, checkName string) string {
+	return "ck_" + tableName + "_" + checkName
+}
+
+func (lowerNamingStrategy) SequenceName(tableName string) string {
+	return tableName + "_id_seq"
+}
+
+var _ names.NamingStrategy = lowerNamingStrategy{}
+
+func TestNamingStrategy(t *testing.T) {
+	dialect := QueryDialect("postgres")
+	assert.NoError(t, dialect.Init(&URI{DBType: "postgres", Schema: "public"}))
+
+	index := schemas.NewIndex("name", schemas.IndexType)
+	index.AddColumn("name")
+	assert.EqualValues(t, `CREATE INDEX "IDX_user_name" ON "public"."user" ("name")`, dialect.CreateIndexSQL("public.user", index))
+
+	dialect.SetNamingStrategy(lowerNamingStrategy{})
+	assert.EqualValues(t, `CREATE INDEX "ix_user_name" ON "public"."user" ("name")`, dialect.CreateIndexSQL("public.user", index))
+	assert.EqualValues(t, `DROP INDEX "public"."ix_user_name"`, dialect.DropIndexSQL("public.user", index))
+	assert.EqualValues(t, "public.user_id_seq", SequenceName(dialect, "public.user"))
+
+	fk := schemas.NewForeignKey("user_id", "user")
+	fk.AddColumn("user_id", "id")
+	assert.EqualValues(t, "fk_post_user_id", ForeignKeyName(dialect, "post", fk))
+	assert.True(t, IsRegularForeignKeyName(dialect, "post", "fk_post_user_id"))
+	assert.False(t, IsRegularForeignKeyName(dialect, "post", "FK_post_user_id"))
+	// the names read from the database are not named again
+	fk.Name = "fk_post_user_id"
+	assert.EqualValues(t, "fk_post_user_id", ForeignKeyName(dialect, "post", fk))
+
+	check := schemas.NewCheck("age", "age > 0")
+	assert.EqualValues(t, "ck_user_age", CheckName(dialect, "user", check))
+	assert.True(t, IsRegularCheckName(dialect, "user", "ck_user_age"))
+
+	name, isRegular := regularIndexName(dialect, "user", "uq_user_email")
+	assert.True(t, isRegular)
+	assert.EqualValues(t, "email", name)
+	name, isRegular = regularIndexName(dialect, "user", "user_email_key")
+	assert.False(t, isRegular)
+	assert.EqualValues(t, "user_email_key", name)
+}
+
+func TestNamingStrategyMaxLength(t *testing.T) {
+	dialect := QueryDialect("postgres")
+	assert.NoError(t, dialect.Init(&URI{DBType: "postgres"}))
+
+	tableName := "a_table_with_a_long_name"
+	index := schemas.NewIndex("a_very_long_column_name_for_the_identifier_limit", schemas.UniqueType)
+	name := IndexName(dialect, tableName, index)
+	assert.Len(t, name, 63)
+	assert.True(t, strings.HasPrefix(name, "UQE_"+tableName+"_"))
+	assert.EqualValues(t, name, IndexName(dialect, tableName, index))
+
+	// the shortened name read from the database is recognized as the same index
+	indexName, isRegular := regularIndexName(dialect, tableName, name)
+	assert.True(t, isRegular)
+	assert.EqualValues(t, name, IndexName(dialect, tableName, schemas.NewIndex(indexName, schemas.UniqueType)))
+
+	sqlite := QueryDialect("sqlite3")
+	assert.NoError(t, sqlite.Init(&URI{DBType: "sqlite3"}))
+	assert.EqualValues(t, "UQE_"+tableName+"_a_very_long_column_name_for_the_identifier_limit", IndexName(sqlite, tableName, index))
+}
+
+func TestAutoincrSequence(t *testing.T) {
+	col := &schemas.Column{Name: "id", IsAutoIncrement: true}
+	seqCol := &schemas.Column{Name: "id", IsAutoIncrement: true, Sequence: "SEQ_USER_ID"}
+
+	ora := QueryDialect("oracle")
+	assert.NoError(t, ora.Init(&URI{DBType: schemas.ORACLE}))
+	assert.EqualValues(t, "SEQ_USER", AutoincrSequenceName(ora, "USER", col))
+	assert.EqualValues(t, "SEQ_USER.nextval", AutoincrNextvalSQL(ora, "USER", col))
+	assert.EqualValues(t, "SEQ_USER_ID.nextval", AutoincrNextvalSQL(ora, "USER", seqCol))
+	assert.EqualValues(t, "HR.SEQ_USER_ID", AutoincrSequenceName(ora, "HR.USER", seqCol))
+
+	// the identity columns generate the values on Oracle 12c and later
+	ora.(*oracle).version = &schemas.Version{Number: "Oracle Database 12c Enterprise Edition Release 12.1.0.2.0 - 64bit Production"}
+	assert.EqualValues(t, IdentityAutoincrMode, ora.Features().AutoincrMode)
+	assert.EqualValues(t, "", AutoincrSequenceName(ora, "USER", col))
+	assert.EqualValues(t, "", AutoincrNextvalSQL(ora, "USER", col))
+	assert.EqualValues(t, "SEQ_USER_ID.nextval", AutoincrNextvalSQL(ora, "USER", seqCol))
+
+	// the existing tables created with the sequences before 12c still use them
+	ora.(*oracle).setAutoincrMode("OLD_USER", false)
+	assert.EqualValues(t, SequenceAutoincrMode, TableAutoincrMode(ora, "OLD_USER"))
+	assert.EqualValues(t, "SEQ_OLD_USER", AutoincrSequenceName(ora, "OLD_USER", col))
+	assert.EqualValues(t, "SEQ_OLD_USER.nextval", AutoincrNextvalSQL(ora, "OLD_USER", col))
+	ora.(*oracle).setAutoincrMode("USER", true)
+	assert.EqualValues(t, IdentityAutoincrMode, TableAutoincrMode(ora, "USER"))
+	assert.EqualValues(t, "", AutoincrNextvalSQL(ora, "USER", col))
+
+	pg := QueryDialect("postgres")
+	assert.NoError(t, pg.Init(&URI{DBType: schemas.POSTGRES}))
+	assert.EqualValues(t, "", AutoincrNextvalSQL(pg, "user", col))
+	assert.EqualValues(t, `nextval('"SEQ_USER_ID"')`, AutoincrNextvalSQL(pg, "user", seqCol))
+
+	sqlite := QueryDialect("sqlite3")
+	assert.NoError(t, sqlite.Init(&URI{DBType: schemas.SQLITE}))
+	assert.False(t, IsSequenceBindable(sqlite))
+	assert.EqualValues(t, "", AutoincrSequenceName(sqlite, "user", seqCol))
+	assert.EqualValues(t, "", AutoincrNextvalSQL(sqlite, "user", seqCol))
+}
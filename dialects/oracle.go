@@ -12,6 +12,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 
 	"xorm.io/xorm/core"
 	"xorm.io/xorm/schemas"
@@ -509,6 +510,9 @@ var (
 
 type oracle struct {
 	Base
+
+	autoincrModesMutex sync.RWMutex
+	autoincrModes      map[string]int // the autoincrement modes of the existing tables by the lower names
 }
 
 func (db *oracle) Init(uri *URI) error {
@@ -539,9 +543,116 @@ func (db *oracle) Version(ctx context.Context, queryer core.Queryer) (*schemas.V
 	}, nil
 }
 
+// oracleReleaseRegexp matches the release of the version banner like
+// Oracle Database 12c Enterprise Edition Release 12.1.0.2.0 - 64bit Production
+var oracleReleaseRegexp = regexp.MustCompile(`Release (\d+(\.\d+)*)`)
+
+// is12c returns true if the detected server is Oracle 12c or later which supports OFFSET FETCH
+// and the identity columns
+func (db *oracle) is12c() bool {
+	version := db.detectedVersion()
+	if version == nil {
+		return false
+	}
+	matches := oracleReleaseRegexp.FindStringSubmatch(version.Number)
+	return len(matches) > 1 && versionAtLeast(matches[1], 12, 0)
+}
+
+func (db *oracle) autoincrModeKey(tableName string) string {
+	schema, name := db.tableSchema(context.Background(), tableName)
+	return strings.ToLower(TableNameInSchema(schema, name))
+}
+
+// setAutoincrMode records the autoincrement mode of the table, identity or sequence, and returns it
+func (db *oracle) setAutoincrMode(tableName string, identity bool) int {
+	mode := SequenceAutoincrMode
+	if identity {
+		mode = IdentityAutoincrMode
+	}
+	db.autoincrModesMutex.Lock()
+	defer db.autoincrModesMutex.Unlock()
+	if db.autoincrModes == nil {
+		db.autoincrModes = make(map[string]int)
+	}
+	db.autoincrModes[db.autoincrModeKey(tableName)] = mode
+	return mode
+}
+
+// tableAutoincrMode implements tableAutoincrModer. On 12c and later, the mode of an existing
+// table is read from the database the first time, since the tables created before could still
+// use the sequences. The modes are also recorded when the columns are loaded.
+func (db *oracle) tableAutoincrMode(tableName string) (int, bool) {
+	db.autoincrModesMutex.RLock()
+	mode, ok := db.autoincrModes[db.autoincrModeKey(tableName)]
+	db.autoincrModesMutex.RUnlock()
+	if ok || db.queryer == nil || !db.is12c() {
+		return mode, ok
+	}
+
+	ctx := context.Background()
+	owner, name := ownerOf(&db.Base, ctx, tableName)
+	rows, err := db.queryer.QueryContext(ctx, "SELECT (SELECT COUNT(*) FROM all_tab_identity_cols i"+
+		" WHERE i.owner = t.owner AND i.table_name = t.table_name) FROM all_tables t WHERE t.table_name = :1 AND t.owner = "+owner, name)
+	if err != nil {
+		return 0, false
+	}
+	defer rows.Close()
+
+	// the table which doesn't exist will be created with the mode of the version
+	if !rows.Next() {
+		return 0, false
+	}
+	var identityCols int
+	if err := rows.Scan(&identityCols); err != nil {
+		return 0, false
+	}
+	return db.setAutoincrMode(tableName, identityCols > 0), true
+}
+
+// resetTableAutoincrMode implements tableAutoincrModer
+func (db *oracle) resetTableAutoincrMode(tableName string) {
+	db.autoincrModesMutex.Lock()
+	delete(db.autoincrModes, db.autoincrModeKey(tableName))
+	db.autoincrModesMutex.Unlock()
+}
+
+// LimitOffsetSQL returns the query paginated by OFFSET FETCH on Oracle 12c and later,
+// otherwise the query is wrapped to filter the records by ROWNUM
+func (db *oracle) LimitOffsetSQL(query *SelectQuery) (string, []interface{}, error) {
+	if db.is12c() {
+		var clause string
+		if query.Offset > 0 {
+			clause = fmt.Sprintf(" OFFSET %d ROWS", query.Offset)
+			if query.Limit != nil {
+				clause += fmt.Sprintf(" FETCH NEXT %d ROWS ONLY", *query.Limit)
+			}
+		} else if query.Limit != nil {
+			clause = fmt.Sprintf(" FETCH FIRST %d ROWS ONLY", *query.Limit)
+		}
+		sql, args := query.Build("", "", nil, clause)
+		return sql, args, nil
+	}
+
+	sql, args := query.Build("", "", nil, "")
+	if query.Limit == nil {
+		return sql, args, nil
+	}
+	rawColStr := query.Columns
+	if rawColStr == "*" {
+		rawColStr = "at.*"
+	}
+	return fmt.Sprintf("SELECT %v FROM (SELECT %v,ROWNUM RN FROM (%v) at WHERE ROWNUM <= %d) aat WHERE RN > %d",
+		query.Columns, rawColStr, sql, query.Offset+*query.Limit, query.Offset), args, nil
+}
+
 func (db *oracle) Features() *DialectFeatures {
+	autoincrMode := SequenceAutoincrMode
+	if db.is12c() {
+		autoincrMode = IdentityAutoincrMode
+	}
 	return &DialectFeatures{
-		AutoincrMode: SequenceAutoincrMode,
+		AutoincrMode:        autoincrMode,
+		MaxIdentifierLength: 30,
 	}
 }
 
@@ -602,6 +713,9 @@ func (db *oracle) IsReserved(name string) bool {
 }
 
 func (db *oracle) DropTableSQL(tableName string) (string, bool) {
+	if schema, name := SplitTableName(tableName); schema != "" {
+		return fmt.Sprintf("DROP TABLE `%s`.`%s`", schema, name), false
+	}
 	return fmt.Sprintf("DROP TABLE `%s`", tableName), false
 }
 
@@ -622,6 +736,12 @@ func (db *oracle) CreateTableSQL(ctx context.Context, queryer core.Queryer, tabl
 			sql += col.String(b.dialect)
 		} else {*/
 		s, _ := ColumnString(db, col, false)
+		if col.IsAutoIncrement && col.Sequence == "" {
+			// the mode of an existing table is kept, the same as the sequence created by Sync
+			if TableAutoincrMode(db, tableName) == IdentityAutoincrMode {
+				s = quoter.Quote(col.Name) + " " + db.SQLType(col) + " GENERATED BY DEFAULT AS IDENTITY NOT NULL"
+			}
+		}
 		sql += s
 		// }
 		sql = strings.TrimSpace(sql)
@@ -634,8 +754,33 @@ func (db *oracle) CreateTableSQL(ctx context.Context, queryer core.Queryer, tabl
 		sql += " ), "
 	}
 
-	sql = sql[:len(sql)-2] + ")"
-	return sql, false, nil
+	var b strings.Builder
+	b.WriteString(sql[:len(sql)-2])
+	writeForeignKeys(&b, db, table, tableName)
+	writeChecks(&b, db, table, tableName)
+	b.WriteString(")")
+	return b.String(), false, nil
+}
+
+func (db *oracle) ModifyColumnSQL(tableName string, col *schemas.Column) string {
+	s, _ := ColumnString(db, col, false)
+	return fmt.Sprintf("ALTER TABLE %s MODIFY (%s)", db.quoter.Quote(tableName), s)
+}
+
+func (db *oracle) AlterColumnNullableSQL(tableName string, col *schemas.Column) string {
+	nullable := "NULL"
+	if !col.Nullable {
+		nullable = "NOT NULL"
+	}
+	return fmt.Sprintf("ALTER TABLE %s MODIFY (%s %s)", db.quoter.Quote(tableName), db.quoter.Quote(col.Name), nullable)
+}
+
+func (db *oracle) AlterColumnDefaultSQL(tableName string, col *schemas.Column) string {
+	def := "NULL"
+	if !col.DefaultIsEmpty {
+		def = defaultValue(col)
+	}
+	return fmt.Sprintf("ALTER TABLE %s MODIFY (%s DEFAULT %s)", db.quoter.Quote(tableName), db.quoter.Quote(col.Name), def)
 }
 
 func (db *oracle) SetQuotePolicy(quotePolicy QuotePolicy) {
@@ -655,27 +800,47 @@ func (db *oracle) SetQuotePolicy(quotePolicy QuotePolicy) {
 	}
 }
 
+// ownerOf returns the SQL expression of the owner and the name of the table, the tables
+// are owned by the current schema if no schema is set
+func ownerOf(db *Base, ctx context.Context, tableName string) (string, string) {
+	schema, tableName := db.tableSchema(ctx, tableName)
+	if schema == "" {
+		return "SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA')", tableName
+	}
+	return "'" + strings.ReplaceAll(schema, "'", "''") + "'", tableName
+}
+
 func (db *oracle) IndexCheckSQL(tableName, idxName string) (string, []interface{}) {
+	owner, tableName := ownerOf(&db.Base, context.Background(), tableName)
 	args := []interface{}{tableName, idxName}
-	return `SELECT INDEX_NAME FROM USER_INDEXES ` +
-		`WHERE TABLE_NAME = :1 AND INDEX_NAME = :2`, args
+	return `SELECT INDEX_NAME FROM ALL_INDEXES ` +
+		`WHERE TABLE_NAME = :1 AND INDEX_NAME = :2 AND TABLE_OWNER = ` + owner, args
 }
 
 func (db *oracle) IsTableExist(queryer core.Queryer, ctx context.Context, tableName string) (bool, error) {
-	return db.HasRecords(queryer, ctx, `SELECT table_name FROM user_tables WHERE table_name = :1`, tableName)
+	owner, tableName := ownerOf(&db.Base, ctx, tableName)
+	return db.HasRecords(queryer, ctx, `SELECT table_name FROM all_tables WHERE table_name = :1 AND owner = `+owner, tableName)
+}
+
+func (db *oracle) IsSequenceExist(ctx context.Context, queryer core.Queryer, seqName string) (bool, error) {
+	owner, seqName := ownerOf(&db.Base, ctx, seqName)
+	return db.HasRecords(queryer, ctx, `SELECT sequence_name FROM all_sequences WHERE sequence_name = :1 AND sequence_owner = `+owner, seqName)
 }
 
 func (db *oracle) IsColumnExist(queryer core.Queryer, ctx context.Context, tableName, colName string) (bool, error) {
+	owner, tableName := ownerOf(&db.Base, ctx, tableName)
 	args := []interface{}{tableName, colName}
-	query := "SELECT column_name FROM USER_TAB_COLUMNS WHERE table_name = :1" +
-		" AND column_name = :2"
+	query := "SELECT column_name FROM ALL_TAB_COLUMNS WHERE table_name = :1" +
+		" AND column_name = :2 AND owner = " + owner
 	return db.HasRecords(queryer, ctx, query, args...)
 }
 
 func (db *oracle) GetColumns(queryer core.Queryer, ctx context.Context, tableName string) ([]string, map[string]*schemas.Column, error) {
+	fullName := tableName
+	owner, tableName := ownerOf(&db.Base, ctx, tableName)
 	args := []interface{}{tableName}
 	s := "SELECT column_name,data_default,data_type,data_length,data_precision,data_scale," +
-		"nullable FROM USER_TAB_COLUMNS WHERE table_name = :1"
+		"nullable FROM ALL_TAB_COLUMNS WHERE table_name = :1 AND owner = " + owner
 
 	rows, err := queryer.QueryContext(ctx, s, args...)
 	if err != nil {
@@ -769,12 +934,26 @@ func (db *oracle) GetColumns(queryer core.Queryer, ctx context.Context, tableNam
 		return nil, nil, rows.Err()
 	}
 
+	if db.is12c() {
+		identity, err := db.HasRecords(queryer, ctx, "SELECT column_name FROM all_tab_identity_cols WHERE table_name = :1 AND owner = "+owner, tableName)
+		if err != nil {
+			return nil, nil, err
+		}
+		db.setAutoincrMode(fullName, identity)
+	}
+
 	return colSeq, cols, nil
 }
 
+func (db *oracle) GetViews(queryer core.Queryer, ctx context.Context) ([]*schemas.Table, error) {
+	owner, _ := ownerOf(&db.Base, ctx, "")
+	return queryViews(queryer, ctx, false, "SELECT view_name, text FROM all_views WHERE owner = "+owner)
+}
+
 func (db *oracle) GetTables(queryer core.Queryer, ctx context.Context) ([]*schemas.Table, error) {
+	owner, _ := ownerOf(&db.Base, ctx, "")
 	args := []interface{}{}
-	s := "SELECT table_name FROM user_tables"
+	s := "SELECT table_name FROM all_tables WHERE owner = " + owner
 
 	rows, err := queryer.QueryContext(ctx, s, args...)
 	if err != nil {
@@ -798,10 +977,65 @@ func (db *oracle) GetTables(queryer core.Queryer, ctx context.Context) ([]*schem
 	return tables, nil
 }
 
+func (db *oracle) GetForeignKeys(queryer core.Queryer, ctx context.Context, tableName string) (map[string]*schemas.ForeignKey, error) {
+	owner, tableName := ownerOf(&db.Base, ctx, tableName)
+	args := []interface{}{tableName}
+	s := "SELECT c.constraint_name, cc.column_name, rc.table_name, rcc.column_name, 'NO ACTION', c.delete_rule" +
+		" FROM all_constraints c JOIN all_cons_columns cc ON cc.owner = c.owner AND cc.constraint_name = c.constraint_name" +
+		" JOIN all_constraints rc ON rc.owner = c.r_owner AND rc.constraint_name = c.r_constraint_name" +
+		" JOIN all_cons_columns rcc ON rcc.owner = rc.owner AND rcc.constraint_name = rc.constraint_name AND rcc.position = cc.position" +
+		" WHERE c.constraint_type = 'R' AND c.table_name = :1 AND c.owner = " + owner + " ORDER BY c.constraint_name, cc.position"
+	return queryForeignKeys(queryer, ctx, s, args...)
+}
+
+func (db *oracle) GetChecks(queryer core.Queryer, ctx context.Context, tableName string) (map[string]*schemas.Check, error) {
+	owner, tableName := ownerOf(&db.Base, ctx, tableName)
+	args := []interface{}{tableName}
+	s := "SELECT constraint_name, search_condition, generated FROM all_constraints WHERE constraint_type = 'C' AND table_name = :1 AND owner = " + owner
+	return queryOracleChecks(queryer, ctx, s, args...)
+}
+
+var oracleNotNullCheckRegexp = regexp.MustCompile(`^"[^"]+" IS NOT NULL$`)
+
+// isNotNullCheck returns true if the check is generated by the database for a NOT NULL column
+func isNotNullCheck(generated, expr string) bool {
+	return generated == "GENERATED NAME" && oracleNotNullCheckRegexp.MatchString(strings.TrimSpace(expr))
+}
+
+// queryOracleChecks reads the checks from a query which returns the constraint name, the search
+// condition and whether the name is generated, the NOT NULL columns are also check constraints
+// on oracle and dameng which are skipped
+func queryOracleChecks(queryer core.Queryer, ctx context.Context, query string, args ...interface{}) (map[string]*schemas.Check, error) {
+	rows, err := queryer.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	checks := make(map[string]*schemas.Check)
+	for rows.Next() {
+		var name, expr, generated string
+		if err = rows.Scan(&name, &expr, &generated); err != nil {
+			return nil, err
+		}
+		if isNotNullCheck(generated, expr) {
+			continue
+		}
+		checks[name] = schemas.NewCheck(name, expr)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return checks, nil
+}
+
 func (db *oracle) GetIndexes(queryer core.Queryer, ctx context.Context, tableName string) (map[string]*schemas.Index, error) {
+	owner, tableName := ownerOf(&db.Base, ctx, tableName)
 	args := []interface{}{tableName}
-	s := "SELECT t.column_name,i.uniqueness,i.index_name FROM user_ind_columns t,user_indexes i " +
-		"WHERE t.index_name = i.index_name and t.table_name = i.table_name and t.table_name =:1"
+	s := "SELECT t.column_name,i.uniqueness,i.index_name,t.descend,e.column_expression FROM all_ind_columns t " +
+		"INNER JOIN all_indexes i ON t.index_owner = i.owner and t.index_name = i.index_name and t.table_name = i.table_name " +
+		"LEFT JOIN all_ind_expressions e ON e.index_owner = t.index_owner and e.index_name = t.index_name and e.column_position = t.column_position " +
+		"WHERE t.table_name =:1 AND t.table_owner = " + owner + " ORDER BY t.column_position"
 
 	rows, err := queryer.QueryContext(ctx, s, args...)
 	if err != nil {
@@ -812,20 +1046,27 @@ func (db *oracle) GetIndexes(queryer core.Queryer, ctx context.Context, tableNam
 	indexes := make(map[string]*schemas.Index)
 	for rows.Next() {
 		var indexType int
-		var indexName, colName, uniqueness string
+		var indexName, colName, uniqueness, descend string
+		var expr sql.NullString
 
-		err = rows.Scan(&colName, &uniqueness, &indexName)
+		err = rows.Scan(&colName, &uniqueness, &indexName, &descend, &expr)
 		if err != nil {
 			return nil, err
 		}
 
+		// a descending column is stored as an expression of the quoted column name
+		if expr.Valid {
+			if e := strings.TrimSpace(expr.String); len(e) > 1 && e[0] == '"' && strings.Count(e, `"`) == 2 && e[len(e)-1] == '"' {
+				colName = strings.Trim(e, `"`)
+			} else {
+				colName = e
+			}
+		}
+
 		indexName = strings.Trim(indexName, `" `)
 
 		var isRegular bool
-		if strings.HasPrefix(indexName, "IDX_"+tableName) || strings.HasPrefix(indexName, "UQE_"+tableName) {
-			indexName = indexName[5+len(tableName):]
-			isRegular = true
-		}
+		indexName, isRegular = regularIndexName(db, tableName, indexName)
 
 		if uniqueness == "UNIQUE" {
 			indexType = schemas.UniqueType
@@ -843,6 +1084,9 @@ func (db *oracle) GetIndexes(queryer core.Queryer, ctx context.Context, tableNam
 			indexes[indexName] = index
 		}
 		index.AddColumn(colName)
+		if descend == "DESC" {
+			index.SetDesc(colName)
+		}
 	}
 	if rows.Err() != nil {
 		return nil, rows.Err()
@@ -1,8 +1,12 @@
 package dialects
 
 import (
+	"context"
 	"reflect"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"xorm.io/xorm/schemas"
 )
 
 func TestParseOracleConnStr(t *testing.T) {
@@ -32,3 +36,94 @@ func TestParseOracleConnStr(t *testing.T) {
 		})
 	}
 }
+
+func TestOracleLimitOffsetSQL(t *testing.T) {
+	dialect := QueryDialect("oracle")
+	assert.NoError(t, dialect.Init(&URI{DBType: "oracle"}))
+	limit := 10
+	query := &SelectQuery{
+		Columns: "*",
+		From:    ` FROM "user"`,
+		OrderBy: ` ORDER BY "id"`,
+		Limit:   &limit,
+		Offset:  20,
+	}
+
+	// ROWNUM is used when the version is unknown
+	sql, _, err := dialect.LimitOffsetSQL(query)
+	assert.NoError(t, err)
+	assert.EqualValues(t, `SELECT * FROM (SELECT at.*,ROWNUM RN FROM (SELECT * FROM "user" ORDER BY "id") at WHERE ROWNUM <= 30) aat WHERE RN > 20`, sql)
+
+	dialect.(*oracle).version = &schemas.Version{Number: "Oracle Database 11g Enterprise Edition Release 11.2.0.1.0 - 64bit Production"}
+	sql, _, err = dialect.LimitOffsetSQL(query)
+	assert.NoError(t, err)
+	assert.EqualValues(t, `SELECT * FROM (SELECT at.*,ROWNUM RN FROM (SELECT * FROM "user" ORDER BY "id") at WHERE ROWNUM <= 30) aat WHERE RN > 20`, sql)
+
+	dialect.(*oracle).version = &schemas.Version{Number: "Oracle Database 19c Enterprise Edition Release 19.0.0.0.0 - Production"}
+	sql, _, err = dialect.LimitOffsetSQL(query)
+	assert.NoError(t, err)
+	assert.EqualValues(t, `SELECT * FROM "user" ORDER BY "id" OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY`, sql)
+
+	query.Offset = 0
+	sql, _, err = dialect.LimitOffsetSQL(query)
+	assert.NoError(t, err)
+	assert.EqualValues(t, `SELECT * FROM "user" ORDER BY "id" FETCH FIRST 10 ROWS ONLY`, sql)
+
+	query.Limit, query.Offset = nil, 20
+	sql, _, err = dialect.LimitOffsetSQL(query)
+	assert.NoError(t, err)
+	assert.EqualValues(t, `SELECT * FROM "user" ORDER BY "id" OFFSET 20 ROWS`, sql)
+}
+
+func TestOracleIdentityColumn(t *testing.T) {
+	dialect := QueryDialect("oracle")
+	assert.NoError(t, dialect.Init(&URI{DBType: "oracle"}))
+
+	table := schemas.NewEmptyTable()
+	table.Name = "USER"
+	col := schemas.NewColumn("ID", "Id", schemas.SQLType{Name: schemas.BigInt}, 0, 0, false)
+	col.IsPrimaryKey, col.IsAutoIncrement = true, true
+	table.AddColumn(col)
+	table.PrimaryKeys = []string{"ID"}
+
+	sqlStr, _, err := dialect.CreateTableSQL(context.Background(), nil, table, "")
+	assert.NoError(t, err)
+	assert.EqualValues(t, `CREATE TABLE "USER" ("ID" NUMBER NOT NULL, PRIMARY KEY ( "ID" ))`, sqlStr)
+	// building the SQL records nothing
+	_, ok := dialect.(*oracle).tableAutoincrMode("USER")
+	assert.False(t, ok)
+
+	dialect.(*oracle).version = &schemas.Version{Number: "Oracle Database 19c Enterprise Edition Release 19.0.0.0.0 - Production"}
+	// the existing table created with the sequence keeps using it
+	dialect.(*oracle).setAutoincrMode("USER", false)
+	sqlStr, _, err = dialect.CreateTableSQL(context.Background(), nil, table, "")
+	assert.NoError(t, err)
+	assert.EqualValues(t, `CREATE TABLE "USER" ("ID" NUMBER NOT NULL, PRIMARY KEY ( "ID" ))`, sqlStr)
+	assert.EqualValues(t, "SEQ_USER.nextval", AutoincrNextvalSQL(dialect, "USER", col))
+
+	// the mode is kept until the table is dropped
+	_, _ = dialect.DropTableSQL("USER")
+	assert.EqualValues(t, SequenceAutoincrMode, TableAutoincrMode(dialect, "USER"))
+
+	// the table is created with the identity column after it's dropped
+	ResetTableAutoincrMode(dialect, "USER")
+	sqlStr, _, err = dialect.CreateTableSQL(context.Background(), nil, table, "")
+	assert.NoError(t, err)
+	assert.EqualValues(t, `CREATE TABLE "USER" ("ID" NUMBER GENERATED BY DEFAULT AS IDENTITY NOT NULL, PRIMARY KEY ( "ID" ))`, sqlStr)
+	// the inserts of the created table don't use the sequence
+	assert.EqualValues(t, IdentityAutoincrMode, TableAutoincrMode(dialect, "USER"))
+	assert.EqualValues(t, "", AutoincrNextvalSQL(dialect, "USER", col))
+
+	// the column bound to a sequence isn't an identity column
+	col.Sequence = "SEQ_USER_ID"
+	sqlStr, _, err = dialect.CreateTableSQL(context.Background(), nil, table, "")
+	assert.NoError(t, err)
+	assert.EqualValues(t, `CREATE TABLE "USER" ("ID" NUMBER NOT NULL, PRIMARY KEY ( "ID" ))`, sqlStr)
+}
+
+func TestOracleNotNullCheck(t *testing.T) {
+	assert.True(t, isNotNullCheck("GENERATED NAME", `"EMAIL" IS NOT NULL`))
+	assert.False(t, isNotNullCheck("USER NAME", `"EMAIL" IS NOT NULL`))
+	assert.False(t, isNotNullCheck("GENERATED NAME", `email IS NOT NULL`))
+	assert.False(t, isNotNullCheck("GENERATED NAME", `"A" IS NOT NULL OR "B" IS NOT NULL`))
+}
@@ -840,6 +840,15 @@ func (db *postgres) Version(ctx context.Context, queryer core.Queryer) (*schemas
 	return nil, errors.New("unknow database version")
 }
 
+// schemaOf returns the schema and the name of the table, the default schema is public
+func (db *postgres) schemaOf(ctx context.Context, tableName string) (string, string) {
+	schema, tableName := db.tableSchema(ctx, tableName)
+	if schema == "" {
+		schema = DefaultPostgresSchema
+	}
+	return schema, tableName
+}
+
 func (db *postgres) getSchema() string {
 	if db.uri.Schema != "" {
 		return db.uri.Schema
@@ -886,12 +895,13 @@ func (db *postgres) SQLType(c *schemas.Column) string {
 		res = schemas.Boolean
 		return res
 	case schemas.MediumInt, schemas.Int, schemas.Integer, schemas.UnsignedMediumInt, schemas.UnsignedSmallInt:
-		if c.IsAutoIncrement {
+		// the column bound to a sequence by the seq tag isn't a serial
+		if c.IsAutoIncrement && c.Sequence == "" {
 			return schemas.Serial
 		}
 		return schemas.Integer
 	case schemas.BigInt, schemas.UnsignedBigInt, schemas.UnsignedInt:
-		if c.IsAutoIncrement {
+		if c.IsAutoIncrement && c.Sequence == "" {
 			return schemas.BigSerial
 		}
 		return schemas.BigInt
@@ -919,6 +929,13 @@ func (db *postgres) SQLType(c *schemas.Column) string {
 		return schemas.Bytea
 	case schemas.Double:
 		return "DOUBLE PRECISION"
+	case schemas.Array:
+		// the arrays are TEXT[] if the element type is not given
+		elemType := schemas.Text
+		if len(c.TypeParams) > 0 {
+			elemType = c.TypeParams[0]
+		}
+		return db.SQLType(&schemas.Column{SQLType: schemas.SQLType{Name: elemType}}) + "[]"
 	default:
 		if c.IsAutoIncrement {
 			return schemas.Serial
@@ -943,7 +960,9 @@ func (db *postgres) SQLType(c *schemas.Column) string {
 
 func (db *postgres) Features() *DialectFeatures {
 	return &DialectFeatures{
-		AutoincrMode: IncrAutoincrMode,
+		AutoincrMode:        IncrAutoincrMode,
+		MaxIdentifierLength: 63,
+		SupportReturning:    true,
 	}
 }
 
@@ -972,23 +991,43 @@ func (db *postgres) AutoIncrStr() string {
 }
 
 func (db *postgres) IndexCheckSQL(tableName, idxName string) (string, []interface{}) {
-	if len(db.getSchema()) == 0 {
+	schema, tableName := db.schemaOf(context.Background(), tableName)
+	if len(schema) == 0 {
 		args := []interface{}{tableName, idxName}
 		return `SELECT indexname FROM pg_indexes WHERE tablename = ? AND indexname = ?`, args
 	}
 
-	args := []interface{}{db.getSchema(), tableName, idxName}
+	args := []interface{}{schema, tableName, idxName}
 	return `SELECT indexname FROM pg_indexes ` +
 		`WHERE schemaname = ? AND tablename = ? AND indexname = ?`, args
 }
 
 func (db *postgres) IsTableExist(queryer core.Queryer, ctx context.Context, tableName string) (bool, error) {
-	if len(db.getSchema()) == 0 {
+	schema, tableName := db.schemaOf(ctx, tableName)
+	if len(schema) == 0 {
 		return db.HasRecords(queryer, ctx, `SELECT tablename FROM pg_tables WHERE tablename = $1`, tableName)
 	}
 
 	return db.HasRecords(queryer, ctx, `SELECT tablename FROM pg_tables WHERE schemaname = $1 AND tablename = $2`,
-		db.getSchema(), tableName)
+		schema, tableName)
+}
+
+// CreateSequenceSQL returns a SQL to create the sequence bound to a column by the seq tag
+func (db *postgres) CreateSequenceSQL(ctx context.Context, queryer core.Queryer, seqName string) (string, error) {
+	return fmt.Sprintf("CREATE SEQUENCE IF NOT EXISTS %s START WITH 1 INCREMENT BY 1", db.quoter.Quote(seqName)), nil
+}
+
+func (db *postgres) IsSequenceExist(ctx context.Context, queryer core.Queryer, seqName string) (bool, error) {
+	schema, seqName := db.schemaOf(ctx, seqName)
+	if len(schema) == 0 {
+		return db.HasRecords(queryer, ctx, `SELECT sequence_name FROM information_schema.sequences WHERE sequence_name = $1`, seqName)
+	}
+	return db.HasRecords(queryer, ctx, `SELECT sequence_name FROM information_schema.sequences WHERE sequence_schema = $1 AND sequence_name = $2`,
+		schema, seqName)
+}
+
+func (db *postgres) DropSequenceSQL(seqName string) (string, error) {
+	return fmt.Sprintf("DROP SEQUENCE IF EXISTS %s", db.quoter.Quote(seqName)), nil
 }
 
 func (db *postgres) AddColumnSQL(tableName string, col *schemas.Column) string {
@@ -1012,43 +1051,56 @@ func (db *postgres) ModifyColumnSQL(tableName string, col *schemas.Column) strin
 	quoter := db.dialect.Quoter()
 	modifyColumnSQL := ""
 	commentSQL := "; "
+	// the elements of the arrays are converted to the new element type
+	var using string
+	if col.SQLType.IsArray() {
+		using = fmt.Sprintf(" USING %s::%s", quoter.Quote(col.Name), db.SQLType(col))
+	}
 
 	if len(db.getSchema()) == 0 || strings.Contains(tableName, ".") {
-		modifyColumnSQL = fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s", quoter.Quote(tableName), quoter.Quote(col.Name), db.SQLType(col))
+		modifyColumnSQL = fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s%s", quoter.Quote(tableName), quoter.Quote(col.Name), db.SQLType(col), using)
 		commentSQL += fmt.Sprintf("COMMENT ON COLUMN %s.%s IS '%s'", quoter.Quote(tableName), quoter.Quote(col.Name), col.Comment)
 		return modifyColumnSQL + commentSQL
 	}
 
-	modifyColumnSQL = fmt.Sprintf("ALTER TABLE %s.%s ALTER COLUMN %s TYPE %s", quoter.Quote(db.getSchema()), quoter.Quote(tableName), quoter.Quote(col.Name), db.SQLType(col))
+	modifyColumnSQL = fmt.Sprintf("ALTER TABLE %s.%s ALTER COLUMN %s TYPE %s%s", quoter.Quote(db.getSchema()), quoter.Quote(tableName), quoter.Quote(col.Name), db.SQLType(col), using)
 	commentSQL += fmt.Sprintf("COMMENT ON COLUMN %s.%s.%s IS '%s'", quoter.Quote(db.getSchema()), quoter.Quote(tableName), quoter.Quote(col.Name), col.Comment)
 	return modifyColumnSQL + commentSQL
 }
 
-func (db *postgres) DropIndexSQL(tableName string, index *schemas.Index) string {
-	idxName := index.Name
+func (db *postgres) AlterColumnNullableSQL(tableName string, col *schemas.Column) string {
+	quoter := db.dialect.Quoter()
+	action := "DROP NOT NULL"
+	if !col.Nullable {
+		action = "SET NOT NULL"
+	}
+	return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s", quoter.Quote(TableNameWithSchema(db, tableName)), quoter.Quote(col.Name), action)
+}
 
-	tableParts := strings.Split(strings.Replace(tableName, `"`, "", -1), ".")
-	tableName = tableParts[len(tableParts)-1]
+func (db *postgres) AlterColumnDefaultSQL(tableName string, col *schemas.Column) string {
+	return db.Base.AlterColumnDefaultSQL(TableNameWithSchema(db, tableName), col)
+}
 
-	if !strings.HasPrefix(idxName, "UQE_") &&
-		!strings.HasPrefix(idxName, "IDX_") {
-		if index.Type == schemas.UniqueType {
-			idxName = fmt.Sprintf("UQE_%v_%v", tableName, index.Name)
-		} else {
-			idxName = fmt.Sprintf("IDX_%v_%v", tableName, index.Name)
-		}
-	}
-	if db.getSchema() != "" {
-		idxName = db.getSchema() + "." + idxName
-	}
-	return fmt.Sprintf("DROP INDEX %v", db.Quoter().Quote(idxName))
+func (db *postgres) RenameColumnSQL(tableName, oldName string, col *schemas.Column) string {
+	return db.Base.RenameColumnSQL(TableNameWithSchema(db, tableName), oldName, col)
+}
+
+func (db *postgres) DropColumnSQL(tableName, colName string) string {
+	return db.Base.DropColumnSQL(TableNameWithSchema(db, tableName), colName)
+}
+
+func (db *postgres) DropIndexSQL(tableName string, index *schemas.Index) string {
+	idxName := IndexName(db, tableName, index)
+	schema, _ := db.schemaOf(context.Background(), tableName)
+	return fmt.Sprintf("DROP INDEX %v", db.Quoter().Quote(schema+"."+idxName))
 }
 
 func (db *postgres) IsColumnExist(queryer core.Queryer, ctx context.Context, tableName, colName string) (bool, error) {
-	args := []interface{}{db.getSchema(), tableName, colName}
+	schema, tableName := db.schemaOf(ctx, tableName)
+	args := []interface{}{schema, tableName, colName}
 	query := "SELECT column_name FROM INFORMATION_SCHEMA.COLUMNS WHERE table_schema = $1 AND table_name = $2" +
 		" AND column_name = $3"
-	if len(db.getSchema()) == 0 {
+	if len(schema) == 0 {
 		args = []interface{}{tableName, colName}
 		query = "SELECT column_name FROM INFORMATION_SCHEMA.COLUMNS WHERE table_name = $1" +
 			" AND column_name = $2"
@@ -1067,8 +1119,9 @@ func (db *postgres) IsColumnExist(queryer core.Queryer, ctx context.Context, tab
 }
 
 func (db *postgres) GetColumns(queryer core.Queryer, ctx context.Context, tableName string) ([]string, map[string]*schemas.Column, error) {
+	schema, tableName := db.schemaOf(ctx, tableName)
 	args := []interface{}{tableName}
-	s := `SELECT column_name, column_default, is_nullable, data_type, character_maximum_length, description,
+	s := `SELECT column_name, column_default, is_nullable, data_type, udt_name, character_maximum_length, description,
     CASE WHEN p.contype = 'p' THEN true ELSE false END AS primarykey,
     CASE WHEN p.contype = 'u' THEN true ELSE false END AS uniquekey
 FROM pg_attribute f
@@ -1079,9 +1132,8 @@ FROM pg_attribute f
     LEFT JOIN pg_constraint p ON p.conrelid = c.oid AND f.attnum = ANY (p.conkey)
     LEFT JOIN pg_class AS g ON p.confrelid = g.oid
     LEFT JOIN INFORMATION_SCHEMA.COLUMNS s ON s.column_name=f.attname AND c.relname=s.table_name
-WHERE n.nspname= s.table_schema AND c.relkind = 'r'::char AND c.relname = $1%s AND f.attnum > 0 ORDER BY f.attnum;`
+WHERE n.nspname= s.table_schema AND c.relkind IN ('r', 'p') AND c.relname = $1%s AND f.attnum > 0 ORDER BY f.attnum;`
 
-	schema := db.getSchema()
 	if schema != "" {
 		s = fmt.Sprintf(s, " AND s.table_schema = $2")
 		args = append(args, schema)
@@ -1102,10 +1154,10 @@ WHERE n.nspname= s.table_schema AND c.relkind = 'r'::char AND c.relname = $1%s A
 		col := new(schemas.Column)
 		col.Indexes = make(map[string]int)
 
-		var colName, isNullable, dataType string
+		var colName, isNullable, dataType, udtName string
 		var maxLenStr, colDefault, description *string
 		var isPK, isUnique bool
-		err = rows.Scan(&colName, &colDefault, &isNullable, &dataType, &maxLenStr, &description, &isPK, &isUnique)
+		err = rows.Scan(&colName, &colDefault, &isNullable, &dataType, &udtName, &maxLenStr, &description, &isPK, &isUnique)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -1182,6 +1234,10 @@ WHERE n.nspname= s.table_schema AND c.relkind = 'r'::char AND c.relname = $1%s A
 			col.SQLType = schemas.SQLType{Name: schemas.BigInt, DefaultLength: 0, DefaultLength2: 0}
 		case "array":
 			col.SQLType = schemas.SQLType{Name: schemas.Array, DefaultLength: 0, DefaultLength2: 0}
+			col.TypeParams = []string{pgArrayElementType(udtName)}
+		case "user-defined":
+			// the types of the extensions like hstore
+			col.SQLType = schemas.SQLType{Name: strings.ToUpper(udtName), DefaultLength: 0, DefaultLength2: 0}
 		default:
 			startIdx := strings.Index(strings.ToLower(dataType), "string(")
 			if startIdx != -1 && strings.HasSuffix(dataType, ")") {
@@ -1219,13 +1275,163 @@ WHERE n.nspname= s.table_schema AND c.relkind = 'r'::char AND c.relname = $1%s A
 	return colSeq, cols, nil
 }
 
+func (db *postgres) GetViews(queryer core.Queryer, ctx context.Context) ([]*schemas.Table, error) {
+	schema, _ := db.schemaOf(ctx, "")
+	var args []interface{}
+	var cond string
+	if schema != "" {
+		args = append(args, schema)
+		cond = " WHERE schemaname = $1"
+	}
+	views, err := queryViews(queryer, ctx, false, "SELECT viewname, definition FROM pg_views"+cond, args...)
+	if err != nil {
+		return nil, err
+	}
+	matViews, err := queryViews(queryer, ctx, true, "SELECT matviewname, definition FROM pg_matviews"+cond, args...)
+	if err != nil {
+		return nil, err
+	}
+	return append(views, matViews...), nil
+}
+
+func (db *postgres) CreateViewSQL(viewName string, view *schemas.View) (string, error) {
+	viewName = TableNameWithSchema(db, viewName)
+	if view.Materialized {
+		return fmt.Sprintf("CREATE MATERIALIZED VIEW %s AS %s", db.quoter.Quote(viewName), view.Query), nil
+	}
+	return db.Base.CreateViewSQL(viewName, view)
+}
+
+// ReplaceViewSQL returns an empty string, postgres replaces a view only if the columns are
+// kept, so the views are dropped and created again
+func (db *postgres) ReplaceViewSQL(viewName string, view *schemas.View) string {
+	return ""
+}
+
+func (db *postgres) DropViewSQL(viewName string, view *schemas.View) string {
+	return db.Base.DropViewSQL(TableNameWithSchema(db, viewName), view)
+}
+
+func (db *postgres) RefreshMaterializedViewSQL(viewName string, concurrently bool) (string, error) {
+	var s = "REFRESH MATERIALIZED VIEW "
+	if concurrently {
+		s += "CONCURRENTLY "
+	}
+	return s + db.quoter.Quote(TableNameWithSchema(db, viewName)), nil
+}
+
+func (db *postgres) GetPartitions(queryer core.Queryer, ctx context.Context, tableName string) ([]*schemas.Partition, error) {
+	schema, tableName := db.schemaOf(ctx, tableName)
+	args := []interface{}{tableName}
+	s := `SELECT c.relname, pg_get_expr(c.relpartbound, c.oid) FROM pg_inherits i
+JOIN pg_class c ON c.oid = i.inhrelid
+JOIN pg_class p ON p.oid = i.inhparent
+JOIN pg_namespace n ON n.oid = p.relnamespace
+WHERE p.relname = $1`
+	if len(schema) != 0 {
+		args = append(args, schema)
+		s += " AND n.nspname = $2"
+	}
+	s += " ORDER BY c.relname"
+
+	rows, err := queryer.QueryContext(ctx, s, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	partitions := make([]*schemas.Partition, 0)
+	for rows.Next() {
+		var partition schemas.Partition
+		var bound sql.NullString
+		if err = rows.Scan(&partition.Name, &bound); err != nil {
+			return nil, err
+		}
+		if err = parsePartitionBound(&partition, bound.String); err != nil {
+			return nil, err
+		}
+		partitions = append(partitions, &partition)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return partitions, nil
+}
+
+// parsePartitionBound parses the bound of a partition like FOR VALUES FROM ('2024-01-01') TO ('2024-02-01'),
+// FOR VALUES IN (1, 2), FOR VALUES WITH (modulus 4, remainder 0) or DEFAULT
+func parsePartitionBound(partition *schemas.Partition, bound string) error {
+	bound = strings.TrimSpace(bound)
+	upperBound := strings.ToUpper(bound)
+	switch {
+	case upperBound == "DEFAULT":
+		partition.Default = true
+	case strings.HasPrefix(upperBound, "FOR VALUES FROM "):
+		from, rest := cutParentheses(strings.TrimSpace(bound[len("FOR VALUES FROM "):]))
+		rest = strings.TrimSpace(rest)
+		if !strings.HasPrefix(strings.ToUpper(rest), "TO ") {
+			return fmt.Errorf("unknown partition bound %s", bound)
+		}
+		to, _ := cutParentheses(strings.TrimSpace(rest[len("TO "):]))
+		partition.From, partition.To = strings.TrimSpace(from), strings.TrimSpace(to)
+	case strings.HasPrefix(upperBound, "FOR VALUES IN "):
+		values, _ := cutParentheses(strings.TrimSpace(bound[len("FOR VALUES IN "):]))
+		for _, value := range splitColumnDefs(values) {
+			partition.In = append(partition.In, strings.TrimSpace(value))
+		}
+	case strings.HasPrefix(upperBound, "FOR VALUES WITH "):
+		if _, err := fmt.Sscanf(strings.ToLower(bound[len("FOR VALUES WITH "):]), "(modulus %d, remainder %d)",
+			&partition.Modulus, &partition.Remainder); err != nil {
+			return fmt.Errorf("unknown partition bound %s: %v", bound, err)
+		}
+	default:
+		return fmt.Errorf("unknown partition bound %s", bound)
+	}
+	return nil
+}
+
+func (db *postgres) CreatePartitionSQL(tableName string, partition *schemas.Partition) (string, error) {
+	var bound string
+	switch {
+	case partition.Default:
+		bound = "DEFAULT"
+	case len(partition.In) > 0:
+		bound = fmt.Sprintf("FOR VALUES IN (%s)", strings.Join(partition.In, ", "))
+	case partition.Modulus > 0:
+		bound = fmt.Sprintf("FOR VALUES WITH (MODULUS %d, REMAINDER %d)", partition.Modulus, partition.Remainder)
+	default:
+		from, to := partition.From, partition.To
+		if from == "" {
+			from = "MINVALUE"
+		}
+		if to == "" {
+			to = "MAXVALUE"
+		}
+		bound = fmt.Sprintf("FOR VALUES FROM (%s) TO (%s)", from, to)
+	}
+	// the partition is created in the schema of the partitioned table
+	schema, _ := db.schemaOf(context.Background(), tableName)
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s PARTITION OF %s %s",
+		db.quoter.Quote(TableNameInSchema(schema, partition.Name)),
+		db.quoter.Quote(TableNameWithSchema(db, tableName)), bound), nil
+}
+
+// DropPartitionSQL returns a SQL to drop the partition, the partitions are tables in postgres
+func (db *postgres) DropPartitionSQL(tableName, partitionName string) (string, error) {
+	schema, _ := db.schemaOf(context.Background(), tableName)
+	return fmt.Sprintf("DROP TABLE IF EXISTS %s", db.quoter.Quote(TableNameInSchema(schema, partitionName))), nil
+}
+
 func (db *postgres) GetTables(queryer core.Queryer, ctx context.Context) ([]*schemas.Table, error) {
+	schema, _ := db.schemaOf(ctx, "")
 	args := []interface{}{}
-	s := "SELECT tablename FROM pg_tables"
-	schema := db.getSchema()
+	// the partitions are tables too but they should be accessed through the partitioned tables
+	s := "SELECT tablename FROM pg_tables t WHERE NOT EXISTS (SELECT 1 FROM pg_inherits i " +
+		"JOIN pg_class c ON c.oid = i.inhrelid JOIN pg_namespace n ON n.oid = c.relnamespace " +
+		"JOIN pg_class p ON p.oid = i.inhparent WHERE p.relkind = 'p' AND c.relname = t.tablename AND n.nspname = t.schemaname)"
 	if schema != "" {
 		args = append(args, schema)
-		s = s + " WHERE schemaname = $1"
+		s = s + " AND t.schemaname = $1"
 	}
 
 	rows, err := queryer.QueryContext(ctx, s, args...)
@@ -1252,21 +1458,17 @@ func (db *postgres) GetTables(queryer core.Queryer, ctx context.Context) ([]*sch
 }
 
 func getIndexColName(indexdef string) []string {
-	var colNames []string
-
-	cs := strings.Split(indexdef, "(")
-	for _, v := range strings.Split(strings.Split(cs[1], ")")[0], ",") {
-		colNames = append(colNames, strings.Split(strings.TrimLeft(v, " "), " ")[0])
-	}
-
-	return colNames
+	index := schemas.NewIndex("", schemas.IndexType)
+	parseIndexSQL(index, indexdef)
+	return index.Cols
 }
 
 func (db *postgres) GetIndexes(queryer core.Queryer, ctx context.Context, tableName string) (map[string]*schemas.Index, error) {
+	schema, tableName := db.schemaOf(ctx, tableName)
 	args := []interface{}{tableName}
 	s := "SELECT indexname, indexdef FROM pg_indexes WHERE tablename=$1"
-	if len(db.getSchema()) != 0 {
-		args = append(args, db.getSchema())
+	if len(schema) != 0 {
+		args = append(args, schema)
 		s += " AND schemaname=$2"
 	}
 
@@ -1280,7 +1482,6 @@ func (db *postgres) GetIndexes(queryer core.Queryer, ctx context.Context, tableN
 	for rows.Next() {
 		var indexType int
 		var indexName, indexdef string
-		var colNames []string
 		err = rows.Scan(&indexName, &indexdef)
 		if err != nil {
 			return nil, err
@@ -1299,28 +1500,18 @@ func (db *postgres) GetIndexes(queryer core.Queryer, ctx context.Context, tableN
 		} else {
 			indexType = schemas.IndexType
 		}
-		colNames = getIndexColName(indexdef)
+		index := &schemas.Index{Type: indexType, Cols: make([]string, 0)}
+		parseIndexSQL(index, indexdef)
 
 		// Oid It's a special index. You can't put it in. TODO: This is not perfect.
-		if indexName == tableName+"_oid_index" && len(colNames) == 1 && colNames[0] == "oid" {
+		if indexName == tableName+"_oid_index" && len(index.Cols) == 1 && index.Cols[0] == "oid" {
 			continue
 		}
 
 		var isRegular bool
-		if strings.HasPrefix(indexName, "IDX_"+tableName) || strings.HasPrefix(indexName, "UQE_"+tableName) {
-			newIdxName := indexName[5+len(tableName):]
-			isRegular = true
-			if newIdxName != "" {
-				indexName = newIdxName
-			}
-		}
+		indexName, isRegular = regularIndexName(db, tableName, indexName)
 
-		index := &schemas.Index{Name: indexName, Type: indexType, Cols: make([]string, 0)}
-		for _, colName := range colNames {
-			col := strings.TrimSpace(strings.Replace(colName, `"`, "", -1))
-			fields := strings.Split(col, " ")
-			index.Cols = append(index.Cols, fields[0])
-		}
+		index.Name = indexName
 		index.IsRegular = isRegular
 		indexes[index.Name] = index
 	}
@@ -1330,6 +1521,66 @@ func (db *postgres) GetIndexes(queryer core.Queryer, ctx context.Context, tableN
 	return indexes, nil
 }
 
+func (db *postgres) GetForeignKeys(queryer core.Queryer, ctx context.Context, tableName string) (map[string]*schemas.ForeignKey, error) {
+	schema, tableName := db.schemaOf(ctx, tableName)
+	args := []interface{}{tableName}
+	s := `SELECT c.conname, a.attname, cf.relname, af.attname,
+CASE c.confupdtype WHEN 'r' THEN 'RESTRICT' WHEN 'c' THEN 'CASCADE' WHEN 'n' THEN 'SET NULL' WHEN 'd' THEN 'SET DEFAULT' ELSE 'NO ACTION' END,
+CASE c.confdeltype WHEN 'r' THEN 'RESTRICT' WHEN 'c' THEN 'CASCADE' WHEN 'n' THEN 'SET NULL' WHEN 'd' THEN 'SET DEFAULT' ELSE 'NO ACTION' END
+FROM pg_constraint c
+JOIN pg_class t ON t.oid = c.conrelid
+JOIN pg_namespace n ON n.oid = t.relnamespace
+JOIN pg_class cf ON cf.oid = c.confrelid
+JOIN LATERAL unnest(c.conkey, c.confkey) WITH ORDINALITY AS k(attnum, refattnum, seq) ON true
+JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum
+JOIN pg_attribute af ON af.attrelid = c.confrelid AND af.attnum = k.refattnum
+WHERE c.contype = 'f' AND t.relname = $1`
+	if len(schema) != 0 {
+		args = append(args, schema)
+		s += " AND n.nspname = $2"
+	}
+	s += " ORDER BY c.conname, k.seq"
+	return queryForeignKeys(queryer, ctx, s, args...)
+}
+
+func (db *postgres) GetChecks(queryer core.Queryer, ctx context.Context, tableName string) (map[string]*schemas.Check, error) {
+	schema, tableName := db.schemaOf(ctx, tableName)
+	args := []interface{}{tableName}
+	s := `SELECT c.conname, pg_get_constraintdef(c.oid) FROM pg_constraint c
+JOIN pg_class t ON t.oid = c.conrelid
+JOIN pg_namespace n ON n.oid = t.relnamespace
+WHERE c.contype = 'c' AND t.relname = $1`
+	if len(schema) != 0 {
+		args = append(args, schema)
+		s += " AND n.nspname = $2"
+	}
+	checks, err := queryChecks(queryer, ctx, s, args...)
+	if err != nil {
+		return nil, err
+	}
+	// the definitions are like CHECK ((amount >= 0)) NOT VALID
+	for _, check := range checks {
+		check.Expr = strings.TrimSuffix(strings.TrimPrefix(check.Expr, "CHECK "), " NOT VALID")
+	}
+	return checks, nil
+}
+
+func (db *postgres) AddCheckSQL(tableName string, check *schemas.Check) string {
+	return db.Base.AddCheckSQL(TableNameWithSchema(db, tableName), check)
+}
+
+func (db *postgres) DropCheckSQL(tableName string, check *schemas.Check) string {
+	return db.Base.DropCheckSQL(TableNameWithSchema(db, tableName), check)
+}
+
+func (db *postgres) AddForeignKeySQL(tableName string, fk *schemas.ForeignKey) string {
+	return db.Base.AddForeignKeySQL(TableNameWithSchema(db, tableName), fk)
+}
+
+func (db *postgres) DropForeignKeySQL(tableName string, fk *schemas.ForeignKey) string {
+	return db.Base.DropForeignKeySQL(TableNameWithSchema(db, tableName), fk)
+}
+
 func (db *postgres) CreateTableSQL(ctx context.Context, queryer core.Queryer, table *schemas.Table, tableName string) (string, bool, error) {
 	quoter := db.dialect.Quoter()
 	if len(db.getSchema()) != 0 && !strings.Contains(tableName, ".") {
@@ -1341,6 +1592,17 @@ func (db *postgres) CreateTableSQL(ctx context.Context, queryer core.Queryer, ta
 		return "", ok, err
 	}
 
+	if table.IsPartitioned() {
+		createTableSQL += fmt.Sprintf(" PARTITION BY %s (%s)", table.Partitioning.Type, partitionKey(quoter, table.Partitioning))
+		for _, partition := range table.Partitioning.Partitions {
+			partitionSQL, err := db.CreatePartitionSQL(tableName, partition)
+			if err != nil {
+				return "", false, err
+			}
+			createTableSQL += "; " + partitionSQL
+		}
+	}
+
 	commentSQL := "; "
 	if table.Comment != "" {
 		// support schema.table -> "schema"."table"
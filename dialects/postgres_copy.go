@@ -0,0 +1,220 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dialects
+
+import (
+	"bufio"
+	"context"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"xorm.io/xorm/core"
+)
+
+var _ BulkLoader = &pqDriver{}
+
+// copyFromSQL returns the COPY FROM STDIN statement of the quoted table and columns
+func copyFromSQL(tableName string, colNames []string) string {
+	return fmt.Sprintf("COPY %s (%s) FROM STDIN", tableName, strings.Join(colNames, ", "))
+}
+
+// BulkLoad implements BulkLoader, lib/pq runs COPY FROM STDIN as a prepared statement in a transaction
+func (p *pqDriver) BulkLoad(ctx context.Context, db *core.DB, tx *core.Tx, tableName string, colNames []string, next func() ([]interface{}, error)) (int64, error) {
+	if tx != nil {
+		return pqCopyIn(ctx, tx, copyFromSQL(tableName, colNames), next)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	loaded, err := pqCopyIn(ctx, tx, copyFromSQL(tableName, colNames), next)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+	return loaded, tx.Commit()
+}
+
+func pqCopyIn(ctx context.Context, tx *core.Tx, query string, next func() ([]interface{}, error)) (int64, error) {
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	var loaded int64
+	for {
+		row, err := next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return 0, err
+		}
+		// the embedded statement is used to avoid the hooks and the logs of every row
+		if _, err := stmt.Stmt.ExecContext(ctx, row...); err != nil {
+			return 0, err
+		}
+		loaded++
+	}
+	// the rows buffered by lib/pq are flushed by the execution without arguments
+	if _, err := stmt.Stmt.ExecContext(ctx); err != nil {
+		return 0, err
+	}
+	return loaded, nil
+}
+
+// BulkLoad implements BulkLoader, the rows are streamed by CopyFrom of the pgx connection. The connection
+// held by a transaction of database/sql cannot be reached, so ErrBulkLoadUnsupported is returned in a
+// transaction and the records are inserted in chunks instead
+func (pgx *pqDriverPgx) BulkLoad(ctx context.Context, db *core.DB, tx *core.Tx, tableName string, colNames []string, next func() ([]interface{}, error)) (int64, error) {
+	if tx != nil {
+		return 0, ErrBulkLoadUnsupported
+	}
+
+	conn, err := db.DB.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	var loaded int64
+	err = conn.Raw(func(driverConn interface{}) error {
+		r, w := io.Pipe()
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_ = w.CloseWithError(writeCopyRows(w, next))
+		}()
+		var err error
+		loaded, err = pgxCopyFrom(ctx, driverConn, r, copyFromSQL(tableName, colNames))
+		// unblock the writer if CopyFrom returns before reading all the rows
+		_ = r.CloseWithError(io.ErrClosedPipe)
+		<-done
+		return err
+	})
+	return loaded, err
+}
+
+// pgxCopyFrom calls Conn().PgConn().CopyFrom of the pgx stdlib connection by reflection,
+// so that neither pgx v4 nor v5 has to be imported
+func pgxCopyFrom(ctx context.Context, driverConn interface{}, r io.Reader, query string) (int64, error) {
+	conn := reflect.ValueOf(driverConn).MethodByName("Conn")
+	if !conn.IsValid() || conn.Type().NumIn() != 0 || conn.Type().NumOut() != 1 {
+		return 0, ErrBulkLoadUnsupported
+	}
+	pgConn := conn.Call(nil)[0].MethodByName("PgConn")
+	if !pgConn.IsValid() || pgConn.Type().NumIn() != 0 || pgConn.Type().NumOut() != 1 {
+		return 0, ErrBulkLoadUnsupported
+	}
+	copyFrom := pgConn.Call(nil)[0].MethodByName("CopyFrom")
+	if !copyFrom.IsValid() || copyFrom.Type().NumIn() != 3 || copyFrom.Type().NumOut() != 2 {
+		return 0, ErrBulkLoadUnsupported
+	}
+
+	res := copyFrom.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(r), reflect.ValueOf(query)})
+	if err, ok := res[1].Interface().(error); ok && err != nil {
+		return 0, err
+	}
+	if tag, ok := res[0].Interface().(interface{ RowsAffected() int64 }); ok {
+		return tag.RowsAffected(), nil
+	}
+	return 0, nil
+}
+
+// writeCopyRows writes the rows returned by next until io.EOF in the text format of COPY
+func writeCopyRows(w io.Writer, next func() ([]interface{}, error)) error {
+	bw := bufio.NewWriter(w)
+	for {
+		row, err := next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		for i, v := range row {
+			if i > 0 {
+				_ = bw.WriteByte('\t')
+			}
+			s, err := formatCopyValue(v)
+			if err != nil {
+				return err
+			}
+			_, _ = bw.WriteString(s)
+		}
+		if err := bw.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+var copyTextEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// formatCopyValue formats a value as a column of the text format of COPY, nil is \N
+func formatCopyValue(v interface{}) (string, error) {
+	if rv := reflect.ValueOf(v); !rv.IsValid() || (rv.Kind() == reflect.Ptr && rv.IsNil()) {
+		return `\N`, nil
+	}
+
+	switch t := v.(type) {
+	case string:
+		return copyTextEscaper.Replace(t), nil
+	case []byte:
+		if t == nil {
+			return `\N`, nil
+		}
+		// the backslash of the hex format of bytea is escaped
+		return `\\x` + hex.EncodeToString(t), nil
+	case bool:
+		if t {
+			return "t", nil
+		}
+		return "f", nil
+	case int:
+		return strconv.FormatInt(int64(t), 10), nil
+	case int8:
+		return strconv.FormatInt(int64(t), 10), nil
+	case int16:
+		return strconv.FormatInt(int64(t), 10), nil
+	case int32:
+		return strconv.FormatInt(int64(t), 10), nil
+	case int64:
+		return strconv.FormatInt(t, 10), nil
+	case uint:
+		return strconv.FormatUint(uint64(t), 10), nil
+	case uint8:
+		return strconv.FormatUint(uint64(t), 10), nil
+	case uint16:
+		return strconv.FormatUint(uint64(t), 10), nil
+	case uint32:
+		return strconv.FormatUint(uint64(t), 10), nil
+	case uint64:
+		return strconv.FormatUint(t, 10), nil
+	case float32:
+		return strconv.FormatFloat(float64(t), 'g', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(t, 'g', -1, 64), nil
+	case time.Time:
+		return t.Format(time.RFC3339Nano), nil
+	case driver.Valuer:
+		value, err := t.Value()
+		if err != nil {
+			return "", err
+		}
+		return formatCopyValue(value)
+	}
+
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr {
+		return formatCopyValue(rv.Elem().Interface())
+	}
+	return copyTextEscaper.Replace(fmt.Sprint(v)), nil
+}
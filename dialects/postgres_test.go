@@ -1,10 +1,15 @@
 package dialects
 
 import (
+	"context"
+	"io"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"xorm.io/xorm/schemas"
 )
 
 func TestParsePostgres(t *testing.T) {
@@ -114,5 +119,271 @@ func TestGetIndexColName(t *testing.T) {
 		assert.Equal(t, []string{"major"}, colNames)
 	})
 
-	t.Run("Indexes on Expressions", func(t *testing.T) {})
+	t.Run("Indexes on Expressions", func(t *testing.T) {
+		s := "CREATE INDEX test1_lower_col1_idx ON public.test1 USING btree (lower((col1)::text), col2 DESC)"
+		colNames := getIndexColName(s)
+		assert.Equal(t, []string{"lower((col1)::text)", "col2"}, colNames)
+	})
+}
+
+func TestPostgresIndexSQL(t *testing.T) {
+	dialect := QueryDialect("postgres")
+	assert.NoError(t, dialect.Init(&URI{DBType: "postgres", Schema: "public"}))
+
+	index := schemas.NewIndex("email", schemas.UniqueType)
+	index.AddColumn("lower(email)", "tenant_id")
+	index.SetDesc("tenant_id")
+	index.SetLength("tenant_id", 10)
+	index.AddInclude("name")
+	index.Where = "deleted_at IS NULL"
+	index.Method = "btree"
+	s := dialect.CreateIndexSQL("user", index)
+	assert.EqualValues(t, `CREATE UNIQUE INDEX "UQE_user_email" ON "user" USING btree ((lower(email)),"tenant_id" DESC) INCLUDE ("name") WHERE deleted_at IS NULL`, s)
+
+	var parsed = &schemas.Index{Type: schemas.UniqueType}
+	parseIndexSQL(parsed, `CREATE UNIQUE INDEX "UQE_user_email" ON public."user" USING btree (lower((email)::text), tenant_id DESC) INCLUDE (name) WHERE (deleted_at IS NULL)`)
+	assert.EqualValues(t, []string{"lower((email)::text)", "tenant_id"}, parsed.Cols)
+	assert.EqualValues(t, []string{"name"}, parsed.Include)
+	assert.True(t, SupportedIndex(dialect, index).Equal(parsed))
+
+	mysql := QueryDialect("mysql")
+	assert.NoError(t, mysql.Init(&URI{DBType: "mysql"}))
+	assert.EqualValues(t, "CREATE UNIQUE INDEX `UQE_user_email` ON `user` ((lower(email)),`tenant_id`(10) DESC) USING BTREE", mysql.CreateIndexSQL("user", index))
+}
+
+func TestPostgresAlterColumnSQL(t *testing.T) {
+	dialect := QueryDialect("postgres")
+	assert.NoError(t, dialect.Init(&URI{DBType: "postgres", Schema: "public"}))
+
+	col := &schemas.Column{Name: "name", Nullable: false, DefaultIsEmpty: true}
+	assert.EqualValues(t, `ALTER TABLE "public"."user" ALTER COLUMN "name" SET NOT NULL`, dialect.AlterColumnNullableSQL("user", col))
+	assert.EqualValues(t, `ALTER TABLE "public"."user" ALTER COLUMN "name" DROP DEFAULT`, dialect.AlterColumnDefaultSQL("user", col))
+
+	col.Nullable = true
+	col.Default = "'lunny'"
+	col.DefaultIsEmpty = false
+	assert.EqualValues(t, `ALTER TABLE "public"."user" ALTER COLUMN "name" DROP NOT NULL`, dialect.AlterColumnNullableSQL("user", col))
+	assert.EqualValues(t, `ALTER TABLE "public"."user" ALTER COLUMN "name" SET DEFAULT 'lunny'`, dialect.AlterColumnDefaultSQL("user", col))
+	assert.EqualValues(t, `ALTER TABLE "public"."user" DROP COLUMN "name"`, dialect.DropColumnSQL("user", "name"))
+	assert.EqualValues(t, `ALTER TABLE "public"."user" RENAME COLUMN "nick" TO "name"`, dialect.RenameColumnSQL("user", "nick", col))
+}
+
+func TestPostgresForeignKeySQL(t *testing.T) {
+	dialect := QueryDialect("postgres")
+	assert.NoError(t, dialect.Init(&URI{DBType: "postgres", Schema: "public"}))
+
+	fk := schemas.NewForeignKey("user_id", "user")
+	fk.AddColumn("user_id", "id")
+	fk.OnDelete = schemas.Cascade
+	fk.OnUpdate = schemas.Restrict
+	assert.EqualValues(t, `ALTER TABLE "public"."post" ADD CONSTRAINT "FK_post_user_id" FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE CASCADE ON UPDATE RESTRICT`,
+		dialect.AddForeignKeySQL("post", fk))
+	assert.EqualValues(t, `ALTER TABLE "public"."post" DROP CONSTRAINT "FK_post_user_id"`, dialect.DropForeignKeySQL("post", fk))
+
+	mssql := QueryDialect("mssql")
+	assert.NoError(t, mssql.Init(&URI{DBType: "mssql"}))
+	assert.EqualValues(t, `CONSTRAINT [FK_post_user_id] FOREIGN KEY ([user_id]) REFERENCES [user] ([id]) ON DELETE CASCADE`,
+		ForeignKeyString(mssql, "post", fk))
+}
+
+func TestPostgresViewSQL(t *testing.T) {
+	dialect := QueryDialect("postgres")
+	assert.NoError(t, dialect.Init(&URI{DBType: "postgres", Schema: "public"}))
+
+	view := schemas.NewView("SELECT id FROM \"user\"", true)
+	s, err := dialect.CreateViewSQL("user_ids", view)
+	assert.NoError(t, err)
+	assert.EqualValues(t, `CREATE MATERIALIZED VIEW "public"."user_ids" AS SELECT id FROM "user"`, s)
+	assert.EqualValues(t, `DROP MATERIALIZED VIEW "public"."user_ids"`, dialect.DropViewSQL("user_ids", view))
+	s, err = dialect.RefreshMaterializedViewSQL("user_ids", true)
+	assert.NoError(t, err)
+	assert.EqualValues(t, `REFRESH MATERIALIZED VIEW CONCURRENTLY "public"."user_ids"`, s)
+
+	mysql := QueryDialect("mysql")
+	assert.NoError(t, mysql.Init(&URI{DBType: "mysql"}))
+	_, err = mysql.CreateViewSQL("user_ids", view)
+	assert.Error(t, err)
+	_, err = mysql.RefreshMaterializedViewSQL("user_ids", false)
+	assert.Error(t, err)
+}
+
+func TestPostgresPartitionSQL(t *testing.T) {
+	dialect := QueryDialect("postgres")
+	assert.NoError(t, dialect.Init(&URI{DBType: "postgres", Schema: "public"}))
+
+	table := schemas.NewTable("event", nil)
+	table.AddColumn(&schemas.Column{Name: "created", SQLType: schemas.SQLType{Name: schemas.Date}, Nullable: false, DefaultIsEmpty: true})
+	table.Partitioning = schemas.NewPartitioning(schemas.RangePartitioning, "created").AddPartition(
+		schemas.RangePartition("event_2024_01", "'2024-01-01'", "'2024-02-01'"),
+		schemas.DefaultPartition("event_default"),
+	)
+	s, _, err := dialect.CreateTableSQL(context.Background(), nil, table, "event")
+	assert.NoError(t, err)
+	assert.EqualValues(t, `CREATE TABLE IF NOT EXISTS "public"."event" ("created" DATE NOT NULL) PARTITION BY RANGE ("created"); `+
+		`CREATE TABLE IF NOT EXISTS "public"."event_2024_01" PARTITION OF "public"."event" FOR VALUES FROM ('2024-01-01') TO ('2024-02-01'); `+
+		`CREATE TABLE IF NOT EXISTS "public"."event_default" PARTITION OF "public"."event" DEFAULT; `, s)
+
+	s, err = dialect.CreatePartitionSQL("event", schemas.HashPartition("event_h0", 4, 0))
+	assert.NoError(t, err)
+	assert.EqualValues(t, `CREATE TABLE IF NOT EXISTS "public"."event_h0" PARTITION OF "public"."event" FOR VALUES WITH (MODULUS 4, REMAINDER 0)`, s)
+	s, err = dialect.DropPartitionSQL("event", "event_h0")
+	assert.NoError(t, err)
+	assert.EqualValues(t, `DROP TABLE IF EXISTS "public"."event_h0"`, s)
+
+	var kases = []struct {
+		bound     string
+		partition schemas.Partition
+	}{
+		{"FOR VALUES FROM ('2024-01-01') TO ('2024-02-01')", schemas.Partition{From: "'2024-01-01'", To: "'2024-02-01'"}},
+		{"FOR VALUES FROM (MINVALUE) TO (100)", schemas.Partition{From: "MINVALUE", To: "100"}},
+		{"FOR VALUES IN ('a', 'b,c')", schemas.Partition{In: []string{"'a'", "'b,c'"}}},
+		{"FOR VALUES WITH (modulus 4, remainder 3)", schemas.Partition{Modulus: 4, Remainder: 3}},
+		{"DEFAULT", schemas.Partition{Default: true}},
+	}
+	for _, kase := range kases {
+		var partition schemas.Partition
+		assert.NoError(t, parsePartitionBound(&partition, kase.bound))
+		assert.EqualValues(t, kase.partition, partition)
+	}
+	assert.Error(t, parsePartitionBound(new(schemas.Partition), "FOR VALUES LESS THAN (1)"))
+}
+
+func TestPostgresArraySQLType(t *testing.T) {
+	dialect := QueryDialect(schemas.POSTGRES)
+	assert.NoError(t, dialect.Init(&URI{DBType: schemas.POSTGRES}))
+
+	var kases = []struct {
+		col      *schemas.Column
+		expected string
+	}{
+		{&schemas.Column{SQLType: schemas.SQLType{Name: schemas.Array}, TypeParams: []string{schemas.BigInt}}, "BIGINT[]"},
+		{&schemas.Column{SQLType: schemas.SQLType{Name: schemas.Array}, TypeParams: []string{schemas.Double}}, "DOUBLE PRECISION[]"},
+		{&schemas.Column{SQLType: schemas.SQLType{Name: schemas.Array}, TypeParams: []string{schemas.TimeStampz}}, "timestamp with time zone[]"},
+		{&schemas.Column{SQLType: schemas.SQLType{Name: schemas.Array}}, "TEXT[]"},
+		{&schemas.Column{SQLType: schemas.SQLType{Name: schemas.Hstore}}, "HSTORE"},
+		{&schemas.Column{SQLType: schemas.SQLType{Name: schemas.TsTzRange}}, "TSTZRANGE"},
+	}
+	for _, kase := range kases {
+		assert.EqualValues(t, kase.expected, dialect.SQLType(kase.col))
+	}
+	assert.EqualValues(t, schemas.BigInt, pgArrayElementType("_int8"))
+	assert.EqualValues(t, "CITEXT", pgArrayElementType("_citext"))
+
+	// the elements are converted when the element type is changed
+	col := &schemas.Column{Name: "scores", SQLType: schemas.SQLType{Name: schemas.Array}, TypeParams: []string{schemas.BigInt}}
+	assert.EqualValues(t, `ALTER TABLE "public"."user" ALTER COLUMN "scores" TYPE BIGINT[] USING "scores"::BIGINT[]; COMMENT ON COLUMN "public"."user"."scores" IS ''`,
+		dialect.ModifyColumnSQL("user", col))
+}
+
+func TestPgArrayLiteral(t *testing.T) {
+	s, err := FormatPgArray(reflect.ValueOf([]string{"a", `b "c"`, `d\e`, ""}))
+	assert.NoError(t, err)
+	assert.EqualValues(t, `{"a","b \"c\"","d\\e",""}`, s)
+
+	elements, err := ParsePgArray(s)
+	assert.NoError(t, err)
+	assert.Len(t, elements, 4)
+	assert.EqualValues(t, `b "c"`, *elements[1])
+	assert.EqualValues(t, `d\e`, *elements[2])
+	assert.EqualValues(t, "", *elements[3])
+
+	one := int64(1)
+	s, err = FormatPgArray(reflect.ValueOf([]*int64{&one, nil}))
+	assert.NoError(t, err)
+	assert.EqualValues(t, "{1,NULL}", s)
+
+	s, err = FormatPgArray(reflect.ValueOf([]time.Time{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}))
+	assert.NoError(t, err)
+	assert.EqualValues(t, `{"2024-01-02T03:04:05Z"}`, s)
+
+	elements, err = ParsePgArray("{1, NULL ,3}")
+	assert.NoError(t, err)
+	assert.Len(t, elements, 3)
+	assert.EqualValues(t, "1", *elements[0])
+	assert.Nil(t, elements[1])
+	assert.EqualValues(t, "3", *elements[2])
+
+	elements, err = ParsePgArray("[0:1]={a,b}")
+	assert.NoError(t, err)
+	assert.Len(t, elements, 2)
+
+	elements, err = ParsePgArray("{}")
+	assert.NoError(t, err)
+	assert.Len(t, elements, 0)
+
+	_, err = ParsePgArray("{{1,2},{3,4}}")
+	assert.Error(t, err)
+	_, err = ParsePgArray(`{"a}`)
+	assert.Error(t, err)
+	_, err = FormatPgArray(reflect.ValueOf(1))
+	assert.Error(t, err)
+
+	tm, err := ParsePgTime("2024-01-02 03:04:05.123+05:30", time.UTC)
+	assert.NoError(t, err)
+	assert.EqualValues(t, time.Date(2024, 1, 1, 21, 34, 5, 123000000, time.UTC).Unix(), tm.Unix())
+	tm, err = ParsePgTime("2024-01-02 03:04:05+00", time.UTC)
+	assert.NoError(t, err)
+	assert.EqualValues(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC).Unix(), tm.Unix())
+}
+
+func TestHstoreLiteral(t *testing.T) {
+	s, err := FormatHstore(reflect.ValueOf(map[string]string{"b": "2", "a": `x"y`}))
+	assert.NoError(t, err)
+	assert.EqualValues(t, `"a"=>"x\"y", "b"=>"2"`, s)
+
+	pairs, err := ParseHstore(`"a"=>"x\"y", "b"=>NULL,"c" => "3"`)
+	assert.NoError(t, err)
+	assert.Len(t, pairs, 3)
+	assert.EqualValues(t, `x"y`, *pairs["a"])
+	assert.Nil(t, pairs["b"])
+	assert.EqualValues(t, "3", *pairs["c"])
+
+	pairs, err = ParseHstore("")
+	assert.NoError(t, err)
+	assert.Len(t, pairs, 0)
+
+	_, err = ParseHstore(`"a"=>`)
+	assert.Error(t, err)
+	_, err = FormatHstore(reflect.ValueOf(map[int]string{1: "a"}))
+	assert.Error(t, err)
+}
+
+func TestWriteCopyRows(t *testing.T) {
+	var name *string
+	rows := [][]interface{}{
+		{int64(1), "a\tb\\c\nd", true, []byte{0xde, 0xad}, nil},
+		{uint8(2), `{"color":"red"}`, false, []byte{}, name},
+		{3.5, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), "", nil, "x"},
+	}
+	var i int
+	var b strings.Builder
+	assert.NoError(t, writeCopyRows(&b, func() ([]interface{}, error) {
+		if i >= len(rows) {
+			return nil, io.EOF
+		}
+		i++
+		return rows[i-1], nil
+	}))
+	assert.EqualValues(t, "1\ta\\tb\\\\c\\nd\tt\t\\\\xdead\t\\N\n"+
+		"2\t{\"color\":\"red\"}\tf\t\\\\x\t\\N\n"+
+		"3.5\t2026-01-02T03:04:05Z\t\t\\N\tx\n", b.String())
+
+	assert.EqualValues(t, `COPY "t" ("a", "b") FROM STDIN`, copyFromSQL(`"t"`, []string{`"a"`, `"b"`}))
+
+	_, err := pgxCopyFrom(context.Background(), struct{}{}, strings.NewReader(""), "")
+	assert.EqualValues(t, ErrBulkLoadUnsupported, err)
+}
+
+func TestPostgresSeqColumn(t *testing.T) {
+	dialect := QueryDialect("postgres")
+	assert.NoError(t, dialect.Init(&URI{DBType: schemas.POSTGRES}))
+
+	col := &schemas.Column{Name: "id", SQLType: schemas.SQLType{Name: schemas.BigInt}, IsAutoIncrement: true}
+	assert.EqualValues(t, schemas.BigSerial, dialect.SQLType(col))
+	col.Sequence = "user_id_seq"
+	assert.EqualValues(t, schemas.BigInt, dialect.SQLType(col))
+
+	sqlStr, err := dialect.CreateSequenceSQL(context.Background(), nil, "user_id_seq")
+	assert.NoError(t, err)
+	assert.EqualValues(t, `CREATE SEQUENCE IF NOT EXISTS "user_id_seq" START WITH 1 INCREMENT BY 1`, sqlStr)
 }
@@ -0,0 +1,290 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dialects
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"xorm.io/xorm/schemas"
+)
+
+// pgArrayElementTypes maps the udt names of the postgres arrays to their element types
+var pgArrayElementTypes = map[string]string{
+	"_int2":        schemas.SmallInt,
+	"_int4":        schemas.Integer,
+	"_int8":        schemas.BigInt,
+	"_float4":      schemas.Real,
+	"_float8":      schemas.Double,
+	"_numeric":     schemas.Numeric,
+	"_bool":        schemas.Bool,
+	"_text":        schemas.Text,
+	"_varchar":     schemas.Varchar,
+	"_bpchar":      schemas.Char,
+	"_uuid":        schemas.Uuid,
+	"_date":        schemas.Date,
+	"_timestamp":   schemas.TimeStamp,
+	"_timestamptz": schemas.TimeStampz,
+	"_jsonb":       schemas.Jsonb,
+}
+
+// pgArrayElementType returns the element type of the postgres array by its udt name like _int8
+func pgArrayElementType(udtName string) string {
+	if t, ok := pgArrayElementTypes[strings.ToLower(udtName)]; ok {
+		return t
+	}
+	return strings.ToUpper(strings.TrimPrefix(udtName, "_"))
+}
+
+// quotePgLiteral quotes an element of the postgres array or hstore literals
+func quotePgLiteral(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// formatPgElement formats a value as an element of the postgres array or hstore literals,
+// the nil pointers are NULL
+func formatPgElement(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "NULL", nil
+		}
+		return formatPgElement(v.Elem())
+	}
+
+	if v.Type().ConvertibleTo(schemas.TimeType) {
+		t := v.Convert(schemas.TimeType).Interface().(time.Time)
+		return quotePgLiteral(t.Format(time.RFC3339Nano)), nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return quotePgLiteral(v.String()), nil
+	case reflect.Bool:
+		if v.Bool() {
+			return "t", nil
+		}
+		return "f", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64), nil
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return quotePgLiteral(s.String()), nil
+	}
+	return "", fmt.Errorf("unsupported postgres array element type %v", v.Type())
+}
+
+// FormatPgArray formats a slice or an array as the literal of a one dimensional postgres array,
+// like {1,2} or {"a","b"}
+func FormatPgArray(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return "", errors.New("nil cannot be a postgres array")
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", fmt.Errorf("%v cannot be a postgres array", v.Type())
+	}
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		s, err := formatPgElement(v.Index(i))
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(s)
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
+// readPgQuoted reads a double quoted string from s[i:], returns the unescaped string and the
+// position after the closing quote
+func readPgQuoted(s string, i int) (string, int, error) {
+	var b strings.Builder
+	for i++; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+			if i < len(s) {
+				b.WriteByte(s[i])
+			}
+		case '"':
+			return b.String(), i + 1, nil
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", i, errors.New("unterminated quoted string")
+}
+
+// ParsePgArray parses the literal of a one dimensional postgres array like {1,NULL,"a b"},
+// the NULL elements are returned as nil
+func ParsePgArray(s string) ([]*string, error) {
+	literal := strings.TrimSpace(s)
+	// the arrays whose lower bounds are not 1 begin with the dimensions like [0:1]={1,2}
+	if strings.HasPrefix(literal, "[") {
+		if idx := strings.Index(literal, "="); idx > 0 {
+			literal = literal[idx+1:]
+		}
+	}
+	if len(literal) < 2 || literal[0] != '{' || literal[len(literal)-1] != '}' {
+		return nil, fmt.Errorf("invalid postgres array %q", s)
+	}
+	literal = literal[1 : len(literal)-1]
+
+	elements := make([]*string, 0)
+	if strings.TrimSpace(literal) == "" {
+		return elements, nil
+	}
+	for i := 0; ; {
+		for i < len(literal) && literal[i] == ' ' {
+			i++
+		}
+		if i < len(literal) && literal[i] == '{' {
+			return nil, fmt.Errorf("multidimensional postgres array %q is not supported", s)
+		}
+		if i < len(literal) && literal[i] == '"' {
+			v, next, err := readPgQuoted(literal, i)
+			if err != nil {
+				return nil, fmt.Errorf("invalid postgres array %q: %v", s, err)
+			}
+			elements = append(elements, &v)
+			i = next
+		} else {
+			end := strings.IndexByte(literal[i:], ',')
+			if end < 0 {
+				end = len(literal) - i
+			}
+			v := strings.TrimSpace(literal[i : i+end])
+			if strings.EqualFold(v, "NULL") {
+				elements = append(elements, nil)
+			} else {
+				elements = append(elements, &v)
+			}
+			i += end
+		}
+		for i < len(literal) && literal[i] == ' ' {
+			i++
+		}
+		if i >= len(literal) {
+			return elements, nil
+		}
+		if literal[i] != ',' {
+			return nil, fmt.Errorf("invalid postgres array %q", s)
+		}
+		i++
+	}
+}
+
+// pgTimeLayouts are the text formats of the postgres dates and timestamps with or without time zone
+var pgTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999",
+	time.RFC3339Nano,
+	"2006-01-02",
+}
+
+// ParsePgTime parses the text of a postgres timestamp, like an element of timestamptz[],
+// the timestamp without time zone is in the location
+func ParsePgTime(s string, loc *time.Location) (time.Time, error) {
+	for _, layout := range pgTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid postgres time %q", s)
+}
+
+// FormatHstore formats a map whose keys are strings as the literal of hstore like "a"=>"1", "b"=>NULL
+func FormatHstore(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return "", fmt.Errorf("%v cannot be a hstore", v.Type())
+	}
+
+	keys := make([]string, 0, v.Len())
+	for _, key := range v.MapKeys() {
+		keys = append(keys, key.String())
+	}
+	// sort the keys to generate the same literal for the same map
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value, err := formatPgElement(v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key())))
+		if err != nil {
+			return "", err
+		}
+		if value != "NULL" && !strings.HasPrefix(value, `"`) {
+			value = quotePgLiteral(value)
+		}
+		pairs = append(pairs, quotePgLiteral(key)+"=>"+value)
+	}
+	return strings.Join(pairs, ", "), nil
+}
+
+// ParseHstore parses the literal of hstore like "a"=>"1", "b"=>NULL, the NULL values are returned as nil
+func ParseHstore(s string) (map[string]*string, error) {
+	res := make(map[string]*string)
+	skipSpaces := func(i int) int {
+		for i < len(s) && s[i] == ' ' {
+			i++
+		}
+		return i
+	}
+	for i := skipSpaces(0); i < len(s); {
+		if s[i] != '"' {
+			return nil, fmt.Errorf("invalid hstore %q", s)
+		}
+		key, next, err := readPgQuoted(s, i)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hstore %q: %v", s, err)
+		}
+		i = skipSpaces(next)
+		if !strings.HasPrefix(s[i:], "=>") {
+			return nil, fmt.Errorf("invalid hstore %q", s)
+		}
+		i = skipSpaces(i + 2)
+		if i < len(s) && s[i] == '"' {
+			value, next, err := readPgQuoted(s, i)
+			if err != nil {
+				return nil, fmt.Errorf("invalid hstore %q: %v", s, err)
+			}
+			res[key] = &value
+			i = next
+		} else if len(s)-i >= 4 && strings.EqualFold(s[i:i+4], "NULL") {
+			res[key] = nil
+			i += 4
+		} else {
+			return nil, fmt.Errorf("invalid hstore %q", s)
+		}
+		i = skipSpaces(i)
+		if i < len(s) {
+			if s[i] != ',' {
+				return nil, fmt.Errorf("invalid hstore %q", s)
+			}
+			i = skipSpaces(i + 1)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dialects
+
+import (
+	"strings"
+)
+
+// SelectQuery represents the clauses of a select query which is paginated by the dialect,
+// the clauses except the columns and the conditions begin with a space like " FROM `user`"
+type SelectQuery struct {
+	Distinct    bool
+	Columns     string
+	From        string // the FROM clause with the joins
+	FromArgs    []interface{}
+	Where       string // the conditions without WHERE
+	WhereArgs   []interface{}
+	GroupBy     string
+	Having      string
+	OrderBy     string
+	OrderByArgs []interface{}
+	// KeyColumn identifies the records, the legacy pagination of mssql skips the records by it
+	KeyColumn string
+	Limit     *int
+	Offset    int
+}
+
+// Build returns the SQL and the arguments of the query, top is written before the columns,
+// cond and its arguments are added to the conditions and clause is appended to the query
+func (query *SelectQuery) Build(top, cond string, condArgs []interface{}, clause string) (string, []interface{}) {
+	var buf strings.Builder
+	buf.WriteString("SELECT ")
+	if query.Distinct {
+		buf.WriteString("DISTINCT ")
+	}
+	buf.WriteString(top)
+	buf.WriteString(query.Columns)
+	buf.WriteString(query.From)
+
+	args := make([]interface{}, 0, len(query.FromArgs)+len(query.WhereArgs)+len(condArgs)+len(query.OrderByArgs))
+	args = append(args, query.FromArgs...)
+	if query.Where != "" {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(query.Where)
+		args = append(args, query.WhereArgs...)
+		if cond != "" {
+			buf.WriteString(" AND ")
+		}
+	} else if cond != "" {
+		buf.WriteString(" WHERE ")
+	}
+	buf.WriteString(cond)
+	args = append(args, condArgs...)
+
+	buf.WriteString(query.GroupBy)
+	buf.WriteString(query.Having)
+	buf.WriteString(query.OrderBy)
+	args = append(args, query.OrderByArgs...)
+	buf.WriteString(clause)
+	return buf.String(), args
+}
@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"xorm.io/xorm/core"
+	"xorm.io/xorm/internal/utils"
 	"xorm.io/xorm/schemas"
 )
 
@@ -267,28 +268,160 @@ func (db *sqlite3) AutoIncrStr() string {
 	return "AUTOINCREMENT"
 }
 
+// masterTable returns the sqlite_master table of the schema and the name of the table, the
+// schema is the name of an attached database
+func (db *sqlite3) masterTable(ctx context.Context, tableName string) (string, string) {
+	schema, tableName := db.tableSchema(ctx, tableName)
+	if schema == "" {
+		return "sqlite_master", tableName
+	}
+	return db.quoter.Quote(schema) + ".sqlite_master", tableName
+}
+
 func (db *sqlite3) IndexCheckSQL(tableName, idxName string) (string, []interface{}) {
+	master, _ := db.masterTable(context.Background(), tableName)
 	args := []interface{}{idxName}
-	return "SELECT name FROM sqlite_master WHERE type='index' and name = ?", args
+	return "SELECT name FROM " + master + " WHERE type='index' and name = ?", args
 }
 
 func (db *sqlite3) IsTableExist(queryer core.Queryer, ctx context.Context, tableName string) (bool, error) {
-	return db.HasRecords(queryer, ctx, "SELECT name FROM sqlite_master WHERE type='table' and name = ?", tableName)
+	master, tableName := db.masterTable(ctx, tableName)
+	return db.HasRecords(queryer, ctx, "SELECT name FROM "+master+" WHERE type='table' and name = ?", tableName)
 }
 
 func (db *sqlite3) DropIndexSQL(tableName string, index *schemas.Index) string {
 	// var unique string
-	idxName := index.Name
+	idxName := IndexName(db, tableName, index)
+	schema, _ := SplitTableName(tableName)
+	if schema != "" {
+		idxName = schema + "." + idxName
+	}
+	return fmt.Sprintf("DROP INDEX %v", db.Quoter().Quote(idxName))
+}
 
-	if !strings.HasPrefix(idxName, "UQE_") &&
-		!strings.HasPrefix(idxName, "IDX_") {
-		if index.Type == schemas.UniqueType {
-			idxName = fmt.Sprintf("UQE_%v_%v", tableName, index.Name)
-		} else {
-			idxName = fmt.Sprintf("IDX_%v_%v", tableName, index.Name)
+// AlterColumnNullableSQL returns an empty string because sqlite cannot alter a column,
+// the table should be rebuilt
+func (db *sqlite3) AlterColumnNullableSQL(tableName string, col *schemas.Column) string {
+	return ""
+}
+
+// AlterColumnDefaultSQL returns an empty string because sqlite cannot alter a column,
+// the table should be rebuilt
+func (db *sqlite3) AlterColumnDefaultSQL(tableName string, col *schemas.Column) string {
+	return ""
+}
+
+// AddCheckSQL returns an empty string because sqlite cannot alter a check constraint,
+// the table should be rebuilt
+func (db *sqlite3) AddCheckSQL(tableName string, check *schemas.Check) string {
+	return ""
+}
+
+// DropCheckSQL returns an empty string because sqlite cannot alter a check constraint,
+// the table should be rebuilt
+func (db *sqlite3) DropCheckSQL(tableName string, check *schemas.Check) string {
+	return ""
+}
+
+// AddForeignKeySQL returns an empty string because sqlite cannot alter a foreign key,
+// the table should be rebuilt
+func (db *sqlite3) AddForeignKeySQL(tableName string, fk *schemas.ForeignKey) string {
+	return ""
+}
+
+// DropForeignKeySQL returns an empty string because sqlite cannot alter a foreign key,
+// the table should be rebuilt
+func (db *sqlite3) DropForeignKeySQL(tableName string, fk *schemas.ForeignKey) string {
+	return ""
+}
+
+// the SQLs to rebuild the tables which are referenced by the foreign keys of other tables, the
+// foreign keys should be disabled out of any transaction, otherwise dropping the old table will
+// delete the referencing records or fail
+const (
+	SQLiteForeignKeysOffSQL  = "PRAGMA foreign_keys=OFF"
+	SQLiteForeignKeysOnSQL   = "PRAGMA foreign_keys=ON"
+	SQLiteForeignKeyCheckSQL = "PRAGMA foreign_key_check"
+)
+
+// RebuildTableSQLs returns SQLs to rebuild a table, the SQLs are wrapped by disabling and
+// enabling the foreign keys and the referencing tables are checked after the rebuilding
+// if the foreign keys are enabled and the table is referenced by other tables
+func (db *sqlite3) RebuildTableSQLs(ctx context.Context, queryer core.Queryer, table *schemas.Table, tableName string) ([]string, error) {
+	sqls, err := db.Base.RebuildTableSQLs(ctx, queryer, table, tableName)
+	if err != nil {
+		return nil, err
+	}
+	if tableName == "" {
+		tableName = table.Name
+	}
+
+	enabled, err := db.foreignKeysEnabled(queryer, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return sqls, nil
+	}
+
+	referencing, err := db.referencingTables(queryer, ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+	if len(referencing) == 0 {
+		return sqls, nil
+	}
+
+	rebuildSQLs := make([]string, 0, len(sqls)+len(referencing)+2)
+	rebuildSQLs = append(rebuildSQLs, SQLiteForeignKeysOffSQL)
+	rebuildSQLs = append(rebuildSQLs, sqls...)
+	for _, name := range referencing {
+		rebuildSQLs = append(rebuildSQLs, fmt.Sprintf("%s(%s)", SQLiteForeignKeyCheckSQL, db.quoter.Quote(name)))
+	}
+	return append(rebuildSQLs, SQLiteForeignKeysOnSQL), nil
+}
+
+func (db *sqlite3) foreignKeysEnabled(queryer core.Queryer, ctx context.Context) (bool, error) {
+	rows, err := queryer.QueryContext(ctx, "PRAGMA foreign_keys")
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	var enabled int
+	if rows.Next() {
+		if err := rows.Scan(&enabled); err != nil {
+			return false, err
 		}
 	}
-	return fmt.Sprintf("DROP INDEX %v", db.Quoter().Quote(idxName))
+	return enabled == 1, rows.Err()
+}
+
+// referencingTables returns the names of the other tables whose foreign keys reference the table
+func (db *sqlite3) referencingTables(queryer core.Queryer, ctx context.Context, tableName string) ([]string, error) {
+	_, name := SplitTableName(tableName)
+	tables, err := db.GetTables(queryer, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, table := range tables {
+		if strings.EqualFold(table.Name, name) {
+			continue
+		}
+		fks, err := db.GetForeignKeys(queryer, ctx, table.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, fk := range fks {
+			if strings.EqualFold(fk.RefTable, name) {
+				names = append(names, table.Name)
+				break
+			}
+		}
+	}
+	return names, nil
 }
 
 func (db *sqlite3) ForUpdateSQL(query string) string {
@@ -308,13 +441,35 @@ func (db *sqlite3) IsColumnExist(queryer core.Queryer, ctx context.Context, tabl
 		return false, err
 	}
 
-	for _, col := range cols {
-		if strings.EqualFold(col, colName) {
-			return true, nil
+	return utils.ContainsFold(cols, colName), nil
+}
+
+// splitColumnDefs splits the body of a sqlite create table SQL as column and
+// constraint definitions by the commas which are not in parentheses or quotes
+func splitColumnDefs(body string) []string {
+	var results = make([]string, 0, 10)
+	var depth, lastIdx int
+	var quote rune
+	for i, c := range body {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '[':
+			quote = ']'
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			results = append(results, body[lastIdx:i])
+			lastIdx = i + 1
 		}
 	}
-
-	return false, nil
+	return append(results, body[lastIdx:])
 }
 
 // splitColStr splits a sqlite col strings as fields
@@ -380,8 +535,9 @@ func parseString(colStr string) (*schemas.Column, error) {
 }
 
 func (db *sqlite3) GetColumns(queryer core.Queryer, ctx context.Context, tableName string) ([]string, map[string]*schemas.Column, error) {
+	master, tableName := db.masterTable(ctx, tableName)
 	args := []interface{}{tableName}
-	s := "SELECT sql FROM sqlite_master WHERE type='table' and name = ?"
+	s := "SELECT sql FROM " + master + " WHERE type='table' and name = ?"
 
 	rows, err := queryer.QueryContext(ctx, s, args...)
 	if err != nil {
@@ -406,14 +562,18 @@ func (db *sqlite3) GetColumns(queryer core.Queryer, ctx context.Context, tableNa
 
 	nStart := strings.Index(name, "(")
 	nEnd := strings.LastIndex(name, ")")
-	reg := regexp.MustCompile(`[^\(,\)]*(\([^\(]*\))?`)
-	colCreates := reg.FindAllString(name[nStart+1:nEnd], -1)
+	colCreates := splitColumnDefs(name[nStart+1 : nEnd])
 	cols := make(map[string]*schemas.Column)
 	colSeq := make([]string, 0)
 
 	for _, colStr := range colCreates {
-		reg = regexp.MustCompile(`,\s`)
+		reg := regexp.MustCompile(`,\s`)
 		colStr = reg.ReplaceAllString(colStr, ",")
+		if strings.HasPrefix(strings.TrimSpace(colStr), "CONSTRAINT") ||
+			strings.HasPrefix(strings.TrimSpace(colStr), "FOREIGN KEY") ||
+			strings.HasPrefix(strings.TrimSpace(colStr), "CHECK") {
+			continue
+		}
 		if strings.HasPrefix(strings.TrimSpace(colStr), "PRIMARY KEY") {
 			parts := strings.Split(strings.TrimSpace(colStr), "(")
 			if len(parts) == 2 {
@@ -439,8 +599,9 @@ func (db *sqlite3) GetColumns(queryer core.Queryer, ctx context.Context, tableNa
 }
 
 func (db *sqlite3) GetTables(queryer core.Queryer, ctx context.Context) ([]*schemas.Table, error) {
+	master, _ := db.masterTable(ctx, "")
 	args := []interface{}{}
-	s := "SELECT name FROM sqlite_master WHERE type='table'"
+	s := "SELECT name FROM " + master + " WHERE type='table'"
 
 	rows, err := queryer.QueryContext(ctx, s, args...)
 	if err != nil {
@@ -466,9 +627,157 @@ func (db *sqlite3) GetTables(queryer core.Queryer, ctx context.Context) ([]*sche
 	return tables, nil
 }
 
+// ReplaceViewSQL returns an empty string, sqlite cannot replace the views
+func (db *sqlite3) ReplaceViewSQL(viewName string, view *schemas.View) string {
+	return ""
+}
+
+func (db *sqlite3) GetViews(queryer core.Queryer, ctx context.Context) ([]*schemas.Table, error) {
+	master, _ := db.masterTable(ctx, "")
+	return queryViews(queryer, ctx, false, "SELECT name, sql FROM "+master+" WHERE type='view'")
+}
+
+func (db *sqlite3) GetForeignKeys(queryer core.Queryer, ctx context.Context, tableName string) (map[string]*schemas.ForeignKey, error) {
+	schema, name := db.tableSchema(ctx, tableName)
+	s := fmt.Sprintf("PRAGMA foreign_key_list(%s)", db.quoter.Quote(name))
+	if schema != "" {
+		s = fmt.Sprintf("PRAGMA %s.foreign_key_list(%s)", db.quoter.Quote(schema), db.quoter.Quote(name))
+	}
+	rows, err := queryer.QueryContext(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	var fksByID = make(map[int]*schemas.ForeignKey)
+	for rows.Next() {
+		var id, seq int
+		var refTable, colName, updateRule, deleteRule, match string
+		var refColName sql.NullString
+		if err = rows.Scan(&id, &seq, &refTable, &colName, &refColName, &updateRule, &deleteRule, &match); err != nil {
+			return nil, err
+		}
+		fk, ok := fksByID[id]
+		if !ok {
+			fk = schemas.NewForeignKey("", refTable)
+			fk.OnUpdate = schemas.ReferentialAction(updateRule)
+			fk.OnDelete = schemas.ReferentialAction(deleteRule)
+			fksByID[id] = fk
+			ids = append(ids, id)
+		}
+		fk.AddColumn(colName, refColName.String)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	// sqlite doesn't report the names of foreign keys, so they are read from the
+	// create table SQL and the unnamed ones are named by the columns
+	names, err := db.foreignKeyNames(queryer, ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	fks := make(map[string]*schemas.ForeignKey, len(ids))
+	for _, id := range ids {
+		fk := fksByID[id]
+		if name, ok := names[strings.ToLower(strings.Join(fk.Cols, ","))]; ok {
+			fk.Name = name
+		} else {
+			fk.Name = strings.Join(fk.Cols, "_")
+			fk.Name = ForeignKeyName(db, tableName, fk)
+		}
+		fks[fk.Name] = fk
+	}
+	return fks, nil
+}
+
+// foreignKeyNames returns the names of the named foreign keys of the table, the keys
+// are the lower case columns joined by comma
+func (db *sqlite3) foreignKeyNames(queryer core.Queryer, ctx context.Context, tableName string) (map[string]string, error) {
+	defs, err := db.tableDefs(queryer, ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string)
+	for _, def := range defs {
+		fields := splitColStr(def)
+		if len(fields) < 4 || !strings.EqualFold(fields[0], "CONSTRAINT") ||
+			!strings.EqualFold(fields[2], "FOREIGN") {
+			continue
+		}
+		colsStart := strings.Index(def, "(")
+		colsEnd := strings.Index(def, ")")
+		if colsStart == -1 || colsEnd <= colsStart {
+			continue
+		}
+		var cols []string
+		for _, col := range strings.Split(def[colsStart+1:colsEnd], ",") {
+			cols = append(cols, strings.ToLower(strings.Trim(strings.TrimSpace(col), "`[]\"")))
+		}
+		names[strings.Join(cols, ",")] = strings.Trim(fields[1], "`[]\"")
+	}
+	return names, nil
+}
+
+// GetChecks returns the named check constraints of the table which are read from the create table SQL
+func (db *sqlite3) GetChecks(queryer core.Queryer, ctx context.Context, tableName string) (map[string]*schemas.Check, error) {
+	defs, err := db.tableDefs(queryer, ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	checks := make(map[string]*schemas.Check)
+	for _, def := range defs {
+		fields := splitColStr(def)
+		if len(fields) < 3 || !strings.EqualFold(fields[0], "CONSTRAINT") ||
+			!strings.HasPrefix(strings.ToUpper(fields[2]), "CHECK") {
+			continue
+		}
+		// skip the name because it may contain check too
+		expr := strings.TrimSpace(def[strings.Index(def, fields[1])+len(fields[1]):])
+		expr = strings.TrimSpace(expr[len("CHECK"):])
+		if strings.HasPrefix(expr, "(") && strings.HasSuffix(expr, ")") {
+			expr = expr[1 : len(expr)-1]
+		}
+		name := strings.Trim(fields[1], "`[]\"")
+		checks[name] = schemas.NewCheck(name, expr)
+	}
+	return checks, nil
+}
+
+// tableDefs returns the column and constraint definitions of the create table SQL
+func (db *sqlite3) tableDefs(queryer core.Queryer, ctx context.Context, tableName string) ([]string, error) {
+	var createSQL string
+	master, tableName := db.masterTable(ctx, tableName)
+	rows, err := queryer.QueryContext(ctx, "SELECT sql FROM "+master+" WHERE type='table' and name = ?", tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if rows.Next() {
+		if err = rows.Scan(&createSQL); err != nil {
+			return nil, err
+		}
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	nStart := strings.Index(createSQL, "(")
+	nEnd := strings.LastIndex(createSQL, ")")
+	if nStart == -1 || nEnd <= nStart {
+		return nil, nil
+	}
+	return splitColumnDefs(createSQL[nStart+1 : nEnd]), nil
+}
+
 func (db *sqlite3) GetIndexes(queryer core.Queryer, ctx context.Context, tableName string) (map[string]*schemas.Index, error) {
+	master, tableName := db.masterTable(ctx, tableName)
 	args := []interface{}{tableName}
-	s := "SELECT sql FROM sqlite_master WHERE type='index' and tbl_name = ?"
+	s := "SELECT sql FROM " + master + " WHERE type='index' and tbl_name = ?"
 
 	rows, err := queryer.QueryContext(ctx, s, args...)
 	if err != nil {
@@ -491,19 +800,14 @@ func (db *sqlite3) GetIndexes(queryer core.Queryer, ctx context.Context, tableNa
 
 		index := new(schemas.Index)
 		nNStart := strings.Index(sql, "INDEX")
-		nNEnd := strings.Index(sql, "ON")
+		nNEnd := strings.Index(sql, " ON ")
 		if nNStart == -1 || nNEnd == -1 {
 			continue
 		}
 
 		indexName := strings.Trim(strings.TrimSpace(sql[nNStart+6:nNEnd]), "`[]'\"")
 		var isRegular bool
-		if strings.HasPrefix(indexName, "IDX_"+tableName) || strings.HasPrefix(indexName, "UQE_"+tableName) {
-			index.Name = indexName[5+len(tableName):]
-			isRegular = true
-		} else {
-			index.Name = indexName
-		}
+		index.Name, isRegular = regularIndexName(db, tableName, indexName)
 
 		if strings.HasPrefix(sql, "CREATE UNIQUE INDEX") {
 			index.Type = schemas.UniqueType
@@ -511,14 +815,8 @@ func (db *sqlite3) GetIndexes(queryer core.Queryer, ctx context.Context, tableNa
 			index.Type = schemas.IndexType
 		}
 
-		nStart := strings.Index(sql, "(")
-		nEnd := strings.Index(sql, ")")
-		colIndexes := strings.Split(sql[nStart+1:nEnd], ",")
-
 		index.Cols = make([]string, 0)
-		for _, col := range colIndexes {
-			index.Cols = append(index.Cols, strings.Trim(col, "` []"))
-		}
+		parseIndexSQL(index, sql)
 		index.IsRegular = isRegular
 		indexes[index.Name] = index
 	}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"xorm.io/xorm/schemas"
 )
 
 func TestSplitColStr(t *testing.T) {
@@ -33,3 +34,75 @@ func TestSplitColStr(t *testing.T) {
 		assert.EqualValues(t, kase.fields, splitColStr(kase.colStr))
 	}
 }
+
+func TestSplitColumnDefs(t *testing.T) {
+	defs := splitColumnDefs("`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, `price` DECIMAL(10,2) DEFAULT 0 NOT NULL, " +
+		"`name` TEXT DEFAULT 'a, b' NULL, CONSTRAINT `FK_order_user_id` FOREIGN KEY (`user_id`,`seq`) REFERENCES `user` (`id`,`seq`) ON DELETE CASCADE")
+	assert.EqualValues(t, []string{
+		"`id` INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL",
+		" `price` DECIMAL(10,2) DEFAULT 0 NOT NULL",
+		" `name` TEXT DEFAULT 'a, b' NULL",
+		" CONSTRAINT `FK_order_user_id` FOREIGN KEY (`user_id`,`seq`) REFERENCES `user` (`id`,`seq`) ON DELETE CASCADE",
+	}, defs)
+}
+
+func TestParseIndexSQL(t *testing.T) {
+	var index schemas.Index
+	parseIndexSQL(&index, "CREATE INDEX `IDX_order_status` ON `order` (`status`,(lower(`name`)) DESC) WHERE deleted_at IS NULL AND status <> 'ON (x)'")
+	assert.EqualValues(t, []string{"status", "lower(`name`)"}, index.Cols)
+	assert.EqualValues(t, map[string]bool{"lower(`name`)": true}, index.Desc)
+	assert.EqualValues(t, "deleted_at IS NULL AND status <> 'ON (x)'", index.Where)
+	assert.EqualValues(t, "", index.Method)
+}
+
+func TestViewPrefixReg(t *testing.T) {
+	var kases = []struct {
+		definition string
+		query      string
+	}{
+		{"CREATE VIEW `active_user` AS SELECT `id`, `name` FROM `user` WHERE active = 1", "SELECT `id`, `name` FROM `user` WHERE active = 1"},
+		{"create view [dbo].[user_name] as\nselect name AS n from [user]", "select name AS n from [user]"},
+		{" SELECT id FROM \"user\";", " SELECT id FROM \"user\";"},
+	}
+	for _, kase := range kases {
+		assert.EqualValues(t, kase.query, viewPrefixReg.ReplaceAllString(kase.definition, ""))
+	}
+}
+
+func TestSqlite3LimitOffsetSQL(t *testing.T) {
+	dialect := QueryDialect("sqlite3")
+	assert.NoError(t, dialect.Init(&URI{DBType: "sqlite3"}))
+	limit := 10
+
+	query := &SelectQuery{
+		Columns:   "*",
+		From:      " FROM `user`",
+		Where:     "`age`>?",
+		WhereArgs: []interface{}{18},
+		OrderBy:   " ORDER BY `id`",
+		Limit:     &limit,
+		Offset:    20,
+	}
+	sql, args, err := dialect.LimitOffsetSQL(query)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT * FROM `user` WHERE `age`>? ORDER BY `id` LIMIT 10 OFFSET 20", sql)
+	assert.EqualValues(t, []interface{}{18}, args)
+
+	query.Offset = 0
+	sql, _, err = dialect.LimitOffsetSQL(query)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT * FROM `user` WHERE `age`>? ORDER BY `id` LIMIT 10", sql)
+
+	query.Limit = nil
+	sql, _, err = dialect.LimitOffsetSQL(query)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT * FROM `user` WHERE `age`>? ORDER BY `id`", sql)
+}
+
+func TestSqlite3RenameColumnSQL(t *testing.T) {
+	col := &schemas.Column{Name: "name", SQLType: schemas.SQLType{Name: schemas.Varchar}, Length: 64, Nullable: false, DefaultIsEmpty: true}
+
+	dialect := QueryDialect("sqlite3")
+	assert.NoError(t, dialect.Init(&URI{DBType: "sqlite3"}))
+	assert.EqualValues(t, "ALTER TABLE `user` RENAME COLUMN `nick` TO `name`", dialect.RenameColumnSQL("user", "nick", col))
+}
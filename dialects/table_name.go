@@ -5,6 +5,7 @@
 package dialects
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
@@ -14,16 +15,81 @@ import (
 	"xorm.io/xorm/schemas"
 )
 
+type schemaContextKey struct{}
+
+// WithSchema returns a copy of the context in which the tables are looked up in the schema
+// instead of the schema of the URI
+func WithSchema(ctx context.Context, schema string) context.Context {
+	return context.WithValue(ctx, schemaContextKey{}, schema)
+}
+
+// SchemaFromContext returns the schema set by WithSchema
+func SchemaFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	schema, _ := ctx.Value(schemaContextKey{}).(string)
+	return schema
+}
+
 // TableNameWithSchema will add schema prefix on table name if possible
 func TableNameWithSchema(dialect Dialect, tableName string) string {
 	// Add schema name as prefix of table name.
-	// Only for postgres database.
 	if dialect.URI().Schema != "" && !strings.Contains(tableName, ".") {
 		return fmt.Sprintf("%s.%s", dialect.URI().Schema, tableName)
 	}
 	return tableName
 }
 
+// TableNameInSchema returns the table name in the schema, the schema of the table name will
+// be replaced. The sub queries and the aliased table names are returned directly.
+func TableNameInSchema(schema, tableName string) string {
+	if schema == "" || tableName == "" || utils.IsSubQuery(tableName) ||
+		strings.ContainsAny(tableName, " ") || strings.Contains(tableName, "..") {
+		return tableName
+	}
+	_, tableName = SplitTableName(tableName)
+	return schema + "." + tableName
+}
+
+// SplitTableName splits the table name like schema.table, the schema is empty if the table
+// name has no schema. The surrounding quotes of the names are removed.
+func SplitTableName(tableName string) (string, string) {
+	idx := strings.LastIndexByte(tableName, '.')
+	// the dot in the quoted table name doesn't split the name
+	if n := len(tableName); n > 1 {
+		if start := strings.LastIndexByte(tableName[:n-1], openingQuote(tableName[n-1])); start >= 0 {
+			idx = start - 1
+			if idx < 0 || tableName[idx] != '.' {
+				idx = -1
+			}
+		}
+	}
+	if idx > 0 {
+		return unquoteName(tableName[:idx]), unquoteName(tableName[idx+1:])
+	}
+	return "", unquoteName(tableName)
+}
+
+// openingQuote returns the opening quote of the closing quote c, or 0 if c is not a quote
+func openingQuote(c byte) byte {
+	switch c {
+	case '"', '`':
+		return c
+	case ']':
+		return '['
+	}
+	return 0
+}
+
+// unquoteName removes the surrounding quotes of the name
+func unquoteName(name string) string {
+	if n := len(name); n > 1 && openingQuote(name[n-1]) != 0 && name[0] == openingQuote(name[n-1]) {
+		return name[1 : n-1]
+	}
+	return name
+}
+
 // TableNameNoSchema returns table name with given tableName
 func TableNameNoSchema(dialect Dialect, mapper names.Mapper, tableName interface{}) string {
 	quote := dialect.Quoter().Quote
@@ -87,7 +153,27 @@ func TableNameNoSchema(dialect Dialect, mapper names.Mapper, tableName interface
 func FullTableName(dialect Dialect, mapper names.Mapper, bean interface{}, includeSchema ...bool) string {
 	tbName := TableNameNoSchema(dialect, mapper, bean)
 	if len(includeSchema) > 0 && includeSchema[0] && !utils.IsSubQuery(tbName) {
+		if schema := tableSchema(bean); schema != "" {
+			return TableNameInSchema(schema, tbName)
+		}
 		tbName = TableNameWithSchema(dialect, tbName)
 	}
 	return tbName
 }
+
+// tableSchema returns the schema of the bean which implements names.TableSchema
+func tableSchema(bean interface{}) string {
+	var v reflect.Value
+	switch t := bean.(type) {
+	case string, []string, []interface{}:
+		return ""
+	case reflect.Value:
+		v = t
+	default:
+		v = reflect.ValueOf(bean)
+	}
+	if !v.IsValid() || reflect.Indirect(v).Kind() != reflect.Struct {
+		return ""
+	}
+	return names.GetTableSchema(v)
+}
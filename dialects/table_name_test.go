@@ -28,3 +28,50 @@ func TestFullTableName(t *testing.T) {
 	assert.EqualValues(t, "mcc", FullTableName(dialect, names.SnakeMapper{}, &MCC{}))
 	assert.EqualValues(t, "mcc", FullTableName(dialect, names.SnakeMapper{}, "mcc"))
 }
+
+type SchemaMCC struct {
+	ID int64 `xorm:"pk 'id'"`
+}
+
+func (SchemaMCC) TableSchema() string {
+	return "other"
+}
+
+func TestFullTableNameWithTableSchema(t *testing.T) {
+	dialect := QueryDialect("postgres")
+
+	assert.EqualValues(t, "schema_m_c_c", FullTableName(dialect, names.SnakeMapper{}, &SchemaMCC{}))
+	assert.EqualValues(t, "other.schema_m_c_c", FullTableName(dialect, names.SnakeMapper{}, &SchemaMCC{}, true))
+}
+
+func TestSplitTableName(t *testing.T) {
+	var kases = []struct {
+		tableName string
+		schema    string
+		name      string
+	}{
+		{"user", "", "user"},
+		{"public.user", "public", "user"},
+		{`"public"."user"`, "public", "user"},
+		{"[dbo].[user]", "dbo", "user"},
+		{"`db`.`user`", "db", "user"},
+		{`"my.schema"."user"`, "my.schema", "user"},
+		{`public."a.b"`, "public", "a.b"},
+		{`"a.b"`, "", "a.b"},
+		{"[dbo].[user's]", "dbo", "user's"},
+		{`dbo.o"brien`, "dbo", `o"brien`},
+	}
+	for _, kase := range kases {
+		schema, name := SplitTableName(kase.tableName)
+		assert.EqualValues(t, kase.schema, schema)
+		assert.EqualValues(t, kase.name, name)
+	}
+}
+
+func TestTableNameInSchema(t *testing.T) {
+	assert.EqualValues(t, "user", TableNameInSchema("", "user"))
+	assert.EqualValues(t, "other.user", TableNameInSchema("other", "user"))
+	assert.EqualValues(t, "other.user", TableNameInSchema("other", "public.user"))
+	assert.EqualValues(t, "user u", TableNameInSchema("other", "user u"))
+	assert.EqualValues(t, "(select * from user)", TableNameInSchema("other", "(select * from user)"))
+}
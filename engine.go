@@ -93,6 +93,10 @@ func newEngine(driverName, dataSourceName string, dialect dialects.Dialect, db *
 	logger.SetLevel(log.LOG_INFO)
 	engine.SetLogger(log.NewLoggerAdapter(logger))
 
+	// the SQLs of some dialects depend on the version which is detected lazily, so the database
+	// is not connected before it's used
+	dialect.SetQueryer(db)
+
 	runtime.SetFinalizer(engine, func(engine *Engine) {
 		_ = engine.Close()
 	})
@@ -139,6 +143,12 @@ func (engine *Engine) GetCacher(tableName string) caches.Cacher {
 	return engine.cacherMgr.GetCacher(tableName)
 }
 
+// SetNamingStrategy sets the naming strategy of the indexes, constraints and sequences,
+// the names longer than the limit of the database will be shortened with a hash
+func (engine *Engine) SetNamingStrategy(strategy names.NamingStrategy) {
+	engine.dialect.SetNamingStrategy(strategy)
+}
+
 // SetQuotePolicy sets the special quote policy
 func (engine *Engine) SetQuotePolicy(quotePolicy dialects.QuotePolicy) {
 	engine.dialect.SetQuotePolicy(quotePolicy)
@@ -355,23 +365,38 @@ func (engine *Engine) NoAutoCondition(no ...bool) *Session {
 	return session.NoAutoCondition(no...)
 }
 
-func (engine *Engine) loadTableInfo(table *schemas.Table) error {
-	colSeq, cols, err := engine.dialect.GetColumns(engine.db, engine.defaultContext, table.Name)
+func (engine *Engine) loadTableInfo(ctx context.Context, table *schemas.Table) error {
+	colSeq, cols, err := engine.dialect.GetColumns(engine.db, ctx, table.Name)
 	if err != nil {
 		return err
 	}
 	for _, name := range colSeq {
 		table.AddColumn(cols[name])
 	}
-	indexes, err := engine.dialect.GetIndexes(engine.db, engine.defaultContext, table.Name)
+	indexes, err := engine.dialect.GetIndexes(engine.db, ctx, table.Name)
 	if err != nil {
 		return err
 	}
 	table.Indexes = indexes
 
+	fks, err := engine.dialect.GetForeignKeys(engine.db, ctx, table.Name)
+	if err != nil {
+		return err
+	}
+	table.ForeignKeys = fks
+
+	checks, err := engine.dialect.GetChecks(engine.db, ctx, table.Name)
+	if err != nil {
+		return err
+	}
+	table.Checks = checks
+
 	var seq int
 	for _, index := range indexes {
 		for _, name := range index.Cols {
+			if schemas.IsIndexExpr(name) {
+				continue
+			}
 			parts := strings.Split(strings.TrimSpace(name), " ")
 			if len(parts) > 1 {
 				if parts[1] == "DESC" {
@@ -399,11 +424,50 @@ func (engine *Engine) DBMetas() ([]*schemas.Table, error) {
 	}
 
 	for _, table := range tables {
-		if err = engine.loadTableInfo(table); err != nil {
+		if err = engine.loadTableInfo(engine.defaultContext, table); err != nil {
 			return nil, err
 		}
 	}
-	return tables, nil
+
+	views, err := engine.dialect.GetViews(engine.db, engine.defaultContext)
+	if err != nil {
+		return nil, err
+	}
+	for _, view := range views {
+		if err = engine.loadViewInfo(view); err != nil {
+			return nil, err
+		}
+	}
+	return append(tables, views...), nil
+}
+
+// loadViewInfo loads the columns of the view from the result of an empty query
+func (engine *Engine) loadViewInfo(table *schemas.Table) error {
+	rows, err := engine.db.QueryContext(engine.defaultContext,
+		fmt.Sprintf("SELECT * FROM %s WHERE 1=0", engine.Quote(engine.TableName(table.Name, true))))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return err
+	}
+	for _, tp := range types {
+		col := &schemas.Column{
+			Name:     tp.Name(),
+			SQLType:  schemas.SQLType{Name: strings.ToUpper(tp.DatabaseTypeName())},
+			Nullable: true,
+			Indexes:  make(map[string]int),
+			MapType:  schemas.TWOSIDES,
+		}
+		if nullable, ok := tp.Nullable(); ok {
+			col.Nullable = nullable
+		}
+		table.AddColumn(col)
+	}
+	return rows.Err()
 }
 
 // DumpAllToFile dump database all table structs and data to a file
@@ -520,8 +584,19 @@ func (engine *Engine) dumpTables(ctx context.Context, tables []*schemas.Table, w
 			}
 		}
 
-		if dstTable.AutoIncrement != "" && dstDialect.Features().AutoincrMode == dialects.SequenceAutoincrMode {
-			sqlstr, err := dstDialect.CreateSequenceSQL(ctx, engine.db, utils.SeqName(dstTableName))
+		if dstTable.IsView() {
+			sqlstr, err := dstDialect.CreateViewSQL(dstTableName, dstTable.View)
+			if err != nil {
+				return err
+			}
+			if _, err = io.WriteString(w, sqlstr+";\n"); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if seqName := dialects.AutoincrSequenceName(dstDialect, dstTableName, dstTable.AutoIncrColumn()); dstTable.AutoIncrement != "" && seqName != "" {
+			sqlstr, err := dstDialect.CreateSequenceSQL(ctx, engine.db, seqName)
 			if err != nil {
 				return err
 			}
@@ -787,6 +862,11 @@ func (engine *Engine) dumpTables(ctx context.Context, tables []*schemas.Table, w
 								return err
 							}
 						}
+					} else if dstDialect.URI().DBType == schemas.DUCKDB && dstTable.Columns()[i].SQLType.IsBlob() {
+						// DuckDB stops parsing a string literal at a NUL byte, so the blobs are written in hex
+						if _, err := fmt.Fprintf(w, "from_hex('%x')", s.String); err != nil {
+							return err
+						}
 					} else {
 						if _, err = io.WriteString(w, "'"+strings.ReplaceAll(s.String, "'", "''")+"'"); err != nil {
 							return err
@@ -944,6 +1024,27 @@ func (engine *Engine) NotIn(column string, args ...interface{}) *Session {
 	return session.NotIn(column, args...)
 }
 
+// ArrayContains will generate "column @> ?" for the postgres array column
+func (engine *Engine) ArrayContains(column string, values interface{}) *Session {
+	session := engine.NewSession()
+	session.isAutoClose = true
+	return session.ArrayContains(column, values)
+}
+
+// ArrayOverlaps will generate "column && ?" for the postgres array column
+func (engine *Engine) ArrayOverlaps(column string, values interface{}) *Session {
+	session := engine.NewSession()
+	session.isAutoClose = true
+	return session.ArrayOverlaps(column, values)
+}
+
+// InArray will generate "column = ANY(?)" with the values as a postgres array
+func (engine *Engine) InArray(column string, values interface{}) *Session {
+	session := engine.NewSession()
+	session.isAutoClose = true
+	return session.InArray(column, values)
+}
+
 // Incr provides a update string like "column = column + ?"
 func (engine *Engine) Incr(column string, arg ...interface{}) *Session {
 	session := engine.NewSession()
@@ -965,6 +1066,13 @@ func (engine *Engine) SetExpr(column string, expression interface{}) *Session {
 	return session.SetExpr(column, expression)
 }
 
+// SetJSON provides a update string like "column = json_set(column, path, value)"
+func (engine *Engine) SetJSON(column, path string, value interface{}) *Session {
+	session := engine.NewSession()
+	session.isAutoClose = true
+	return session.SetJSON(column, path, value)
+}
+
 // Table temporarily change the Get, Find, Update's table
 func (engine *Engine) Table(tableNameOrBean interface{}) *Session {
 	session := engine.NewSession()
@@ -972,6 +1080,13 @@ func (engine *Engine) Table(tableNameOrBean interface{}) *Session {
 	return session.Table(tableNameOrBean)
 }
 
+// Schema temporarily changes the schema of the tables for the next operation
+func (engine *Engine) Schema(schema string) *Session {
+	session := engine.NewSession()
+	session.isAutoClose = true
+	return session.Schema(schema)
+}
+
 // Alias set the table alias
 func (engine *Engine) Alias(alias string) *Session {
 	session := engine.NewSession()
@@ -1065,6 +1180,68 @@ func (engine *Engine) IsTableExist(beanOrTableName interface{}) (bool, error) {
 	return session.IsTableExist(beanOrTableName)
 }
 
+// CreateView creates a view from the query of the session
+func (engine *Engine) CreateView(viewName string, query *Session) error {
+	session := engine.NewSession()
+	defer session.Close()
+	return session.CreateView(viewName, query)
+}
+
+// CreateMaterializedView creates a materialized view from the query of the session, postgres only
+func (engine *Engine) CreateMaterializedView(viewName string, query *Session) error {
+	session := engine.NewSession()
+	defer session.Close()
+	return session.CreateMaterializedView(viewName, query)
+}
+
+// DropView drops the view or the materialized view if it exists
+func (engine *Engine) DropView(viewName string) error {
+	session := engine.NewSession()
+	defer session.Close()
+	return session.DropView(viewName)
+}
+
+// RefreshMaterializedView refreshes the data of the materialized view
+func (engine *Engine) RefreshMaterializedView(viewName string, concurrently bool) error {
+	session := engine.NewSession()
+	defer session.Close()
+	return session.RefreshMaterializedView(viewName, concurrently)
+}
+
+// CreatePartition creates a partition of the partitioned table
+func (engine *Engine) CreatePartition(beanOrTableName interface{}, partition *schemas.Partition) error {
+	session := engine.NewSession()
+	defer session.Close()
+	return session.CreatePartition(beanOrTableName, partition)
+}
+
+// DropPartition drops a partition of the partitioned table with the data in it
+func (engine *Engine) DropPartition(beanOrTableName interface{}, partitionName string) error {
+	session := engine.NewSession()
+	defer session.Close()
+	return session.DropPartition(beanOrTableName, partitionName)
+}
+
+// ListPartitions returns the partitions of the partitioned table
+func (engine *Engine) ListPartitions(beanOrTableName interface{}) ([]*schemas.Partition, error) {
+	session := engine.NewSession()
+	defer session.Close()
+	return session.ListPartitions(beanOrTableName)
+}
+
+// viewOf returns the view definition if the bean is mapped onto a view
+func (engine *Engine) viewOf(bean interface{}) *schemas.View {
+	v := utils.ReflectValue(bean)
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	table, err := engine.tagParser.ParseWithCache(v)
+	if err != nil {
+		return nil
+	}
+	return table.View
+}
+
 // TableName returns table name with schema prefix if has
 func (engine *Engine) TableName(bean interface{}, includeSchema ...bool) string {
 	return dialects.FullTableName(engine.dialect, engine.GetTableMapper(), bean, includeSchema...)
@@ -1122,6 +1299,30 @@ func (engine *Engine) Sync(beans ...interface{}) error {
 	return session.Sync(beans...)
 }
 
+// SyncWithOptions synchronize structs to database tables with options, which could
+// alter the nullability and default values of columns and drop columns or tables.
+func (engine *Engine) SyncWithOptions(opts SyncOptions, beans ...interface{}) error {
+	session := engine.NewSession()
+	defer session.Close()
+	return session.SyncWithOptions(opts, beans...)
+}
+
+// SyncPlanWithOptions returns the changes which SyncWithOptions will apply to the database,
+// including the SQLs, without executing anything.
+func (engine *Engine) SyncPlanWithOptions(opts SyncOptions, beans ...interface{}) (*SyncPlan, error) {
+	session := engine.NewSession()
+	defer session.Close()
+	return session.SyncPlanWithOptions(opts, beans...)
+}
+
+// SyncPlan returns the changes which Sync will apply to the database, including
+// the SQLs, without executing anything.
+func (engine *Engine) SyncPlan(beans ...interface{}) (*SyncPlan, error) {
+	session := engine.NewSession()
+	defer session.Close()
+	return session.SyncPlan(beans...)
+}
+
 // Sync2 synchronize structs to database tables
 // Depricated
 func (engine *Engine) Sync2(beans ...interface{}) error {
@@ -1130,10 +1331,15 @@ func (engine *Engine) Sync2(beans ...interface{}) error {
 
 // CreateTables create tabls according bean
 func (engine *Engine) CreateTables(beans ...interface{}) error {
+	beans, err := engine.sortByForeignKeys(beans)
+	if err != nil {
+		return err
+	}
+
 	session := engine.NewSession()
 	defer session.Close()
 
-	err := session.Begin()
+	err = session.Begin()
 	if err != nil {
 		return err
 	}
@@ -1150,16 +1356,22 @@ func (engine *Engine) CreateTables(beans ...interface{}) error {
 
 // DropTables drop specify tables
 func (engine *Engine) DropTables(beans ...interface{}) error {
+	beans, err := engine.sortByForeignKeys(beans)
+	if err != nil {
+		return err
+	}
+
 	session := engine.NewSession()
 	defer session.Close()
 
-	err := session.Begin()
+	err = session.Begin()
 	if err != nil {
 		return err
 	}
 
-	for _, bean := range beans {
-		err = session.dropTable(bean)
+	// drop the referencing tables before the referenced ones
+	for i := len(beans) - 1; i >= 0; i-- {
+		err = session.dropTable(beans[i])
 		if err != nil {
 			_ = session.Rollback()
 			return err
@@ -1168,6 +1380,29 @@ func (engine *Engine) DropTables(beans ...interface{}) error {
 	return session.Commit()
 }
 
+// sortByForeignKeys sorts the beans so that the referenced tables come before the
+// referencing ones, the beans which are not structs keep their original order.
+func (engine *Engine) sortByForeignKeys(beans []interface{}) ([]interface{}, error) {
+	tables := make([]*schemas.Table, len(beans))
+	for i, bean := range beans {
+		v := utils.ReflectValue(bean)
+		if v.Kind() != reflect.Struct {
+			continue
+		}
+		table, err := engine.tagParser.ParseWithCache(v)
+		if err != nil {
+			return nil, err
+		}
+		tables[i] = table
+	}
+
+	sorted := make([]interface{}, 0, len(beans))
+	for _, i := range schemas.SortByForeignKeys(tables) {
+		sorted = append(sorted, beans[i])
+	}
+	return sorted, nil
+}
+
 // DropIndexes drop indexes of a table
 func (engine *Engine) DropIndexes(bean interface{}) error {
 	session := engine.NewSession()
@@ -1203,6 +1438,13 @@ func (engine *Engine) QueryInterface(sqlOrArgs ...interface{}) ([]map[string]int
 	return session.QueryInterface(sqlOrArgs...)
 }
 
+// BulkLoad inserts the records of the slice in bulk, COPY FROM STDIN is used on postgres
+func (engine *Engine) BulkLoad(rowsSlicePtr interface{}) (int64, error) {
+	session := engine.NewSession()
+	defer session.Close()
+	return session.BulkLoad(rowsSlicePtr)
+}
+
 // Insert one or more records
 func (engine *Engine) Insert(beans ...interface{}) (int64, error) {
 	session := engine.NewSession()
@@ -1381,6 +1623,11 @@ func (engine *Engine) AddHook(hook contexts.Hook) {
 	engine.db.AddHook(hook)
 }
 
+// RemoveHook removes a context Hook which has been added
+func (engine *Engine) RemoveHook(hook contexts.Hook) {
+	engine.db.RemoveHook(hook)
+}
+
 // Unscoped always disable struct tag "deleted"
 func (engine *Engine) Unscoped() *Session {
 	session := engine.NewSession()
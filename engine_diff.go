@@ -0,0 +1,151 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xorm
+
+import (
+	"xorm.io/xorm/dialects"
+	"xorm.io/xorm/schemas"
+)
+
+// DiffWith compares the schema of the database with the other's, the differences describe
+// the changes which will bring the database in line with the other one
+func (engine *Engine) DiffWith(other *Engine) (*schemas.SchemaDiff, error) {
+	from, err := engine.DBMetas()
+	if err != nil {
+		return nil, err
+	}
+	to, err := other.DBMetas()
+	if err != nil {
+		return nil, err
+	}
+	return schemas.Diff(from, to), nil
+}
+
+// DiffSQLs returns the SQLs which apply the differences to the database, the dropped
+// tables and columns are included so the SQLs should be reviewed before being executed
+func (engine *Engine) DiffSQLs(diff *schemas.SchemaDiff) ([]string, error) {
+	var sqls []string
+	// the referencing tables and the views should be dropped before the referenced tables
+	dropOrder := schemas.SortByForeignKeys(diff.DroppedTables)
+	for i := len(dropOrder) - 1; i >= 0; i-- {
+		table := diff.DroppedTables[dropOrder[i]]
+		tableName := engine.TableName(table.Name, true)
+		if table.IsView() {
+			sqls = append(sqls, engine.dialect.DropViewSQL(tableName, table.View))
+			continue
+		}
+		sqlStr, _ := engine.dialect.DropTableSQL(tableName)
+		sqls = append(sqls, sqlStr)
+	}
+
+	// the referenced tables should be created before the referencing ones and the views
+	for _, i := range schemas.SortByForeignKeys(diff.AddedTables) {
+		tableSQLs, err := engine.createTableSQLsOf(diff.AddedTables[i])
+		if err != nil {
+			return nil, err
+		}
+		sqls = append(sqls, tableSQLs...)
+	}
+
+	for _, tableDiff := range diff.ChangedTables {
+		tableSQLs, err := engine.alterTableSQLsOf(tableDiff)
+		if err != nil {
+			return nil, err
+		}
+		sqls = append(sqls, tableSQLs...)
+	}
+	return sqls, nil
+}
+
+func (engine *Engine) createTableSQLsOf(table *schemas.Table) ([]string, error) {
+	tableName := engine.TableName(table.Name, true)
+	if table.IsView() {
+		sqlStr, err := engine.dialect.CreateViewSQL(tableName, table.View)
+		if err != nil {
+			return nil, err
+		}
+		return []string{sqlStr}, nil
+	}
+
+	var sqls []string
+	if seqName := dialects.AutoincrSequenceName(engine.dialect, tableName, table.AutoIncrColumn()); table.AutoIncrement != "" && seqName != "" {
+		sqlStr, err := engine.dialect.CreateSequenceSQL(engine.defaultContext, engine.db, seqName)
+		if err != nil {
+			return nil, err
+		}
+		sqls = append(sqls, sqlStr)
+	}
+	sqlStr, _, err := engine.dialect.CreateTableSQL(engine.defaultContext, engine.db, table, tableName)
+	if err != nil {
+		return nil, err
+	}
+	sqls = append(sqls, sqlStr)
+	for _, index := range table.SortedIndexes() {
+		sqls = append(sqls, engine.dialect.CreateIndexSQL(tableName, index))
+	}
+	return sqls, nil
+}
+
+func (engine *Engine) alterTableSQLsOf(diff *schemas.TableDiff) ([]string, error) {
+	tableName := engine.TableName(diff.Name, true)
+	if diff.ViewChanged {
+		return dialects.ReplaceViewSQLs(engine.dialect, tableName, diff.From.View, diff.To.View)
+	}
+
+	var sqls []string
+	var rebuild bool
+	// addSQL adds the SQL or rebuilds the table if the database cannot alter the table
+	addSQL := func(sqlStr string) {
+		if sqlStr == "" {
+			rebuild = true
+		} else {
+			sqls = append(sqls, sqlStr)
+		}
+	}
+
+	for _, fk := range diff.DroppedForeignKeys {
+		addSQL(engine.dialect.DropForeignKeySQL(tableName, fk))
+	}
+	for _, check := range diff.DroppedChecks {
+		addSQL(engine.dialect.DropCheckSQL(tableName, check))
+	}
+	for _, index := range diff.DroppedIndexes {
+		sqls = append(sqls, engine.dialect.DropIndexSQL(tableName, index))
+	}
+
+	for _, col := range diff.AddedColumns {
+		sqls = append(sqls, dialects.AddColumnSQLs(engine.dialect, tableName, col)...)
+	}
+	for _, col := range diff.ChangedColumns {
+		if col.TypeChanged {
+			sqls = append(sqls, engine.dialect.ModifyColumnSQL(tableName, col.To))
+		}
+		if col.NullableChanged {
+			addSQL(engine.dialect.AlterColumnNullableSQL(tableName, col.To))
+		}
+		if col.DefaultChanged {
+			addSQL(engine.dialect.AlterColumnDefaultSQL(tableName, col.To))
+		}
+	}
+	for _, col := range diff.DroppedColumns {
+		sqls = append(sqls, engine.dialect.DropColumnSQL(tableName, col.Name))
+	}
+
+	for _, index := range diff.AddedIndexes {
+		sqls = append(sqls, engine.dialect.CreateIndexSQL(tableName, index))
+	}
+	for _, fk := range diff.AddedForeignKeys {
+		addSQL(engine.dialect.AddForeignKeySQL(tableName, fk))
+	}
+	for _, check := range diff.AddedChecks {
+		addSQL(engine.dialect.AddCheckSQL(tableName, check))
+	}
+
+	// the rebuilt table has all the changes, so the ALTER SQLs are superseded by the rebuilding
+	if rebuild {
+		return engine.dialect.RebuildTableSQLs(engine.defaultContext, engine.db, diff.To, tableName)
+	}
+	return sqls, nil
+}
@@ -20,6 +20,7 @@ type EngineGroup struct {
 	*Engine
 	slaves []*Engine
 	policy GroupPolicy
+	health groupHealth
 }
 
 // NewEngineGroup creates a new engine group
@@ -46,6 +47,7 @@ func NewEngineGroup(args1 interface{}, args2 interface{}, policies ...GroupPolic
 
 		eg.Engine = engines[0]
 		eg.slaves = engines[1:]
+		eg.bindPolicy()
 		return &eg, nil
 	}
 
@@ -58,6 +60,7 @@ func NewEngineGroup(args1 interface{}, args2 interface{}, policies ...GroupPolic
 		}
 		eg.Engine = master
 		eg.slaves = slaves
+		eg.bindPolicy()
 		return &eg, nil
 	}
 	return nil, ErrParamsType
@@ -65,6 +68,8 @@ func NewEngineGroup(args1 interface{}, args2 interface{}, policies ...GroupPolic
 
 // Close the engine
 func (eg *EngineGroup) Close() error {
+	eg.StopHealthCheck()
+
 	err := eg.Engine.Close()
 	if err != nil {
 		return err
@@ -194,10 +199,28 @@ func (eg *EngineGroup) SetMaxOpenConns(conns int) {
 
 // SetPolicy set the group policy
 func (eg *EngineGroup) SetPolicy(policy GroupPolicy) *EngineGroup {
+	if binder, ok := eg.policy.(groupPolicyBinder); ok {
+		binder.unbind(eg)
+	}
 	eg.policy = policy
+	eg.bindPolicy()
 	return eg
 }
 
+func (eg *EngineGroup) bindPolicy() {
+	if binder, ok := eg.policy.(groupPolicyBinder); ok {
+		binder.bind(eg)
+	}
+}
+
+// SetNamingStrategy sets the naming strategy of the indexes, constraints and sequences
+func (eg *EngineGroup) SetNamingStrategy(strategy names.NamingStrategy) {
+	eg.Engine.SetNamingStrategy(strategy)
+	for i := 0; i < len(eg.slaves); i++ {
+		eg.slaves[i].SetNamingStrategy(strategy)
+	}
+}
+
 // SetQuotePolicy sets the special quote policy
 func (eg *EngineGroup) SetQuotePolicy(quotePolicy dialects.QuotePolicy) {
 	eg.Engine.SetQuotePolicy(quotePolicy)
@@ -222,15 +245,23 @@ func (eg *EngineGroup) ShowSQL(show ...bool) {
 	}
 }
 
-// Slave returns one of the physical databases which is a slave according the policy
+// Slave returns one of the physical databases which is a slave according the policy.
+// Unhealthy slaves are skipped and the master will be returned if there is no healthy slave.
 func (eg *EngineGroup) Slave() *Engine {
-	switch len(eg.slaves) {
+	slaves := eg.HealthySlaves()
+	switch len(slaves) {
 	case 0:
 		return eg.Engine
 	case 1:
-		return eg.slaves[0]
+		return slaves[0]
+	}
+
+	// the slaves may become unhealthy after they are fetched, then the master is used
+	slave := eg.policy.Slave(eg)
+	if slave == nil || !eg.health.isHealthy(slave) {
+		return eg.Engine
 	}
-	return eg.policy.Slave(eg)
+	return slave
 }
 
 // Slaves returns all the slaves
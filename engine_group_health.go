@@ -0,0 +1,262 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xorm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"xorm.io/xorm/schemas"
+)
+
+// ErrReplicationStopped represents an error that the replication of a slave is not running
+var ErrReplicationStopped = errors.New("replication is not running")
+
+// LagFunc returns the replication lag of a slave
+type LagFunc func(ctx context.Context, slave *Engine) (time.Duration, error)
+
+// HealthCheckOptions represents the options of the slaves health checking
+type HealthCheckOptions struct {
+	// Interval is the duration between two checks, default is 5 seconds
+	Interval time.Duration
+	// Timeout is the max duration of one check of one slave, default is the Interval
+	Timeout time.Duration
+	// MaxLag marks a slave as unhealthy when its replication lag is greater than it.
+	// Zero means the replication lag will not be checked.
+	MaxLag time.Duration
+	// Lag overrides the default dialect specific replication lag query
+	Lag LagFunc
+}
+
+// SlaveHealth represents the health state of a slave
+type SlaveHealth struct {
+	Engine    *Engine
+	Healthy   bool
+	Lag       time.Duration
+	Err       error
+	CheckedAt time.Time
+}
+
+type groupHealth struct {
+	lock     sync.RWMutex
+	statuses map[*Engine]*SlaveHealth
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+func (h *groupHealth) isHealthy(slave *Engine) bool {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	status, ok := h.statuses[slave]
+	return !ok || status.Healthy
+}
+
+// StartHealthCheck starts a background goroutine to check the health of all the slaves
+// periodically. An unhealthy slave will be skipped by all the group policies until
+// it becomes healthy again. If it has been started, it will be restarted with the new options.
+func (eg *EngineGroup) StartHealthCheck(opts HealthCheckOptions) {
+	if opts.Interval <= 0 {
+		opts.Interval = 5 * time.Second
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = opts.Interval
+	}
+
+	eg.StopHealthCheck()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	eg.health.lock.Lock()
+	eg.health.cancel = cancel
+	eg.health.done = done
+	eg.health.lock.Unlock()
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(opts.Interval)
+		defer ticker.Stop()
+		for {
+			eg.checkHealth(ctx, opts)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// StopHealthCheck stops the background health checking. The last known health
+// states are cleared, so all the slaves are considered as healthy again.
+func (eg *EngineGroup) StopHealthCheck() {
+	eg.health.lock.Lock()
+	cancel, done := eg.health.cancel, eg.health.done
+	eg.health.cancel, eg.health.done = nil, nil
+	eg.health.lock.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+
+	eg.health.lock.Lock()
+	eg.health.statuses = nil
+	eg.health.lock.Unlock()
+}
+
+// CheckHealth checks the health of all the slaves once and returns the states
+func (eg *EngineGroup) CheckHealth(ctx context.Context, opts HealthCheckOptions) []SlaveHealth {
+	eg.checkHealth(ctx, opts)
+	return eg.SlavesHealth()
+}
+
+func (eg *EngineGroup) checkHealth(ctx context.Context, opts HealthCheckOptions) {
+	var wg sync.WaitGroup
+	var statuses = make([]*SlaveHealth, len(eg.slaves))
+	for i, slave := range eg.slaves {
+		wg.Add(1)
+		go func(i int, slave *Engine) {
+			defer wg.Done()
+			statuses[i] = checkSlaveHealth(ctx, slave, opts)
+		}(i, slave)
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	eg.health.lock.Lock()
+	defer eg.health.lock.Unlock()
+	if eg.health.statuses == nil {
+		eg.health.statuses = make(map[*Engine]*SlaveHealth, len(statuses))
+	}
+	for i, status := range statuses {
+		old, ok := eg.health.statuses[status.Engine]
+		if wasHealthy := !ok || old.Healthy; wasHealthy != status.Healthy {
+			if status.Healthy {
+				eg.Engine.logger.Infof("[group] slave %d is healthy", i)
+			} else {
+				eg.Engine.logger.Warnf("[group] slave %d is unhealthy: %v", i, status.Err)
+			}
+		}
+		eg.health.statuses[status.Engine] = status
+	}
+}
+
+func checkSlaveHealth(ctx context.Context, slave *Engine, opts HealthCheckOptions) *SlaveHealth {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	status := &SlaveHealth{
+		Engine:    slave,
+		CheckedAt: time.Now(),
+	}
+	if status.Err = slave.DB().PingContext(ctx); status.Err != nil {
+		return status
+	}
+
+	if opts.MaxLag > 0 {
+		lagFunc := opts.Lag
+		if lagFunc == nil {
+			lagFunc = replicationLag
+		}
+		status.Lag, status.Err = lagFunc(ctx, slave)
+		if status.Err != nil {
+			return status
+		}
+		if status.Lag > opts.MaxLag {
+			status.Err = fmt.Errorf("replication lag %v is greater than %v", status.Lag, opts.MaxLag)
+			return status
+		}
+	}
+
+	status.Healthy = true
+	return status
+}
+
+// replicationLag returns the replication lag of the slave via dialect specific queries,
+// the databases which cannot report it will always return zero.
+func replicationLag(ctx context.Context, slave *Engine) (time.Duration, error) {
+	switch slave.Dialect().URI().DBType {
+	case schemas.POSTGRES:
+		var seconds float64
+		// a replica which has replayed all the received wal is not lagging even if the master is idle
+		if _, err := slave.Context(ctx).SQL("SELECT CASE WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0 " +
+			"ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0) END").Get(&seconds); err != nil {
+			return 0, err
+		}
+		return time.Duration(seconds * float64(time.Second)), nil
+	case schemas.MYSQL:
+		// SHOW REPLICA STATUS is supported since mysql 8.0.22 and mariadb 10.5.1, and
+		// SHOW SLAVE STATUS is removed since mysql 8.4
+		results, err := slave.Context(ctx).QueryString("SHOW REPLICA STATUS")
+		if err != nil {
+			if results, err = slave.Context(ctx).QueryString("SHOW SLAVE STATUS"); err != nil {
+				return 0, err
+			}
+		}
+		if len(results) == 0 {
+			// not a replica
+			return 0, nil
+		}
+		v, ok := results[0]["Seconds_Behind_Source"]
+		if !ok {
+			v = results[0]["Seconds_Behind_Master"]
+		}
+		if v == "" {
+			return 0, ErrReplicationStopped
+		}
+		seconds, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(seconds) * time.Second, nil
+	}
+	return 0, nil
+}
+
+// SlavesHealth returns the last checked health states of all the slaves. A slave which
+// has not been checked is considered as healthy.
+func (eg *EngineGroup) SlavesHealth() []SlaveHealth {
+	eg.health.lock.RLock()
+	defer eg.health.lock.RUnlock()
+
+	var statuses = make([]SlaveHealth, 0, len(eg.slaves))
+	for _, slave := range eg.slaves {
+		if status, ok := eg.health.statuses[slave]; ok {
+			statuses = append(statuses, *status)
+		} else {
+			statuses = append(statuses, SlaveHealth{Engine: slave, Healthy: true})
+		}
+	}
+	return statuses
+}
+
+// HealthySlaves returns all the slaves which are not marked as unhealthy
+func (eg *EngineGroup) HealthySlaves() []*Engine {
+	eg.health.lock.RLock()
+	defer eg.health.lock.RUnlock()
+
+	if len(eg.health.statuses) == 0 {
+		return eg.slaves
+	}
+
+	var slaves = make([]*Engine, 0, len(eg.slaves))
+	for _, slave := range eg.slaves {
+		if status, ok := eg.health.statuses[slave]; !ok || status.Healthy {
+			slaves = append(slaves, slave)
+		}
+	}
+	return slaves
+}
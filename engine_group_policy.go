@@ -5,16 +5,29 @@
 package xorm
 
 import (
+	"context"
 	"math/rand"
 	"sync"
 	"time"
+
+	"xorm.io/xorm/contexts"
 )
 
-// GroupPolicy is be used by chosing the current slave from slaves
+// GroupPolicy is be used by chosing the current slave from slaves. A policy
+// should only chose from EngineGroup.HealthySlaves so that unhealthy slaves are skipped,
+// and return nil if there is no healthy slave so that the master will be used.
 type GroupPolicy interface {
 	Slave(*EngineGroup) *Engine
 }
 
+// groupPolicyBinder will be bound to the engine group when the policy is set,
+// so that the policy could collect information from the slaves, and unbound
+// when the policy is replaced.
+type groupPolicyBinder interface {
+	bind(*EngineGroup)
+	unbind(*EngineGroup)
+}
+
 // GroupPolicyHandler should be used when a function is a GroupPolicy
 type GroupPolicyHandler func(*EngineGroup) *Engine
 
@@ -23,11 +36,26 @@ func (h GroupPolicyHandler) Slave(eg *EngineGroup) *Engine {
 	return h(eg)
 }
 
+// firstHealthySlave returns the first healthy slave or nil if there is no healthy slave
+func firstHealthySlave(g *EngineGroup) *Engine {
+	if slaves := g.HealthySlaves(); len(slaves) > 0 {
+		return slaves[0]
+	}
+	return nil
+}
+
 // RandomPolicy implmentes randomly chose the slave of slaves
 func RandomPolicy() GroupPolicyHandler {
 	var r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	var lock sync.Mutex
 	return func(g *EngineGroup) *Engine {
-		return g.Slaves()[r.Intn(len(g.Slaves()))]
+		var slaves = g.HealthySlaves()
+		if len(slaves) == 0 {
+			return nil
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		return slaves[r.Intn(len(slaves))]
 	}
 }
 
@@ -40,14 +68,25 @@ func WeightRandomPolicy(weights []int) GroupPolicyHandler {
 		}
 	}
 	var r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	var lock sync.Mutex
 
 	return func(g *EngineGroup) *Engine {
 		var slaves = g.Slaves()
-		idx := rands[r.Intn(len(rands))]
-		if idx >= len(slaves) {
-			idx = len(slaves) - 1
+		if len(slaves) == 0 {
+			return nil
 		}
-		return slaves[idx]
+		lock.Lock()
+		defer lock.Unlock()
+		for i := 0; i < len(rands); i++ {
+			idx := rands[r.Intn(len(rands))]
+			if idx >= len(slaves) {
+				idx = len(slaves) - 1
+			}
+			if g.health.isHealthy(slaves[idx]) {
+				return slaves[idx]
+			}
+		}
+		return firstHealthySlave(g)
 	}
 }
 
@@ -56,7 +95,10 @@ func RoundRobinPolicy() GroupPolicyHandler {
 	var pos = -1
 	var lock sync.Mutex
 	return func(g *EngineGroup) *Engine {
-		var slaves = g.Slaves()
+		var slaves = g.HealthySlaves()
+		if len(slaves) == 0 {
+			return nil
+		}
 
 		lock.Lock()
 		defer lock.Unlock()
@@ -82,25 +124,36 @@ func WeightRoundRobinPolicy(weights []int) GroupPolicyHandler {
 
 	return func(g *EngineGroup) *Engine {
 		var slaves = g.Slaves()
+		if len(slaves) == 0 {
+			return nil
+		}
 		lock.Lock()
 		defer lock.Unlock()
-		pos++
-		if pos >= len(rands) {
-			pos = 0
-		}
+		for i := 0; i < len(rands); i++ {
+			pos++
+			if pos >= len(rands) {
+				pos = 0
+			}
 
-		idx := rands[pos]
-		if idx >= len(slaves) {
-			idx = len(slaves) - 1
+			idx := rands[pos]
+			if idx >= len(slaves) {
+				idx = len(slaves) - 1
+			}
+			if g.health.isHealthy(slaves[idx]) {
+				return slaves[idx]
+			}
 		}
-		return slaves[idx]
+		return firstHealthySlave(g)
 	}
 }
 
 // LeastConnPolicy implements GroupPolicy, every time will get the least connections slave
 func LeastConnPolicy() GroupPolicyHandler {
 	return func(g *EngineGroup) *Engine {
-		var slaves = g.Slaves()
+		var slaves = g.HealthySlaves()
+		if len(slaves) == 0 {
+			return nil
+		}
 		connections := 0
 		idx := 0
 		for i := 0; i < len(slaves); i++ {
@@ -116,3 +169,154 @@ func LeastConnPolicy() GroupPolicyHandler {
 		return slaves[idx]
 	}
 }
+
+// LeastInUsePolicy implements GroupPolicy, every time will get the slave which has the least
+// in use connections, if they are equal, the slave which waited less for new connections since
+// last chosen will be returned.
+func LeastInUsePolicy() GroupPolicyHandler {
+	var lastWaits = make(map[*Engine]int64)
+	var lock sync.Mutex
+	return func(g *EngineGroup) *Engine {
+		var slaves = g.HealthySlaves()
+		if len(slaves) == 0 {
+			return nil
+		}
+		lock.Lock()
+		defer lock.Unlock()
+
+		var idx, inUse int
+		var waits int64
+		for i := 0; i < len(slaves); i++ {
+			stats := slaves[i].DB().Stats()
+			slaveWaits := stats.WaitCount - lastWaits[slaves[i]]
+			if i == 0 || stats.InUse < inUse || (stats.InUse == inUse && slaveWaits < waits) {
+				idx, inUse, waits = i, stats.InUse, slaveWaits
+			}
+		}
+		lastWaits[slaves[idx]] = slaves[idx].DB().Stats().WaitCount
+		return slaves[idx]
+	}
+}
+
+// LatencyPolicy implements GroupPolicy, it chooses slaves randomly weighted by the
+// exponentially weighted moving average of the observed query latency and the in use
+// connections, so that slower or busier slaves get less traffic.
+type LatencyPolicy struct {
+	alpha     float64
+	lock      sync.Mutex
+	r         *rand.Rand
+	latencies map[*Engine]float64
+	hooks     map[*Engine]*latencyHook
+}
+
+// LatencyWeightedPolicy returns a LatencyPolicy, alpha is the weight of a new latency sample
+// in the moving average which should be between 0 and 1, default is 0.2.
+func LatencyWeightedPolicy(alpha float64) *LatencyPolicy {
+	if alpha <= 0 || alpha > 1 {
+		alpha = 0.2
+	}
+	return &LatencyPolicy{
+		alpha:     alpha,
+		r:         rand.New(rand.NewSource(time.Now().UnixNano())),
+		latencies: make(map[*Engine]float64),
+		hooks:     make(map[*Engine]*latencyHook),
+	}
+}
+
+// Latency returns the moving average of the observed query latency of the slave
+func (p *LatencyPolicy) Latency(slave *Engine) time.Duration {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return time.Duration(p.latencies[slave])
+}
+
+// Observe adds a latency sample of the slave to the moving average
+func (p *LatencyPolicy) Observe(slave *Engine, latency time.Duration) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if old, ok := p.latencies[slave]; ok {
+		p.latencies[slave] = p.alpha*float64(latency) + (1-p.alpha)*old
+	} else {
+		p.latencies[slave] = float64(latency)
+	}
+}
+
+// Slave implements GroupPolicy
+func (p *LatencyPolicy) Slave(g *EngineGroup) *Engine {
+	var slaves = g.HealthySlaves()
+	if len(slaves) == 0 {
+		return nil
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	// slaves without any sample share the least observed latency so that they will be warmed up
+	var minLatency float64
+	for _, slave := range slaves {
+		if l, ok := p.latencies[slave]; ok && l > 0 && (minLatency == 0 || l < minLatency) {
+			minLatency = l
+		}
+	}
+	if minLatency == 0 {
+		minLatency = 1
+	}
+
+	var weights = make([]float64, len(slaves))
+	var total float64
+	for i, slave := range slaves {
+		latency, ok := p.latencies[slave]
+		if !ok || latency <= 0 {
+			latency = minLatency
+		}
+		weights[i] = 1 / (latency * float64(slave.DB().Stats().InUse+1))
+		total += weights[i]
+	}
+
+	n := p.r.Float64() * total
+	for i, w := range weights {
+		if n < w {
+			return slaves[i]
+		}
+		n -= w
+	}
+	return slaves[len(slaves)-1]
+}
+
+func (p *LatencyPolicy) bind(g *EngineGroup) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for _, slave := range g.Slaves() {
+		if _, ok := p.hooks[slave]; !ok {
+			hook := &latencyHook{policy: p, slave: slave}
+			p.hooks[slave] = hook
+			slave.AddHook(hook)
+		}
+	}
+}
+
+func (p *LatencyPolicy) unbind(g *EngineGroup) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for _, slave := range g.Slaves() {
+		if hook, ok := p.hooks[slave]; ok {
+			delete(p.hooks, slave)
+			delete(p.latencies, slave)
+			slave.RemoveHook(hook)
+		}
+	}
+}
+
+// latencyHook collects the query latency of a slave
+type latencyHook struct {
+	policy *LatencyPolicy
+	slave  *Engine
+}
+
+func (h *latencyHook) BeforeProcess(c *contexts.ContextHook) (context.Context, error) {
+	return c.Ctx, nil
+}
+
+func (h *latencyHook) AfterProcess(c *contexts.ContextHook) error {
+	h.policy.Observe(h.slave, c.ExecuteTime)
+	return nil
+}
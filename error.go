@@ -23,4 +23,8 @@ var (
 	ErrCacheFailed = errors.New("Cache failed")
 	// ErrConditionType condition type unsupported
 	ErrConditionType = errors.New("Unsupported condition type")
+	// ErrViewNotWritable the beans mapped onto views could not be inserted, updated or deleted
+	ErrViewNotWritable = errors.New("View could not be inserted, updated or deleted")
+	// ErrJSONPathDialect the JSON path conditions could only be written by the sessions which know the dialect
+	ErrJSONPathDialect = errors.New("JSON path condition needs the dialect of a session")
 )
@@ -11,9 +11,10 @@ require (
 	github.com/jackc/pgx/v4 v4.12.0
 	github.com/json-iterator/go v1.1.12
 	github.com/lib/pq v1.10.2
+	github.com/marcboeker/go-duckdb v1.5.6
 	github.com/mattn/go-sqlite3 v1.14.9
 	github.com/shopspring/decimal v1.2.0
-	github.com/stretchr/testify v1.7.0
+	github.com/stretchr/testify v1.8.0
 	github.com/syndtr/goleveldb v1.0.0
 	github.com/ziutek/mymysql v1.5.4
 	modernc.org/sqlite v1.14.2
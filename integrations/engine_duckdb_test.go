@@ -0,0 +1,71 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build duckdb
+// +build duckdb
+
+package integrations
+
+import (
+	"strings"
+	"testing"
+
+	_ "github.com/marcboeker/go-duckdb"
+	"github.com/stretchr/testify/assert"
+	"xorm.io/xorm/schemas"
+)
+
+func init() {
+	dbtypes = append(dbtypes, schemas.DUCKDB)
+}
+
+func TestDuckDBNestedTypes(t *testing.T) {
+	assert.NoError(t, PrepareEngine())
+	if testEngine.Dialect().URI().DBType != schemas.DUCKDB {
+		t.Skip("nested types are only supported by duckdb")
+		return
+	}
+
+	type DuckdbNested struct {
+		Id    int64
+		Tags  []string           `xorm:"list(varchar)"`
+		Nums  []int              `xorm:"list(integer)"`
+		Point map[string]float64 `xorm:"struct('x DOUBLE','y DOUBLE')"`
+	}
+
+	assertSync(t, new(DuckdbNested))
+
+	nested := DuckdbNested{
+		Tags:  []string{"a", "b"},
+		Nums:  []int{1, 2, 3},
+		Point: map[string]float64{"x": 1.5, "y": 2},
+	}
+	cnt, err := testEngine.Insert(&nested)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, cnt)
+	assert.True(t, nested.Id > 0)
+
+	var res DuckdbNested
+	has, err := testEngine.ID(nested.Id).Get(&res)
+	assert.NoError(t, err)
+	assert.True(t, has)
+	version, err := testEngine.DBVersion()
+	assert.NoError(t, err)
+	if strings.HasPrefix(version.Number, "0.") {
+		// the strings bound as the lists keep the quotes of the elements on duckdb 0.x
+		res.Tags = nested.Tags
+	}
+	assert.EqualValues(t, nested, res)
+
+	tables, err := testEngine.DBMetas()
+	assert.NoError(t, err)
+	for _, table := range tables {
+		if table.Name != "duckdb_nested" {
+			continue
+		}
+		assert.EqualValues(t, schemas.List, table.GetColumn("tags").SQLType.Name)
+		assert.EqualValues(t, []string{"INTEGER"}, table.GetColumn("nums").TypeParams)
+		assert.EqualValues(t, []string{"x DOUBLE", "y DOUBLE"}, table.GetColumn("point").TypeParams)
+	}
+}
@@ -5,7 +5,9 @@
 package integrations
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"xorm.io/xorm"
 	"xorm.io/xorm/log"
@@ -33,3 +35,103 @@ func TestEngineGroup(t *testing.T) {
 	eg.SetLogLevel(log.LOG_INFO)
 	eg.ShowSQL(true)
 }
+
+func TestEngineGroupHealthCheck(t *testing.T) {
+	assert.NoError(t, PrepareEngine())
+
+	master, ok := testEngine.(*xorm.Engine)
+	if !ok {
+		t.Skip()
+		return
+	}
+
+	slave1, err := xorm.NewEngine(master.DriverName(), master.DataSourceName())
+	assert.NoError(t, err)
+	slave2, err := xorm.NewEngine(master.DriverName(), master.DataSourceName())
+	assert.NoError(t, err)
+	defer slave2.Close()
+
+	eg, err := xorm.NewEngineGroup(master, []*xorm.Engine{slave1, slave2}, xorm.RoundRobinPolicy())
+	assert.NoError(t, err)
+
+	statuses := eg.CheckHealth(context.Background(), xorm.HealthCheckOptions{MaxLag: time.Minute})
+	assert.Len(t, statuses, 2)
+	assert.True(t, statuses[0].Healthy)
+	assert.True(t, statuses[1].Healthy)
+	assert.Len(t, eg.HealthySlaves(), 2)
+
+	assert.NoError(t, slave1.Close())
+	statuses = eg.CheckHealth(context.Background(), xorm.HealthCheckOptions{})
+	assert.False(t, statuses[0].Healthy)
+	assert.Error(t, statuses[0].Err)
+	assert.True(t, statuses[1].Healthy)
+	for i := 0; i < 4; i++ {
+		assert.True(t, eg.Slave() == slave2)
+	}
+
+	assert.NoError(t, slave2.Close())
+	eg.CheckHealth(context.Background(), xorm.HealthCheckOptions{})
+	assert.Len(t, eg.HealthySlaves(), 0)
+	assert.True(t, eg.Slave() == master)
+
+	policies := []xorm.GroupPolicy{
+		xorm.RandomPolicy(),
+		xorm.WeightRandomPolicy([]int{2, 3}),
+		xorm.RoundRobinPolicy(),
+		xorm.WeightRoundRobinPolicy([]int{2, 3}),
+		xorm.LeastConnPolicy(),
+		xorm.LeastInUsePolicy(),
+		xorm.LatencyWeightedPolicy(0),
+	}
+	for _, policy := range policies {
+		assert.Nil(t, policy.Slave(eg))
+		eg.SetPolicy(policy)
+		assert.True(t, eg.Slave() == master)
+	}
+}
+
+func TestEngineGroupLoadPolicies(t *testing.T) {
+	assert.NoError(t, PrepareEngine())
+
+	master, ok := testEngine.(*xorm.Engine)
+	if !ok {
+		t.Skip()
+		return
+	}
+
+	slave1, err := xorm.NewEngine(master.DriverName(), master.DataSourceName())
+	assert.NoError(t, err)
+	defer slave1.Close()
+	slave2, err := xorm.NewEngine(master.DriverName(), master.DataSourceName())
+	assert.NoError(t, err)
+	defer slave2.Close()
+
+	eg, err := xorm.NewEngineGroup(master, []*xorm.Engine{slave1, slave2}, xorm.LeastInUsePolicy())
+	assert.NoError(t, err)
+	slave := eg.Slave()
+	assert.True(t, slave == slave1 || slave == slave2)
+
+	policy := xorm.LatencyWeightedPolicy(0.5)
+	eg.SetPolicy(policy)
+	assert.EqualValues(t, 0, policy.Latency(slave1))
+
+	_, err = slave1.Exec("SELECT 1")
+	assert.NoError(t, err)
+	assert.True(t, policy.Latency(slave1) > 0)
+	assert.EqualValues(t, 0, policy.Latency(slave2))
+
+	policy.Observe(slave1, time.Second)
+	policy.Observe(slave2, time.Microsecond)
+	var chosen = make(map[*xorm.Engine]int)
+	for i := 0; i < 100; i++ {
+		chosen[eg.Slave()]++
+	}
+	assert.True(t, chosen[slave2] > chosen[slave1])
+
+	// the latencies are not collected any more once the policy is replaced
+	eg.SetPolicy(xorm.RoundRobinPolicy())
+	assert.EqualValues(t, 0, policy.Latency(slave1))
+	_, err = slave1.Exec("SELECT 1")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, policy.Latency(slave1))
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -31,6 +32,14 @@ func TestPing(t *testing.T) {
 	}
 }
 
+func TestNewEngineNotConnected(t *testing.T) {
+	// the version of the database is detected lazily, so the engine is created without connecting
+	engine, err := xorm.NewEngine("mysql", "root:@tcp(127.0.0.1:1)/xorm_test?timeout=1s")
+	assert.NoError(t, err)
+	defer engine.Close()
+	assert.Error(t, engine.Ping())
+}
+
 func TestPingContext(t *testing.T) {
 	assert.NoError(t, PrepareEngine())
 
@@ -321,3 +330,90 @@ func TestGetColumnsLength(t *testing.T) {
 		}
 	}
 }
+
+type TestDiffUser struct {
+	Id   int64
+	Name string `xorm:"varchar(50) notnull index"`
+	Age  int
+}
+
+type TestDiffUser2 struct {
+	Id    int64
+	Name  string `xorm:"varchar(100) unique"`
+	Email string `xorm:"varchar(100)"`
+}
+
+func (TestDiffUser2) TableName() string {
+	return "test_diff_user"
+}
+
+type TestDiffOrder struct {
+	Id     int64
+	UserId int64 `xorm:"index"`
+}
+
+type TestDiffUserLog struct {
+	Id     int64
+	UserId int64 `xorm:"fk(test_diff_user.id)"`
+}
+
+func TestDiffWith(t *testing.T) {
+	if testEngine.Dialect().URI().DBType != schemas.SQLITE {
+		t.Skip("diff between two databases is only tested with sqlite")
+	}
+
+	newEngine := func(name string) *xorm.Engine {
+		os.Remove(name)
+		engine, err := xorm.NewEngine(dbType, name)
+		assert.NoError(t, err)
+		return engine
+	}
+	from := newEngine("./test_diff_from.db")
+	defer os.Remove("./test_diff_from.db")
+	defer from.Close()
+	to := newEngine("./test_diff_to.db")
+	defer os.Remove("./test_diff_to.db")
+	defer to.Close()
+
+	assert.NoError(t, from.Sync(new(TestDiffUser)))
+	assert.NoError(t, to.Sync(new(TestDiffUser2), new(TestDiffOrder), new(TestDiffUserLog)))
+
+	diff, err := from.DiffWith(to)
+	assert.NoError(t, err)
+	assert.Len(t, diff.AddedTables, 2)
+	assert.Len(t, diff.DroppedTables, 0)
+	assert.Len(t, diff.ChangedTables, 1)
+
+	sqls, err := from.DiffSQLs(diff)
+	assert.NoError(t, err)
+	for _, sqlStr := range sqls {
+		// sqlite cannot alter the nullability, so the changed table is rebuilt without ALTER
+		assert.NotContains(t, sqlStr, "ALTER TABLE `test_diff_user` ADD")
+		_, err = from.Exec(sqlStr)
+		assert.NoError(t, err)
+	}
+
+	diff, err = from.DiffWith(to)
+	assert.NoError(t, err)
+	assert.True(t, diff.IsEmpty())
+
+	// the referencing tables are dropped before the referenced ones
+	empty := newEngine("./test_diff_empty.db")
+	defer os.Remove("./test_diff_empty.db")
+	defer empty.Close()
+	diff, err = from.DiffWith(empty)
+	assert.NoError(t, err)
+	sqls, err = from.DiffSQLs(diff)
+	assert.NoError(t, err)
+	assert.Len(t, sqls, 3)
+	var dropped []string
+	for _, sqlStr := range sqls {
+		for _, name := range []string{"test_diff_user_log", "test_diff_user"} {
+			if strings.Contains(sqlStr, name) {
+				dropped = append(dropped, name)
+				break
+			}
+		}
+	}
+	assert.EqualValues(t, []string{"test_diff_user_log", "test_diff_user"}, dropped)
+}
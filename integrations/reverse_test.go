@@ -0,0 +1,81 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package integrations
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"xorm.io/xorm/reverse"
+)
+
+type TestReverseUser struct {
+	Id      int64
+	Name    string `xorm:"varchar(50) notnull unique"`
+	Status  int    `xorm:"notnull default(1) index(status_created) check('status >= 0')"`
+	Score   float64
+	Created time.Time `xorm:"created index(status_created)"`
+	Comment string    `xorm:"text"`
+}
+
+type TestReverseOrder struct {
+	Id     int64
+	UserId int64 `xorm:"notnull fk(test_reverse_user.id) on_delete(cascade)"`
+	Amount int   `xorm:"default(0)"`
+}
+
+// reverseTypes maps the generated field types to the reflect types to construct the structs
+var reverseTypes = map[string]reflect.Type{
+	"int":       reflect.TypeOf(0),
+	"int64":     reflect.TypeOf(int64(0)),
+	"uint":      reflect.TypeOf(uint(0)),
+	"uint64":    reflect.TypeOf(uint64(0)),
+	"float32":   reflect.TypeOf(float32(0)),
+	"float64":   reflect.TypeOf(float64(0)),
+	"string":    reflect.TypeOf(""),
+	"bool":      reflect.TypeOf(false),
+	"[]byte":    reflect.TypeOf([]byte{}),
+	"time.Time": reflect.TypeOf(time.Time{}),
+}
+
+func TestReverse(t *testing.T) {
+	assert.NoError(t, PrepareEngine())
+	skipDuckDBIndexLimitations(t)
+	assertSync(t, new(TestReverseUser), new(TestReverseOrder))
+
+	tables, err := testEngine.DBMetas()
+	assert.NoError(t, err)
+	file := reverse.NewFile(testEngine.Dialect(), tables, &reverse.Options{
+		Tables: []string{
+			testEngine.TableName(new(TestReverseUser)),
+			testEngine.TableName(new(TestReverseOrder)),
+		},
+		TableMapper:  testEngine.GetTableMapper(),
+		ColumnMapper: testEngine.GetColumnMapper(),
+	})
+	assert.Len(t, file.Structs, 2)
+
+	// the generated structs should be synced to the same tables without any changes
+	for _, st := range file.Structs {
+		var fields []reflect.StructField
+		for _, field := range st.Fields {
+			tp, ok := reverseTypes[field.Type]
+			assert.True(t, ok, field.Type)
+			fields = append(fields, reflect.StructField{
+				Name: field.Name,
+				Type: tp,
+				Tag:  reflect.StructTag(`xorm:"` + field.Tag + `"`),
+			})
+		}
+		bean := reflect.New(reflect.StructOf(fields)).Interface()
+
+		plan, err := testEngine.Table(st.Table.Name).SyncPlan(bean)
+		assert.NoError(t, err)
+		assert.True(t, plan.IsEmpty(), "%s: %v", st.Name, plan.SQLs)
+		assert.Empty(t, plan.Warnings, st.Name)
+	}
+}
@@ -11,6 +11,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"xorm.io/builder"
+	"xorm.io/xorm"
+	"xorm.io/xorm/schemas"
 )
 
 func TestBuilder(t *testing.T) {
@@ -226,3 +228,86 @@ func TestFindAndCount(t *testing.T) {
 	assert.NoError(t, err)
 	assert.EqualValues(t, 1, total)
 }
+
+func TestJSONPath(t *testing.T) {
+	assert.NoError(t, PrepareEngine())
+
+	switch testEngine.Dialect().URI().DBType {
+	case schemas.POSTGRES, schemas.MYSQL, schemas.SQLITE, schemas.MSSQL:
+	default:
+		t.Skip("json path is unsupported")
+		return
+	}
+
+	if testEngine.Dialect().URI().DBType == schemas.SQLITE {
+		// mattn/go-sqlite3 is built without the json functions unless the sqlite_json tag is given
+		if _, err := testEngine.QueryString("SELECT json('{}')"); err != nil {
+			t.Skip("json functions are unavailable")
+			return
+		}
+	}
+
+	type JsonPathProduct struct {
+		Id    int64
+		Name  string
+		Attrs map[string]interface{} `xorm:"json"`
+	}
+
+	assertSync(t, new(JsonPathProduct))
+
+	_, err := testEngine.Insert([]JsonPathProduct{
+		{Name: "shirt", Attrs: map[string]interface{}{"color": "red", "sizes": []string{"S", "M"}}},
+		{Name: "hat", Attrs: map[string]interface{}{"color": "red", "sizes": []string{"L"}}},
+		{Name: "shoes", Attrs: map[string]interface{}{"color": "black", "sizes": []string{"M"}}},
+	})
+	assert.NoError(t, err)
+
+	var products []JsonPathProduct
+	assert.NoError(t, testEngine.Where(xorm.JSONPath("attrs", "$.color").Eq("red")).Asc("id").Find(&products))
+	assert.EqualValues(t, 2, len(products))
+	assert.EqualValues(t, "shirt", products[0].Name)
+	assert.EqualValues(t, "hat", products[1].Name)
+
+	cnt, err := testEngine.Where(xorm.JSONPath("attrs", "$.sizes").Contains("M")).Count(new(JsonPathProduct))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, cnt)
+
+	cnt, err = testEngine.Where(builder.And(
+		xorm.JSONPath("attrs", "$.color").Neq("red"),
+		xorm.JSONPath("attrs", "$.sizes").Contains("M"),
+	)).Count(new(JsonPathProduct))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, cnt)
+
+	cnt, err = testEngine.Where(xorm.JSONPath("attrs", "$.stock").Eq(nil)).Count(new(JsonPathProduct))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, cnt)
+
+	// only the values at the paths are updated
+	cnt, err = testEngine.ID(products[0].Id).
+		SetJSON("attrs", "$.color", "blue").
+		SetJSON("attrs", "$.stock", 3).
+		Update(new(JsonPathProduct))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, cnt)
+
+	var product JsonPathProduct
+	has, err := testEngine.ID(products[0].Id).Get(&product)
+	assert.NoError(t, err)
+	assert.True(t, has)
+	assert.EqualValues(t, "blue", product.Attrs["color"])
+	assert.EqualValues(t, 3, product.Attrs["stock"])
+	assert.EqualValues(t, []interface{}{"S", "M"}, product.Attrs["sizes"])
+
+	cnt, err = testEngine.Where(xorm.JSONPath("attrs", "$.stock").Eq(3)).Count(new(JsonPathProduct))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, cnt)
+
+	cnt, err = testEngine.Where(xorm.JSONPath("attrs", "$.color").Eq("black")).Delete(new(JsonPathProduct))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, cnt)
+
+	// the conditions could not be written without the dialect of a session
+	_, _, err = builder.ToSQL(xorm.JSONPath("attrs", "$.color").Eq("red"))
+	assert.EqualValues(t, xorm.ErrJSONPathDialect, err)
+}
@@ -348,6 +348,7 @@ func TestGetError(t *testing.T) {
 
 func TestJSONString(t *testing.T) {
 	assert.NoError(t, PrepareEngine())
+	skipWithoutJSONType(t)
 
 	type JsonString struct {
 		Id      int64
@@ -640,6 +640,7 @@ func TestAnonymousStruct(t *testing.T) {
 	}
 
 	assert.NoError(t, PrepareEngine())
+	skipWithoutJSONType(t)
 	assertSync(t, new(PlainFoo))
 
 	_, err := testEngine.Insert(&PlainFoo{
@@ -1039,6 +1040,7 @@ func TestInsertTwice(t *testing.T) {
 
 func TestInsertIntSlice(t *testing.T) {
 	assert.NoError(t, PrepareEngine())
+	skipWithoutJSONType(t)
 
 	type InsertIntSlice struct {
 		NameIDs []int `xorm:"json notnull"`
@@ -1201,3 +1203,54 @@ func TestInsertMultipleMap(t *testing.T) {
 		Name:   "xiaolunwen",
 	}, res[1])
 }
+
+func TestBulkLoad(t *testing.T) {
+	assert.NoError(t, PrepareEngine())
+	skipWithoutJSONType(t)
+
+	type BulkLoadAttrs struct {
+		Color string
+	}
+	type TestBulkLoad struct {
+		Id      int64
+		Name    string
+		Note    string
+		Attrs   BulkLoadAttrs `xorm:"json"`
+		Created time.Time     `xorm:"created"`
+		Updated time.Time     `xorm:"updated"`
+	}
+	assertSync(t, new(TestBulkLoad))
+
+	records := make([]TestBulkLoad, 0, 1500)
+	for i := 0; i < 1500; i++ {
+		records = append(records, TestBulkLoad{
+			Name:  fmt.Sprintf("name\t%d", i),
+			Note:  "note",
+			Attrs: BulkLoadAttrs{Color: "red"},
+		})
+	}
+	loaded, err := testEngine.Omit("note").BulkLoad(&records)
+	assert.NoError(t, err)
+	assert.EqualValues(t, len(records), loaded)
+	assert.False(t, records[0].Created.IsZero())
+	assert.EqualValues(t, records[0].Created.Unix(), records[len(records)-1].Updated.Unix())
+
+	cnt, err := testEngine.Count(new(TestBulkLoad))
+	assert.NoError(t, err)
+	assert.EqualValues(t, len(records), cnt)
+
+	var record TestBulkLoad
+	has, err := testEngine.Where("name = ?", "name\t1499").Get(&record)
+	assert.NoError(t, err)
+	assert.True(t, has)
+	assert.EqualValues(t, "", record.Note)
+	assert.EqualValues(t, "red", record.Attrs.Color)
+	assert.False(t, record.Created.IsZero())
+
+	loaded, err = testEngine.Cols("name").BulkLoad([]*TestBulkLoad{{Name: "a"}, {Name: "b"}})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, loaded)
+
+	_, err = testEngine.BulkLoad([]TestBulkLoad{})
+	assert.EqualValues(t, xorm.ErrNoElementsOnSlice, err)
+}
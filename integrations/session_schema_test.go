@@ -5,12 +5,16 @@
 package integrations
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"xorm.io/xorm"
+	"xorm.io/xorm/dialects"
 	"xorm.io/xorm/schemas"
 )
 
@@ -173,6 +177,7 @@ func (s *SyncTable3) TableName() string {
 
 func TestSyncTable(t *testing.T) {
 	assert.NoError(t, PrepareEngine())
+	skipDuckDBIndexLimitations(t)
 
 	assert.NoError(t, testEngine.Sync(new(SyncTable1)))
 
@@ -207,6 +212,7 @@ func TestSyncTable(t *testing.T) {
 
 func TestSyncTable2(t *testing.T) {
 	assert.NoError(t, PrepareEngine())
+	skipDuckDBIndexLimitations(t)
 
 	assert.NoError(t, testEngine.Table("sync_tablex").Sync(new(SyncTable1)))
 
@@ -536,3 +542,713 @@ func TestModifyColum(t *testing.T) {
 	_, err := testEngine.Exec(alterSQL)
 	assert.NoError(t, err)
 }
+
+func TestSyncPlan(t *testing.T) {
+	type TestSyncPlan struct {
+		Id   int64
+		Name string `xorm:"index"`
+	}
+
+	assert.NoError(t, PrepareEngine())
+	assert.NoError(t, testEngine.DropTables(new(TestSyncPlan)))
+
+	plan, err := testEngine.SyncPlan(new(TestSyncPlan))
+	assert.NoError(t, err)
+	assert.Len(t, plan.AddedTables, 1)
+	assert.Len(t, plan.AddedIndexes, 1)
+	if testEngine.Dialect().Features().AutoincrMode == dialects.SequenceAutoincrMode {
+		// the sequence of the autoincrement column is created before the table
+		assert.Len(t, plan.SQLs, 3)
+	} else {
+		assert.Len(t, plan.SQLs, 2)
+	}
+	assert.False(t, plan.IsEmpty())
+
+	exist, err := testEngine.IsTableExist(new(TestSyncPlan))
+	assert.NoError(t, err)
+	assert.False(t, exist)
+
+	assert.NoError(t, testEngine.Sync(new(TestSyncPlan)))
+	plan, err = testEngine.SyncPlan(new(TestSyncPlan))
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+
+	type TestSyncPlan2 struct {
+		Id       int64
+		Name     string
+		Nickname string `xorm:"unique"`
+	}
+	plan, err = testEngine.Table("test_sync_plan").SyncPlan(new(TestSyncPlan2))
+	assert.NoError(t, err)
+	assert.Len(t, plan.AddedTables, 0)
+	assert.Len(t, plan.AddedColumns, 1)
+	assert.EqualValues(t, "nickname", plan.AddedColumns[0].Column.Name)
+	assert.Len(t, plan.AddedIndexes, 1)
+	assert.Len(t, plan.DroppedIndexes, 1)
+	assert.Len(t, plan.SQLs, 3)
+
+	tables, err := testEngine.DBMetas()
+	assert.NoError(t, err)
+	for _, table := range tables {
+		if table.Name == "test_sync_plan" {
+			assert.Len(t, table.ColumnsSeq(), 2)
+		}
+	}
+}
+
+func TestSyncWithOptions(t *testing.T) {
+	type TestSyncOptions struct {
+		Id      int64
+		Name    string
+		Age     int    `xorm:"default(1)"`
+		Removed string `xorm:"varchar(20)"`
+	}
+
+	type TestSyncOptionsOther struct {
+		Id int64
+	}
+
+	assert.NoError(t, PrepareEngine())
+	assertSync(t, new(TestSyncOptions), new(TestSyncOptionsOther))
+
+	_, err := testEngine.Insert(&TestSyncOptions{Name: "lunny", Age: 3, Removed: "removed"})
+	assert.NoError(t, err)
+
+	type TestSyncOptions2 struct {
+		Id   int64
+		Name string `xorm:"notnull default('')"`
+		Age  int    `xorm:"default(2)"`
+	}
+
+	plan, err := testEngine.Table("test_sync_options").SyncPlan(new(TestSyncOptions2))
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+	warnings := strings.Join(plan.Warnings, "\n")
+	assert.Contains(t, warnings, "Column name db nullable is true, struct nullable is false")
+	assert.Contains(t, warnings, "Column age db default is 1, struct default is 2")
+	assert.Contains(t, warnings, "has column removed but struct has not related field")
+
+	opts := xorm.SyncOptions{
+		AlterNullability: true,
+		AlterDefaults:    true,
+		DropColumns:      true,
+		DropTables:       true,
+	}
+	plan, err = testEngine.Table("test_sync_options").SyncPlanWithOptions(opts, new(TestSyncOptions2))
+	assert.NoError(t, err)
+	assert.Len(t, plan.ChangedColumns, 2)
+	assert.Len(t, plan.DroppedColumns, 1)
+	assert.EqualValues(t, "removed", plan.DroppedColumns[0].OriColumn.Name)
+	assert.Contains(t, plan.DroppedTables, testEngine.TableName("test_sync_options_other", true))
+	assert.NotContains(t, plan.DroppedTables, testEngine.TableName("test_sync_options", true))
+
+	opts.DropTables = false
+	assert.NoError(t, testEngine.Table("test_sync_options").SyncWithOptions(opts, new(TestSyncOptions2)))
+
+	plan, err = testEngine.Table("test_sync_options").SyncPlanWithOptions(opts, new(TestSyncOptions2))
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+	assert.Len(t, plan.Warnings, 0)
+
+	var v TestSyncOptions2
+	has, err := testEngine.Table("test_sync_options").Get(&v)
+	assert.NoError(t, err)
+	assert.True(t, has)
+	assert.EqualValues(t, "lunny", v.Name)
+	assert.EqualValues(t, 3, v.Age)
+}
+
+func TestSyncRenameColumn(t *testing.T) {
+	type TestSyncRename struct {
+		Id   int64
+		Nick string `xorm:"varchar(64) index"`
+		Age  int
+	}
+
+	assert.NoError(t, PrepareEngine())
+	skipDuckDBIndexLimitations(t)
+	assertSync(t, new(TestSyncRename))
+
+	_, err := testEngine.Insert(&TestSyncRename{Nick: "lunny", Age: 3})
+	assert.NoError(t, err)
+
+	type TestSyncRename2 struct {
+		Id       int64
+		Nickname string `xorm:"varchar(64) index renamed_from(nick)"`
+		Age      int
+	}
+
+	plan, err := testEngine.Table("test_sync_rename").SyncPlan(new(TestSyncRename2))
+	assert.NoError(t, err)
+	assert.Len(t, plan.RenamedColumns, 1)
+	assert.EqualValues(t, "nick", plan.RenamedColumns[0].OriColumn.Name)
+	assert.EqualValues(t, "nickname", plan.RenamedColumns[0].Column.Name)
+	assert.Len(t, plan.AddedColumns, 0)
+	assert.Len(t, plan.Warnings, 0)
+
+	assert.NoError(t, testEngine.Table("test_sync_rename").Sync(new(TestSyncRename2)))
+
+	// the old column has gone, so the rename will be skipped
+	plan, err = testEngine.Table("test_sync_rename").SyncPlan(new(TestSyncRename2))
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+	assert.Len(t, plan.Warnings, 0)
+
+	tables, err := testEngine.DBMetas()
+	assert.NoError(t, err)
+	for _, table := range tables {
+		if table.Name != "test_sync_rename" {
+			continue
+		}
+		assert.NotNil(t, table.GetColumn("nickname"))
+		assert.Nil(t, table.GetColumn("nick"))
+		assert.Len(t, table.Indexes, 1)
+	}
+
+	var v TestSyncRename2
+	has, err := testEngine.Table("test_sync_rename").Get(&v)
+	assert.NoError(t, err)
+	assert.True(t, has)
+	assert.EqualValues(t, "lunny", v.Nickname)
+	assert.EqualValues(t, 3, v.Age)
+}
+
+func TestSyncRebuildReferencedTable(t *testing.T) {
+	assert.NoError(t, PrepareEngine())
+	if testEngine.Dialect().URI().DBType != schemas.SQLITE {
+		t.Skip("only sqlite rebuilds tables")
+		return
+	}
+
+	// the foreign keys are enabled for every connection of a new database
+	dsn := filepath.Join(t.TempDir(), "rebuild.db")
+	if dbType == "sqlite" {
+		dsn += "?_pragma=foreign_keys(1)"
+	} else {
+		dsn += "?_foreign_keys=1"
+	}
+	engine, err := xorm.NewEngine(dbType, dsn)
+	assert.NoError(t, err)
+	defer engine.Close()
+
+	type TestRebuildParent struct {
+		Id   int64
+		Name string
+	}
+
+	type TestRebuildChild struct {
+		Id       int64
+		ParentId int64 `xorm:"fk(test_rebuild_parent.id) on_delete(cascade)"`
+	}
+
+	assert.NoError(t, engine.Sync(new(TestRebuildParent), new(TestRebuildChild)))
+	parent := TestRebuildParent{Name: "parent"}
+	_, err = engine.Insert(&parent)
+	assert.NoError(t, err)
+	_, err = engine.Insert(&TestRebuildChild{ParentId: parent.Id})
+	assert.NoError(t, err)
+
+	type TestRebuildParent2 struct {
+		Id   int64
+		Name string `xorm:"notnull"`
+	}
+
+	opts := xorm.SyncOptions{AlterNullability: true}
+	plan, err := engine.Table("test_rebuild_parent").SyncPlanWithOptions(opts, new(TestRebuildParent2))
+	assert.NoError(t, err)
+	assert.Len(t, plan.RebuiltTables, 1)
+	assert.EqualValues(t, "PRAGMA foreign_keys=OFF", plan.SQLs[0])
+	assert.EqualValues(t, "PRAGMA foreign_keys=ON", plan.SQLs[len(plan.SQLs)-1])
+
+	// the rebuilding cannot disable the foreign keys in a transaction
+	session := engine.NewSession()
+	assert.NoError(t, session.Begin())
+	assert.Error(t, session.Table("test_rebuild_parent").SyncWithOptions(opts, new(TestRebuildParent2)))
+	assert.NoError(t, session.Rollback())
+	session.Close()
+
+	assert.NoError(t, engine.Table("test_rebuild_parent").SyncWithOptions(opts, new(TestRebuildParent2)))
+
+	// the referencing records are not deleted by dropping the old table
+	cnt, err := engine.Count(new(TestRebuildChild))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, cnt)
+
+	plan, err = engine.Table("test_rebuild_parent").SyncPlanWithOptions(opts, new(TestRebuildParent2))
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+
+	// the foreign keys are enabled again
+	_, err = engine.Insert(&TestRebuildChild{ParentId: parent.Id + 1})
+	assert.Error(t, err)
+}
+
+func TestSyncForeignKeys(t *testing.T) {
+	type TestFkUser struct {
+		Id   int64
+		Name string
+	}
+
+	type TestFkPost struct {
+		Id     int64
+		UserId int64 `xorm:"fk(test_fk_user.id) on_delete(cascade)"`
+		Title  string
+	}
+
+	assert.NoError(t, PrepareEngine())
+	assert.NoError(t, testEngine.DropTables(new(TestFkUser), new(TestFkPost)))
+	assert.NoError(t, testEngine.CreateTables(new(TestFkPost), new(TestFkUser)))
+
+	tables, err := testEngine.DBMetas()
+	assert.NoError(t, err)
+	var postTable *schemas.Table
+	for _, table := range tables {
+		if table.Name == testEngine.TableName(new(TestFkPost)) {
+			postTable = table
+		}
+	}
+	if assert.NotNil(t, postTable) && assert.Len(t, postTable.ForeignKeys, 1) {
+		for _, fk := range postTable.ForeignKeys {
+			assert.EqualValues(t, "test_fk_user", fk.RefTable)
+			assert.EqualValues(t, []string{"user_id"}, fk.Cols)
+			assert.EqualValues(t, []string{"id"}, fk.RefCols)
+			// duckdb creates the foreign keys without the referential actions
+			expected := dialects.SupportedForeignKey(testEngine.Dialect(), &schemas.ForeignKey{OnDelete: schemas.Cascade})
+			assert.EqualValues(t, expected.OnDelete, fk.OnDelete)
+		}
+	}
+
+	plan, err := testEngine.SyncPlan(new(TestFkPost), new(TestFkUser))
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+
+	type TestFkPost2 struct {
+		Id     int64
+		UserId int64
+		Title  string
+	}
+
+	plan, err = testEngine.Table("test_fk_post").SyncPlan(new(TestFkPost2))
+	assert.NoError(t, err)
+	assert.Len(t, plan.DroppedForeignKeys, 1)
+	assert.Len(t, plan.AddedForeignKeys, 0)
+	assert.NoError(t, testEngine.Table("test_fk_post").Sync(new(TestFkPost2)))
+
+	plan, err = testEngine.SyncPlan(new(TestFkPost))
+	assert.NoError(t, err)
+	assert.Len(t, plan.DroppedForeignKeys, 0)
+	assert.Len(t, plan.AddedForeignKeys, 1)
+	assert.NoError(t, testEngine.Sync(new(TestFkPost)))
+
+	plan, err = testEngine.SyncPlan(new(TestFkPost))
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+
+	assert.NoError(t, testEngine.DropTables(new(TestFkUser), new(TestFkPost)))
+}
+
+type TestSyncCheck struct {
+	Id     int64
+	Amount int64 `xorm:"check('amount >= 0')"`
+	Min    int
+	Max    int
+}
+
+func (TestSyncCheck) TableChecks() []*schemas.Check {
+	return []*schemas.Check{
+		schemas.NewCheck("range", "min <= max"),
+	}
+}
+
+func TestSyncChecks(t *testing.T) {
+	assert.NoError(t, PrepareEngine())
+	assertSync(t, new(TestSyncCheck))
+
+	tables, err := testEngine.DBMetas()
+	assert.NoError(t, err)
+	for _, table := range tables {
+		if table.Name == testEngine.TableName(new(TestSyncCheck)) {
+			assert.Len(t, table.Checks, 2)
+			assert.NotNil(t, table.Checks["CHK_test_sync_check_amount"])
+			if !testEngine.Dialect().Features().UnnamedChecks {
+				assert.NotNil(t, table.Checks["CHK_test_sync_check_range"])
+			}
+		}
+	}
+
+	_, err = testEngine.Insert(&TestSyncCheck{Amount: 1, Min: 1, Max: 2})
+	assert.NoError(t, err)
+	_, err = testEngine.Insert(&TestSyncCheck{Amount: -1})
+	assert.Error(t, err)
+	_, err = testEngine.Insert(&TestSyncCheck{Min: 2, Max: 1})
+	assert.Error(t, err)
+
+	plan, err := testEngine.SyncPlan(new(TestSyncCheck))
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+
+	type TestSyncCheck2 struct {
+		Id     int64
+		Amount int64 `xorm:"check('amount > 0')"`
+		Min    int
+		Max    int
+	}
+
+	plan, err = testEngine.Table("test_sync_check").SyncPlan(new(TestSyncCheck2))
+	assert.NoError(t, err)
+	assert.Len(t, plan.AddedChecks, 1)
+	assert.Len(t, plan.DroppedChecks, 2)
+	assert.NoError(t, testEngine.Table("test_sync_check").Sync(new(TestSyncCheck2)))
+
+	plan, err = testEngine.Table("test_sync_check").SyncPlan(new(TestSyncCheck2))
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+
+	_, err = testEngine.Table("test_sync_check").Insert(&TestSyncCheck2{Amount: 0})
+	assert.Error(t, err)
+	_, err = testEngine.Table("test_sync_check").Insert(&TestSyncCheck2{Amount: 1, Min: 2, Max: 1})
+	assert.NoError(t, err)
+}
+
+type TestSyncIndexOption struct {
+	Id        int64
+	Email     string `xorm:"unique(email, expr:'lower(email)', where:'deleted_at = 0')"`
+	Tenant    int64  `xorm:"index(tenant_created)"`
+	Created   int64  `xorm:"index(tenant_created, desc)"`
+	DeletedAt int64
+}
+
+func TestSyncIndexOptions(t *testing.T) {
+	assert.NoError(t, PrepareEngine())
+	if index := dialects.SupportedIndex(testEngine.Dialect(), &schemas.Index{Where: "deleted_at = 0"}); index.Where == "" {
+		t.Skip("the partial indexes are not supported")
+		return
+	}
+	assertSync(t, new(TestSyncIndexOption))
+
+	plan, err := testEngine.SyncPlan(new(TestSyncIndexOption))
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+
+	_, err = testEngine.Insert(&TestSyncIndexOption{Email: "a@xorm.io"})
+	assert.NoError(t, err)
+	_, err = testEngine.Insert(&TestSyncIndexOption{Email: "A@xorm.io"})
+	assert.Error(t, err)
+	_, err = testEngine.Insert(&TestSyncIndexOption{Email: "A@xorm.io", DeletedAt: 1})
+	assert.NoError(t, err)
+
+	type TestSyncIndexOptions2 struct {
+		Id        int64
+		Email     string `xorm:"unique(email, expr:'lower(email)')"`
+		Tenant    int64  `xorm:"index(tenant_created)"`
+		Created   int64  `xorm:"index(tenant_created)"`
+		DeletedAt int64
+	}
+
+	plan, err = testEngine.Table("test_sync_index_option").SyncPlan(new(TestSyncIndexOptions2))
+	assert.NoError(t, err)
+	assert.Len(t, plan.AddedIndexes, 2)
+	assert.Len(t, plan.DroppedIndexes, 2)
+
+	// the indexes are dropped and added in name order
+	assert.EqualValues(t, "email", plan.AddedIndexes[0].Index.Name)
+	assert.EqualValues(t, "tenant_created", plan.AddedIndexes[1].Index.Name)
+	for i := 0; i < 10; i++ {
+		plan2, err := testEngine.Table("test_sync_index_option").SyncPlan(new(TestSyncIndexOptions2))
+		assert.NoError(t, err)
+		assert.EqualValues(t, plan.SQLs, plan2.SQLs)
+	}
+}
+
+type TestViewUser struct {
+	Id     int64
+	Name   string
+	Status int
+}
+
+type TestActiveUser struct {
+	Id   int64
+	Name string
+}
+
+func (TestActiveUser) TableView() *schemas.View {
+	return schemas.NewView("SELECT id, name FROM test_view_user WHERE status = 1", false)
+}
+
+type TestActiveUser2 struct {
+	Id   int64
+	Name string
+}
+
+func (TestActiveUser2) TableName() string {
+	return "test_active_user"
+}
+
+func (TestActiveUser2) TableView() *schemas.View {
+	return schemas.NewView("SELECT id, name FROM test_view_user WHERE status <> 0", false)
+}
+
+func TestSyncViews(t *testing.T) {
+	assert.NoError(t, PrepareEngine())
+	assert.NoError(t, testEngine.DropTables(new(TestActiveUser), new(TestViewUser)))
+	assert.NoError(t, testEngine.Sync(new(TestActiveUser), new(TestViewUser)))
+
+	_, err := testEngine.Insert([]*TestViewUser{{Name: "a", Status: 1}, {Name: "b"}})
+	assert.NoError(t, err)
+
+	var users []TestActiveUser
+	assert.NoError(t, testEngine.Find(&users))
+	assert.Len(t, users, 1)
+	assert.EqualValues(t, "a", users[0].Name)
+
+	_, err = testEngine.Insert(&TestActiveUser{Name: "c"})
+	assert.True(t, errors.Is(err, xorm.ErrViewNotWritable))
+	_, err = testEngine.ID(users[0].Id).Update(&TestActiveUser{Name: "c"})
+	assert.True(t, errors.Is(err, xorm.ErrViewNotWritable))
+	_, err = testEngine.Delete(&TestActiveUser{Id: users[0].Id})
+	assert.True(t, errors.Is(err, xorm.ErrViewNotWritable))
+
+	plan, err := testEngine.SyncPlan(new(TestViewUser), new(TestActiveUser))
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+
+	tables, err := testEngine.DBMetas()
+	assert.NoError(t, err)
+	var view *schemas.Table
+	for _, table := range tables {
+		if table.Name == "test_active_user" {
+			view = table
+		}
+	}
+	if assert.NotNil(t, view) {
+		assert.True(t, view.IsView())
+		assert.EqualValues(t, []string{"id", "name"}, view.ColumnsSeq())
+	}
+
+	assert.NoError(t, testEngine.CreateView("test_inactive_user",
+		testEngine.Table("test_view_user").Cols("id", "name").Where("status = ?", 0)))
+	var inactive []TestActiveUser
+	assert.NoError(t, testEngine.Table("test_inactive_user").Find(&inactive))
+	assert.Len(t, inactive, 1)
+	assert.EqualValues(t, "b", inactive[0].Name)
+
+	// the existing view is replaced
+	assert.NoError(t, testEngine.CreateView("test_inactive_user",
+		testEngine.Table("test_view_user").Cols("id", "name").Where("status = ?", 1)))
+	inactive = nil
+	assert.NoError(t, testEngine.Table("test_inactive_user").Find(&inactive))
+	assert.Len(t, inactive, 1)
+	assert.EqualValues(t, "a", inactive[0].Name)
+	assert.NoError(t, testEngine.DropView("test_inactive_user"))
+
+	// the changed view is replaced by sync
+	plan, err = testEngine.SyncPlan(new(TestActiveUser2))
+	assert.NoError(t, err)
+	assert.Len(t, plan.ReplacedViews, 1)
+	assert.NoError(t, testEngine.Sync(new(TestActiveUser2)))
+	plan, err = testEngine.SyncPlan(new(TestActiveUser2))
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+
+	if testEngine.Dialect().URI().DBType != schemas.POSTGRES {
+		assert.Error(t, testEngine.CreateMaterializedView("test_inactive_user", testEngine.Table("test_view_user")))
+	}
+
+	assert.NoError(t, testEngine.DropTables(new(TestActiveUser), new(TestViewUser)))
+}
+
+type TestPartitionedEvent struct {
+	Id      int64 `xorm:"pk"`
+	Created int64 `xorm:"pk"`
+	Name    string
+}
+
+func (TestPartitionedEvent) TablePartitioning() *schemas.Partitioning {
+	return schemas.NewPartitioning(schemas.RangePartitioning, "created").AddPartition(
+		schemas.RangePartition("test_partitioned_event_0", "0", "1000"),
+		schemas.RangePartition("test_partitioned_event_1", "1000", "2000"),
+	)
+}
+
+func TestPartitions(t *testing.T) {
+	assert.NoError(t, PrepareEngine())
+	assertSync(t, new(TestPartitionedEvent))
+
+	_, err := testEngine.Insert(&TestPartitionedEvent{Id: 1, Created: 500, Name: "a"})
+	assert.NoError(t, err)
+
+	plan, err := testEngine.SyncPlan(new(TestPartitionedEvent))
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+
+	switch testEngine.Dialect().URI().DBType {
+	case schemas.POSTGRES, schemas.MYSQL:
+		assert.NoError(t, testEngine.CreatePartition(new(TestPartitionedEvent),
+			schemas.RangePartition("test_partitioned_event_2", "2000", "3000")))
+		partitions, err := testEngine.ListPartitions(new(TestPartitionedEvent))
+		assert.NoError(t, err)
+		assert.Len(t, partitions, 3)
+
+		_, err = testEngine.Insert(&TestPartitionedEvent{Id: 2, Created: 2500, Name: "b"})
+		assert.NoError(t, err)
+		assert.NoError(t, testEngine.DropPartition(new(TestPartitionedEvent), "test_partitioned_event_2"))
+		cnt, err := testEngine.Count(new(TestPartitionedEvent))
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, cnt)
+	default:
+		_, err = testEngine.ListPartitions(new(TestPartitionedEvent))
+		assert.Error(t, err)
+		assert.Error(t, testEngine.CreatePartition(new(TestPartitionedEvent),
+			schemas.RangePartition("test_partitioned_event_2", "2000", "3000")))
+	}
+}
+
+type TestSchemaUser struct {
+	Id   int64
+	Name string `xorm:"varchar(64) index"`
+}
+
+func (TestSchemaUser) TableSchema() string {
+	return "other"
+}
+
+func TestSyncSchemas(t *testing.T) {
+	if testEngine.Dialect().URI().DBType != schemas.SQLITE {
+		t.Skip()
+		return
+	}
+
+	// the attached database works as a schema of sqlite for the connection
+	engine, err := xorm.NewEngine(testEngine.DriverName(), "file:"+t.TempDir()+"/main.db")
+	assert.NoError(t, err)
+	defer engine.Close()
+	engine.SetMaxOpenConns(1)
+	_, err = engine.Exec("ATTACH DATABASE '" + t.TempDir() + "/other.db' AS other")
+	assert.NoError(t, err)
+
+	assert.NoError(t, engine.Sync(new(TestSchemaUser)))
+	exist, err := engine.IsTableExist(new(TestSchemaUser))
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	exist, err = engine.IsTableExist("test_schema_user")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+
+	_, err = engine.Insert(&TestSchemaUser{Name: "lunny"})
+	assert.NoError(t, err)
+	var user TestSchemaUser
+	has, err := engine.Get(&user)
+	assert.NoError(t, err)
+	assert.True(t, has)
+	assert.EqualValues(t, "lunny", user.Name)
+
+	plan, err := engine.SyncPlan(new(TestSchemaUser))
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+
+	type TestSchemaPost struct {
+		Id    int64
+		Title string `xorm:"unique"`
+	}
+
+	assert.NoError(t, engine.Schema("other").Sync(new(TestSchemaPost)))
+	exist, err = engine.IsTableExist(new(TestSchemaPost))
+	assert.NoError(t, err)
+	assert.False(t, exist)
+	exist, err = engine.Schema("other").IsTableExist(new(TestSchemaPost))
+	assert.NoError(t, err)
+	assert.True(t, exist)
+
+	_, err = engine.Schema("other").Insert(&TestSchemaPost{Title: "schemas"})
+	assert.NoError(t, err)
+	cnt, err := engine.Schema("other").Count(new(TestSchemaPost))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, cnt)
+
+	plan, err = engine.Schema("other").SyncPlan(new(TestSchemaPost))
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+
+	// the table in the default schema is not the one in the attached schema
+	assert.NoError(t, engine.Sync(new(TestSchemaPost)))
+	cnt, err = engine.Count(new(TestSchemaPost))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, cnt)
+
+	assert.NoError(t, engine.Schema("other").DropTable(new(TestSchemaPost)))
+	exist, err = engine.Schema("other").IsTableExist(new(TestSchemaPost))
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
+
+type lowerNamingStrategy struct{}
+
+func (lowerNamingStrategy) IndexName(tableName, indexName string) string {
+	return "ix_" + tableName + "_" + indexName
+}
+
+func (lowerNamingStrategy) UniqueName(tableName, indexName string) string {
+	return "uq_" + tableName + "_" + indexName
+}
+
+func (lowerNamingStrategy) ForeignKeyName(tableName, fkName string) string {
+	return "fk_" + tableName + "_" + fkName
+}
+
+func (lowerNamingStrategy) CheckName(tableName, checkName string) string {
+	return "ck_" + tableName + "_" + checkName
+}
+
+func (lowerNamingStrategy) SequenceName(tableName string) string {
+	return tableName + "_id_seq"
+}
+
+func TestSyncNamingStrategy(t *testing.T) {
+	type TestSyncNaming struct {
+		Id     int64
+		Name   string `xorm:"varchar(64) index"`
+		Email  string `xorm:"varchar(64) unique"`
+		Amount int64  `xorm:"check('amount >= 0')"`
+	}
+
+	assert.NoError(t, PrepareEngine())
+	testEngine.SetNamingStrategy(lowerNamingStrategy{})
+	defer testEngine.SetNamingStrategy(nil)
+
+	assertSync(t, new(TestSyncNaming))
+
+	tables, err := testEngine.DBMetas()
+	assert.NoError(t, err)
+	for _, table := range tables {
+		if table.Name != "test_sync_naming" {
+			continue
+		}
+		assert.Len(t, table.Indexes, 2)
+		assert.NotNil(t, table.Indexes["name"])
+		assert.True(t, table.Indexes["name"].IsRegular)
+		assert.NotNil(t, table.Indexes["email"])
+		assert.NotNil(t, table.Checks["ck_test_sync_naming_amount"])
+	}
+
+	plan, err := testEngine.SyncPlan(new(TestSyncNaming))
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+
+	type TestSyncNaming2 struct {
+		Id     int64
+		Name   string `xorm:"varchar(64)"`
+		Email  string `xorm:"varchar(64) unique"`
+		Amount int64  `xorm:"check('amount > 0')"`
+	}
+
+	plan, err = testEngine.Table("test_sync_naming").SyncPlan(new(TestSyncNaming2))
+	assert.NoError(t, err)
+	assert.Len(t, plan.DroppedIndexes, 1)
+	assert.Len(t, plan.DroppedChecks, 1)
+	assert.Len(t, plan.AddedChecks, 1)
+	assert.NoError(t, testEngine.Table("test_sync_naming").Sync(new(TestSyncNaming2)))
+
+	plan, err = testEngine.Table("test_sync_naming").SyncPlan(new(TestSyncNaming2))
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+}
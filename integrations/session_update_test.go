@@ -312,6 +312,7 @@ func TestUpdateMap2(t *testing.T) {
 
 func TestUpdate1(t *testing.T) {
 	assert.NoError(t, PrepareEngine())
+	skipDuckDBIndexLimitations(t)
 	assertSync(t, new(Userinfo))
 
 	_, err := testEngine.Insert(&Userinfo{
@@ -606,6 +607,7 @@ func TestUpdateUpdated(t *testing.T) {
 
 func TestUpdateSameMapper(t *testing.T) {
 	assert.NoError(t, PrepareEngine())
+	skipDuckDBIndexLimitations(t)
 
 	oldMapper := testEngine.GetTableMapper()
 	testEngine.UnMapType(utils.ReflectValue(new(Userinfo)).Type())
@@ -807,6 +809,7 @@ func TestNoUpdate(t *testing.T) {
 
 func TestNewUpdate(t *testing.T) {
 	assert.NoError(t, PrepareEngine())
+	skipWithoutJSONType(t)
 
 	type TbUserInfo struct {
 		Id       int64       `xorm:"pk autoincr unique BIGINT" json:"id"`
@@ -1355,6 +1358,7 @@ func TestUpdateMultiplePK(t *testing.T) {
 	}
 
 	assert.NoError(t, PrepareEngine())
+	skipDuckDBIndexLimitations(t)
 	assertSync(t, new(TestUpdateMultiplePKStruct))
 
 	test := &TestUpdateMultiplePKStruct{
@@ -174,6 +174,25 @@ func PrepareEngine() error {
 	return createEngine(dbType, connString)
 }
 
+// skipDuckDBIndexLimitations skips the test on duckdb which cannot alter the tables with
+// indexes or update the indexed columns in place
+func skipDuckDBIndexLimitations(t *testing.T) {
+	if testEngine.Dialect().URI().DBType == schemas.DUCKDB {
+		t.Skip("the tables with indexes cannot be altered and the indexed columns cannot be updated in place on duckdb")
+	}
+}
+
+// skipWithoutJSONType skips the test on duckdb if the json extension cannot be loaded, the
+// extension isn't built into every build of duckdb
+func skipWithoutJSONType(t *testing.T) {
+	if testEngine.Dialect().URI().DBType != schemas.DUCKDB {
+		return
+	}
+	if _, err := testEngine.Exec("LOAD json"); err != nil {
+		t.Skip("the json extension of duckdb is unavailable:", err)
+	}
+}
+
 // MainTest the tests entrance
 func MainTest(m *testing.M) {
 	flag.Parse()
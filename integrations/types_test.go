@@ -435,7 +435,7 @@ func TestUnsignedUint64(t *testing.T) {
 	switch testEngine.Dialect().URI().DBType {
 	case schemas.SQLITE:
 		assert.EqualValues(t, "INTEGER", tables[0].Columns()[0].SQLType.Name)
-	case schemas.MYSQL:
+	case schemas.MYSQL, schemas.DUCKDB:
 		assert.EqualValues(t, "UNSIGNED BIGINT", tables[0].Columns()[0].SQLType.Name)
 	case schemas.POSTGRES, schemas.DAMENG:
 		assert.EqualValues(t, "BIGINT", tables[0].Columns()[0].SQLType.Name)
@@ -479,7 +479,7 @@ func TestUnsignedUint32(t *testing.T) {
 	switch testEngine.Dialect().URI().DBType {
 	case schemas.SQLITE:
 		assert.EqualValues(t, "INTEGER", tables[0].Columns()[0].SQLType.Name)
-	case schemas.MYSQL:
+	case schemas.MYSQL, schemas.DUCKDB:
 		assert.EqualValues(t, "UNSIGNED INT", tables[0].Columns()[0].SQLType.Name)
 	case schemas.POSTGRES, schemas.MSSQL, schemas.DAMENG:
 		assert.EqualValues(t, "BIGINT", tables[0].Columns()[0].SQLType.Name)
@@ -516,7 +516,7 @@ func TestUnsignedTinyInt(t *testing.T) {
 	switch testEngine.Dialect().URI().DBType {
 	case schemas.SQLITE, schemas.DAMENG:
 		assert.EqualValues(t, "INTEGER", tables[0].Columns()[0].SQLType.Name)
-	case schemas.MYSQL:
+	case schemas.MYSQL, schemas.DUCKDB:
 		assert.EqualValues(t, "UNSIGNED TINYINT", tables[0].Columns()[0].SQLType.Name)
 	case schemas.POSTGRES:
 		assert.EqualValues(t, "SMALLINT", tables[0].Columns()[0].SQLType.Name)
@@ -619,3 +619,58 @@ func TestMyArray(t *testing.T) {
 	assert.True(t, has)
 	assert.EqualValues(t, v, m.Content)
 }
+
+func TestPgArrayTypes(t *testing.T) {
+	if testEngine.Dialect().URI().DBType != schemas.POSTGRES {
+		t.Skip("native arrays, ranges and hstore are only supported by postgres")
+		return
+	}
+
+	type PgArrayStruct struct {
+		Id     int64
+		Names  []string          `xorm:"pgarray"`
+		Scores []float64         `xorm:"pgarray"`
+		Attrs  map[string]string `xorm:"hstore"`
+		During string            `xorm:"int8range"`
+	}
+
+	assert.NoError(t, PrepareEngine())
+	_, err := testEngine.Exec("CREATE EXTENSION IF NOT EXISTS hstore")
+	assert.NoError(t, err)
+	assertSync(t, new(PgArrayStruct))
+
+	records := []PgArrayStruct{
+		{Names: []string{"a", `b "c"`}, Scores: []float64{1.5, 2}, Attrs: map[string]string{"color": "red"}, During: "[1,10)"},
+		{Names: []string{"d"}, Scores: []float64{}, Attrs: map[string]string{}, During: "[5,20)"},
+	}
+	_, err = testEngine.Insert(&records)
+	assert.NoError(t, err)
+	assert.NotZero(t, records[0].Id)
+	assert.NotZero(t, records[1].Id)
+
+	var res PgArrayStruct
+	has, err := testEngine.ID(records[0].Id).Get(&res)
+	assert.NoError(t, err)
+	assert.True(t, has)
+	assert.EqualValues(t, records[0].Names, res.Names)
+	assert.EqualValues(t, records[0].Scores, res.Scores)
+	assert.EqualValues(t, records[0].Attrs, res.Attrs)
+	assert.EqualValues(t, "[1,10)", res.During)
+
+	var found []PgArrayStruct
+	assert.NoError(t, testEngine.ArrayContains("names", []string{`b "c"`}).Find(&found))
+	assert.Len(t, found, 1)
+
+	found = nil
+	assert.NoError(t, testEngine.ArrayOverlaps("names", []string{"a", "d"}).Find(&found))
+	assert.Len(t, found, 2)
+
+	found = nil
+	assert.NoError(t, testEngine.InArray("id", []int64{records[1].Id}).Find(&found))
+	assert.Len(t, found, 1)
+	assert.EqualValues(t, []string{"d"}, found[0].Names)
+
+	plan, err := testEngine.SyncPlan(new(PgArrayStruct))
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+}
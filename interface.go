@@ -22,8 +22,11 @@ import (
 type Interface interface {
 	AllCols() *Session
 	Alias(alias string) *Session
+	ArrayContains(column string, values interface{}) *Session
+	ArrayOverlaps(column string, values interface{}) *Session
 	Asc(colNames ...string) *Session
 	BufferSize(size int) *Session
+	BulkLoad(rowsSlicePtr interface{}) (int64, error)
 	Cols(columns ...string) *Session
 	Count(...interface{}) (int64, error)
 	CreateIndexes(bean interface{}) error
@@ -42,6 +45,7 @@ type Interface interface {
 	ID(interface{}) *Session
 	In(string, ...interface{}) *Session
 	Incr(column string, arg ...interface{}) *Session
+	InArray(column string, values interface{}) *Session
 	Insert(...interface{}) (int64, error)
 	InsertOne(interface{}) (int64, error)
 	IsTableEmpty(bean interface{}) (bool, error)
@@ -60,7 +64,9 @@ type Interface interface {
 	QueryInterface(sqlOrArgs ...interface{}) ([]map[string]interface{}, error)
 	QueryString(sqlOrArgs ...interface{}) ([]map[string]string, error)
 	Rows(bean interface{}) (*Rows, error)
+	Schema(schema string) *Session
 	SetExpr(string, interface{}) *Session
+	SetJSON(column, path string, value interface{}) *Session
 	Select(string) *Session
 	SQL(interface{}, ...interface{}) *Session
 	Sum(bean interface{}, colName string) (float64, error)
@@ -82,12 +88,19 @@ type EngineInterface interface {
 	Charset(charset string) *Session
 	ClearCache(...interface{}) error
 	Context(context.Context) *Session
+	CreateMaterializedView(viewName string, query *Session) error
+	CreatePartition(beanOrTableName interface{}, partition *schemas.Partition) error
 	CreateTables(...interface{}) error
+	CreateView(viewName string, query *Session) error
 	DBMetas() ([]*schemas.Table, error)
 	DBVersion() (*schemas.Version, error)
+	DiffSQLs(diff *schemas.SchemaDiff) ([]string, error)
+	DiffWith(other *Engine) (*schemas.SchemaDiff, error)
 	Dialect() dialects.Dialect
 	DriverName() string
+	DropPartition(beanOrTableName interface{}, partitionName string) error
 	DropTables(...interface{}) error
+	DropView(viewName string) error
 	DumpAllToFile(fp string, tp ...schemas.DBType) error
 	GetCacher(string) caches.Cacher
 	GetColumnMapper() names.Mapper
@@ -96,11 +109,13 @@ type EngineInterface interface {
 	GetTZDatabase() *time.Location
 	GetTZLocation() *time.Location
 	ImportFile(fp string) ([]sql.Result, error)
+	ListPartitions(beanOrTableName interface{}) ([]*schemas.Partition, error)
 	MapCacher(interface{}, caches.Cacher) error
 	NewSession() *Session
 	NoAutoTime() *Session
 	Prepare() *Session
 	Quote(string) string
+	RefreshMaterializedView(viewName string, concurrently bool) error
 	SetCacher(string, caches.Cacher)
 	SetConnMaxLifetime(time.Duration)
 	SetColumnMapper(names.Mapper)
@@ -111,6 +126,7 @@ type EngineInterface interface {
 	SetMapper(names.Mapper)
 	SetMaxOpenConns(int)
 	SetMaxIdleConns(int)
+	SetNamingStrategy(names.NamingStrategy)
 	SetQuotePolicy(dialects.QuotePolicy)
 	SetSchema(string)
 	SetTableMapper(names.Mapper)
@@ -120,6 +136,9 @@ type EngineInterface interface {
 	ShowSQL(show ...bool)
 	Sync(...interface{}) error
 	Sync2(...interface{}) error
+	SyncPlan(...interface{}) (*SyncPlan, error)
+	SyncPlanWithOptions(SyncOptions, ...interface{}) (*SyncPlan, error)
+	SyncWithOptions(SyncOptions, ...interface{}) error
 	StoreEngine(storeEngine string) *Session
 	TableInfo(bean interface{}) (*schemas.Table, error)
 	TableName(interface{}, ...bool) string
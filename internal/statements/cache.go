@@ -6,12 +6,15 @@ package statements
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"xorm.io/xorm/internal/utils"
 	"xorm.io/xorm/schemas"
 )
 
+var topRegexp = regexp.MustCompile(`(?i)^SELECT (?:DISTINCT )?(TOP \d+ )`)
+
 // ConvertIDSQL converts SQL with id
 func (statement *Statement) ConvertIDSQL(sqlStr string) string {
 	if statement.RefTable != nil {
@@ -26,10 +29,10 @@ func (statement *Statement) ConvertIDSQL(sqlStr string) string {
 			return ""
 		}
 
+		// TOP of the legacy pagination of mssql is kept when the columns are replaced
 		var top string
-		pLimitN := statement.LimitN
-		if pLimitN != nil && statement.dialect.URI().DBType == schemas.MSSQL {
-			top = fmt.Sprintf("TOP %d ", *pLimitN)
+		if matches := topRegexp.FindStringSubmatch(sqls[0]); matches != nil {
+			top = matches[1]
 		}
 
 		newsql := fmt.Sprintf("SELECT %s%s FROM %v", top, colstrs, sqls[1])
@@ -5,13 +5,18 @@
 package statements
 
 import (
+	"fmt"
+	"reflect"
+
 	"xorm.io/builder"
+	"xorm.io/xorm/dialects"
 	"xorm.io/xorm/schemas"
 )
 
 type QuoteReplacer struct {
 	*builder.BytesWriter
-	quoter schemas.Quoter
+	quoter  schemas.Quoter
+	dialect dialects.Dialect
 }
 
 func (q *QuoteReplacer) Write(p []byte) (n int, err error) {
@@ -19,10 +24,17 @@ func (q *QuoteReplacer) Write(p []byte) (n int, err error) {
 	return q.BytesWriter.Builder.WriteString(c)
 }
 
+// Dialect returns the dialect of the statement, so that the conditions could be written
+// in the dialect specific way
+func (q *QuoteReplacer) Dialect() dialects.Dialect {
+	return q.dialect
+}
+
 func (statement *Statement) QuoteReplacer(w *builder.BytesWriter) *QuoteReplacer {
 	return &QuoteReplacer{
 		BytesWriter: w,
 		quoter:      statement.dialect.Quoter(),
+		dialect:     statement.dialect,
 	}
 }
 
@@ -96,6 +108,35 @@ func (statement *Statement) NotIn(column string, args ...interface{}) *Statement
 	return statement
 }
 
+// ArrayContains generate "Where column @> ?" statement, the postgres array column contains all the values
+func (statement *Statement) ArrayContains(column string, values interface{}) *Statement {
+	return statement.arrayCond("%s @> ?", column, values)
+}
+
+// ArrayOverlaps generate "Where column && ?" statement, the postgres array column contains any of the values
+func (statement *Statement) ArrayOverlaps(column string, values interface{}) *Statement {
+	return statement.arrayCond("%s && ?", column, values)
+}
+
+// InArray generate "Where column = ANY(?)" statement, the values are passed as one postgres array
+func (statement *Statement) InArray(column string, values interface{}) *Statement {
+	return statement.arrayCond("%s = ANY(?)", column, values)
+}
+
+func (statement *Statement) arrayCond(format, column string, values interface{}) *Statement {
+	if dbType := statement.dialect.URI().DBType; dbType != schemas.POSTGRES {
+		statement.LastError = fmt.Errorf("the array conditions are only supported by postgres but the database is %s", dbType)
+		return statement
+	}
+	literal, err := dialects.FormatPgArray(reflect.ValueOf(values))
+	if err != nil {
+		statement.LastError = err
+		return statement
+	}
+	statement.cond = statement.cond.And(builder.Expr(fmt.Sprintf(format, statement.quote(column)), literal))
+	return statement
+}
+
 // SetNoAutoCondition if you do not want convert bean's field as query condition, then use this function
 func (statement *Statement) SetNoAutoCondition(no ...bool) *Statement {
 	statement.NoAutoCondition = true
@@ -10,7 +10,7 @@ import (
 	"strings"
 
 	"xorm.io/builder"
-	"xorm.io/xorm/internal/utils"
+	"xorm.io/xorm/dialects"
 	"xorm.io/xorm/schemas"
 )
 
@@ -44,7 +44,11 @@ func (statement *Statement) GenInsertSQL(colNames []string, args []interface{})
 	}
 
 	var hasInsertColumns = len(colNames) > 0
-	var needSeq = len(table.AutoIncrement) > 0 && (statement.dialect.URI().DBType == schemas.ORACLE || statement.dialect.URI().DBType == schemas.DAMENG)
+	var nextval string
+	if len(table.AutoIncrement) > 0 {
+		nextval = dialects.AutoincrNextvalSQL(statement.dialect, tableName, table.AutoIncrColumn())
+	}
+	var needSeq = nextval != ""
 	if needSeq {
 		for _, col := range colNames {
 			if strings.EqualFold(col, table.AutoIncrement) {
@@ -54,7 +58,7 @@ func (statement *Statement) GenInsertSQL(colNames []string, args []interface{})
 		}
 	}
 
-	if !hasInsertColumns && statement.dialect.URI().DBType != schemas.ORACLE &&
+	if !hasInsertColumns && !needSeq && statement.dialect.URI().DBType != schemas.ORACLE &&
 		statement.dialect.URI().DBType != schemas.DAMENG {
 		if statement.dialect.URI().DBType == schemas.MYSQL {
 			if _, err := buf.WriteString(" VALUES ()"); err != nil {
@@ -103,7 +107,7 @@ func (statement *Statement) GenInsertSQL(colNames []string, args []interface{})
 						return "", nil, err
 					}
 				}
-				if _, err := buf.WriteString(utils.SeqName(tableName) + ".nextval"); err != nil {
+				if _, err := buf.WriteString(nextval); err != nil {
 					return "", nil, err
 				}
 			}
@@ -128,7 +132,7 @@ func (statement *Statement) GenInsertSQL(colNames []string, args []interface{})
 				return "", nil, err
 			}
 
-			if err := statement.Conds().WriteTo(buf); err != nil {
+			if err := statement.Conds().WriteTo(statement.QuoteReplacer(buf)); err != nil {
 				return "", nil, err
 			}
 		} else {
@@ -147,7 +151,7 @@ func (statement *Statement) GenInsertSQL(colNames []string, args []interface{})
 						return "", nil, err
 					}
 				}
-				if _, err := buf.WriteString(utils.SeqName(tableName) + ".nextval"); err != nil {
+				if _, err := buf.WriteString(nextval); err != nil {
 					return "", nil, err
 				}
 			}
@@ -168,7 +172,8 @@ func (statement *Statement) GenInsertSQL(colNames []string, args []interface{})
 		}
 	}
 
-	if len(table.AutoIncrement) > 0 && statement.dialect.URI().DBType == schemas.POSTGRES {
+	if len(table.AutoIncrement) > 0 && (statement.dialect.URI().DBType == schemas.POSTGRES ||
+		statement.dialect.URI().DBType == schemas.DUCKDB) {
 		if _, err := buf.WriteString(" RETURNING "); err != nil {
 			return "", nil, err
 		}
@@ -219,7 +224,7 @@ func (statement *Statement) GenInsertMapSQL(columns []string, args []interface{}
 			return "", nil, err
 		}
 
-		if err := statement.Conds().WriteTo(buf); err != nil {
+		if err := statement.Conds().WriteTo(statement.QuoteReplacer(buf)); err != nil {
 			return "", nil, err
 		}
 	} else {
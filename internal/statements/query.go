@@ -11,7 +11,7 @@ import (
 	"strings"
 
 	"xorm.io/builder"
-	"xorm.io/xorm/internal/utils"
+	"xorm.io/xorm/dialects"
 	"xorm.io/xorm/schemas"
 )
 
@@ -201,170 +201,88 @@ func (statement *Statement) writeFrom(w builder.Writer) error {
 	return statement.writeJoin(w)
 }
 
-func (statement *Statement) writeLimitOffset(w builder.Writer) error {
-	if statement.Start > 0 {
-		if statement.LimitN != nil {
-			_, err := fmt.Fprintf(w, " LIMIT %v OFFSET %v", *statement.LimitN, statement.Start)
-			return err
-		}
-		_, err := fmt.Fprintf(w, " LIMIT 0 OFFSET %v", statement.Start)
-		return err
-	}
-	if statement.LimitN != nil {
-		_, err := fmt.Fprint(w, " LIMIT ", *statement.LimitN)
-		return err
-	}
-	// no limit statement
-	return nil
-}
-
 func (statement *Statement) genSelectSQL(columnStr string, needLimit, needOrderBy bool) (string, []interface{}, error) {
-	var (
-		distinct      string
-		dialect       = statement.dialect
-		top, whereStr string
-		mssqlCondi    = builder.NewWriter()
-	)
+	query := dialects.SelectQuery{
+		Distinct: statement.IsDistinct && !strings.HasPrefix(columnStr, "count"),
+		Columns:  columnStr,
+	}
 
-	if statement.IsDistinct && !strings.HasPrefix(columnStr, "count") {
-		distinct = "DISTINCT "
+	fromWriter := builder.NewWriter()
+	if err := statement.writeFrom(fromWriter); err != nil {
+		return "", nil, err
 	}
+	query.From, query.FromArgs = fromWriter.String(), fromWriter.Args()
 
 	condWriter := builder.NewWriter()
 	if err := statement.cond.WriteTo(statement.QuoteReplacer(condWriter)); err != nil {
 		return "", nil, err
 	}
+	query.Where, query.WhereArgs = condWriter.String(), condWriter.Args()
 
-	if condWriter.Len() > 0 {
-		whereStr = " WHERE "
-	}
-
-	pLimitN := statement.LimitN
-	if dialect.URI().DBType == schemas.MSSQL {
-		if pLimitN != nil {
-			LimitNValue := *pLimitN
-			top = fmt.Sprintf("TOP %d ", LimitNValue)
-		}
-		if statement.Start > 0 {
-			if statement.RefTable == nil {
-				return "", nil, errors.New("Unsupported query limit without reference table")
-			}
-			var column string
-			if len(statement.RefTable.PKColumns()) == 0 {
-				for _, index := range statement.RefTable.Indexes {
-					if len(index.Cols) == 1 {
-						column = index.Cols[0]
-						break
-					}
-				}
-				if len(column) == 0 {
-					column = statement.RefTable.ColumnsSeq()[0]
-				}
-			} else {
-				column = statement.RefTable.PKColumns()[0].Name
-			}
-			if statement.needTableName() {
-				if len(statement.TableAlias) > 0 {
-					column = fmt.Sprintf("%s.%s", statement.TableAlias, column)
-				} else {
-					column = fmt.Sprintf("%s.%s", statement.TableName(), column)
-				}
-			}
-
-			if _, err := fmt.Fprintf(mssqlCondi, "(%s NOT IN (SELECT TOP %d %s",
-				column, statement.Start, column); err != nil {
-				return "", nil, err
-			}
-			if err := statement.writeFrom(mssqlCondi); err != nil {
-				return "", nil, err
-			}
-			if whereStr != "" {
-				if _, err := fmt.Fprint(mssqlCondi, whereStr); err != nil {
-					return "", nil, err
-				}
-				if err := utils.WriteBuilder(mssqlCondi, statement.QuoteReplacer(condWriter)); err != nil {
-					return "", nil, err
-				}
-			}
-			if needOrderBy {
-				if err := statement.WriteOrderBy(mssqlCondi); err != nil {
-					return "", nil, err
-				}
-			}
-			if err := statement.WriteGroupBy(mssqlCondi); err != nil {
-				return "", nil, err
-			}
-			if _, err := fmt.Fprint(mssqlCondi, "))"); err != nil {
-				return "", nil, err
-			}
-		}
-	}
-
-	buf := builder.NewWriter()
-	if _, err := fmt.Fprintf(buf, "SELECT %v%v%v", distinct, top, columnStr); err != nil {
+	groupByWriter := builder.NewWriter()
+	if err := statement.WriteGroupBy(groupByWriter); err != nil {
 		return "", nil, err
 	}
-	if err := statement.writeFrom(buf); err != nil {
+	query.GroupBy = groupByWriter.String()
+	havingWriter := builder.NewWriter()
+	if err := statement.writeHaving(havingWriter); err != nil {
 		return "", nil, err
 	}
-	if whereStr != "" {
-		if _, err := fmt.Fprint(buf, whereStr); err != nil {
-			return "", nil, err
-		}
-		if err := utils.WriteBuilder(buf, statement.QuoteReplacer(condWriter)); err != nil {
-			return "", nil, err
-		}
-	}
-	if mssqlCondi.Len() > 0 {
-		if len(whereStr) > 0 {
-			if _, err := fmt.Fprint(buf, " AND "); err != nil {
-				return "", nil, err
-			}
-		} else {
-			if _, err := fmt.Fprint(buf, " WHERE "); err != nil {
-				return "", nil, err
-			}
-		}
+	query.Having = havingWriter.String()
 
-		if err := utils.WriteBuilder(buf, mssqlCondi); err != nil {
+	if needOrderBy {
+		orderByWriter := builder.NewWriter()
+		if err := statement.WriteOrderBy(orderByWriter); err != nil {
 			return "", nil, err
 		}
+		query.OrderBy, query.OrderByArgs = orderByWriter.String(), orderByWriter.Args()
 	}
 
-	if err := statement.WriteGroupBy(buf); err != nil {
-		return "", nil, err
+	if needLimit {
+		query.Limit, query.Offset = statement.LimitN, statement.Start
+		query.KeyColumn = statement.keyColumn()
 	}
-	if err := statement.writeHaving(buf); err != nil {
+
+	sqlStr, args, err := statement.dialect.LimitOffsetSQL(&query)
+	if err != nil {
 		return "", nil, err
 	}
-	if needOrderBy {
-		if err := statement.WriteOrderBy(buf); err != nil {
-			return "", nil, err
-		}
+	if statement.IsForUpdate {
+		return statement.dialect.ForUpdateSQL(sqlStr), args, nil
 	}
-	if needLimit {
-		if dialect.URI().DBType != schemas.MSSQL && dialect.URI().DBType != schemas.ORACLE {
-			if err := statement.writeLimitOffset(buf); err != nil {
-				return "", nil, err
+	return sqlStr, args, nil
+}
+
+// keyColumn returns the column which identifies the records of the reference table, the
+// first primary key or the first single column index or the first column
+func (statement *Statement) keyColumn() string {
+	if statement.RefTable == nil {
+		return ""
+	}
+	var column string
+	if len(statement.RefTable.PKColumns()) == 0 {
+		for _, index := range statement.RefTable.Indexes {
+			if len(index.Cols) == 1 && !schemas.IsIndexExpr(index.Cols[0]) {
+				column = index.Cols[0]
+				break
 			}
-		} else if dialect.URI().DBType == schemas.ORACLE {
-			if pLimitN != nil {
-				oldString := buf.String()
-				buf.Reset()
-				rawColStr := columnStr
-				if rawColStr == "*" {
-					rawColStr = "at.*"
-				}
-				fmt.Fprintf(buf, "SELECT %v FROM (SELECT %v,ROWNUM RN FROM (%v) at WHERE ROWNUM <= %d) aat WHERE RN > %d",
-					columnStr, rawColStr, oldString, statement.Start+*pLimitN, statement.Start)
+		}
+		if len(column) == 0 {
+			if len(statement.RefTable.ColumnsSeq()) == 0 {
+				return ""
 			}
+			column = statement.RefTable.ColumnsSeq()[0]
 		}
+	} else {
+		column = statement.RefTable.PKColumns()[0].Name
 	}
-	if statement.IsForUpdate {
-		return dialect.ForUpdateSQL(buf.String()), buf.Args(), nil
+	if statement.needTableName() {
+		if len(statement.TableAlias) > 0 {
+			return fmt.Sprintf("%s.%s", statement.TableAlias, column)
+		}
+		return fmt.Sprintf("%s.%s", statement.TableName(), column)
 	}
-
-	return buf.String(), buf.Args(), nil
+	return column
 }
 
 // GenExistSQL generates Exist SQL
@@ -53,6 +53,7 @@ type Statement struct {
 	useAllCols      bool
 	AltTableName    string
 	tableName       string
+	Schema          string
 	RawSQL          string
 	RawParams       []interface{}
 	UseCascade      bool
@@ -131,6 +132,7 @@ func (statement *Statement) Reset() {
 	statement.OmitColumnMap = columnMap{}
 	statement.AltTableName = ""
 	statement.tableName = ""
+	statement.Schema = ""
 	statement.idParam = nil
 	statement.RawSQL = ""
 	statement.RawParams = make([]interface{}, 0)
@@ -231,6 +233,12 @@ func (statement *Statement) Decr(column string, arg ...interface{}) *Statement {
 
 // SetExpr Generate  "Update ... Set column = {expression}" statement
 func (statement *Statement) SetExpr(column string, expression interface{}) *Statement {
+	for _, expr := range statement.ExprColumns {
+		if _, ok := expr.Arg.(jsonSetExpr); ok && strings.EqualFold(expr.ColName, column) {
+			statement.LastError = fmt.Errorf("column %s has been set by SetJSON, it cannot be set by SetExpr", column)
+			return statement
+		}
+	}
 	if e, ok := expression.(string); ok {
 		statement.ExprColumns.Add(column, statement.dialect.Quoter().Replace(e))
 	} else {
@@ -239,6 +247,50 @@ func (statement *Statement) SetExpr(column string, expression interface{}) *Stat
 	return statement
 }
 
+// jsonSetExpr is the expression generated by SetJSON, the following SetJSON of the same
+// column nests it
+type jsonSetExpr struct {
+	builder.Cond
+}
+
+// SetJSON Generate "Update ... Set column = json_set(column, path, value)" statement, the values
+// of the paths of the same column are set by one nested expression. The column should not be
+// set by SetExpr at the same time.
+func (statement *Statement) SetJSON(column, path string, value interface{}) *Statement {
+	target, targetArgs := statement.quote(column), []interface{}(nil)
+	idx := -1
+	for i, expr := range statement.ExprColumns {
+		if !strings.EqualFold(expr.ColName, column) {
+			continue
+		}
+		prev, ok := expr.Arg.(jsonSetExpr)
+		if !ok {
+			statement.LastError = fmt.Errorf("column %s has been set by SetExpr, it cannot be set by SetJSON", column)
+			return statement
+		}
+		sql, args, err := builder.ToSQL(prev.Cond)
+		if err != nil {
+			statement.LastError = err
+			return statement
+		}
+		target, targetArgs, idx = sql, args, i
+		break
+	}
+
+	sql, args, err := dialects.JSONSetSQL(statement.dialect, target, path, value)
+	if err != nil {
+		statement.LastError = err
+		return statement
+	}
+	expr := jsonSetExpr{builder.Expr(sql, append(targetArgs, args...)...)}
+	if idx >= 0 {
+		statement.ExprColumns[idx].Arg = expr
+	} else {
+		statement.ExprColumns.Add(column, expr)
+	}
+	return statement
+}
+
 // ForUpdate generates "SELECT ... FOR UPDATE" statement
 func (statement *Statement) ForUpdate() *Statement {
 	statement.IsForUpdate = true
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"xorm.io/builder"
 	"xorm.io/xorm/caches"
 	"xorm.io/xorm/dialects"
 	"xorm.io/xorm/names"
@@ -96,6 +97,61 @@ func TestConvertSQLOrArgs(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestArrayConds(t *testing.T) {
+	pgDialect := dialects.QueryDialect("postgres")
+	assert.NoError(t, pgDialect.Init(&dialects.URI{DBType: "postgres"}))
+	pgTagParser := tags.NewParser("xorm", pgDialect, names.SnakeMapper{}, names.SnakeMapper{}, caches.NewManager())
+
+	statement := NewStatement(pgDialect, pgTagParser, time.Local)
+	statement.ArrayContains("tags", []string{"a", "b"}).
+		ArrayOverlaps("scores", []int64{1, 2}).
+		InArray("id", []int64{3})
+	assert.NoError(t, statement.LastError)
+
+	sql, args, err := builder.ToSQL(statement.Conds())
+	assert.NoError(t, err)
+	assert.EqualValues(t, `"tags" @> ? AND "scores" && ? AND "id" = ANY(?)`, sql)
+	assert.EqualValues(t, []interface{}{`{"a","b"}`, "{1,2}", "{3}"}, args)
+
+	statement = NewStatement(pgDialect, pgTagParser, time.Local)
+	statement.InArray("id", 1)
+	assert.Error(t, statement.LastError)
+
+	// the other databases have no arrays
+	statement = NewStatement(dialect, tagParser, time.Local)
+	statement.InArray("id", []int64{3})
+	assert.Error(t, statement.LastError)
+}
+
+func TestSetJSON(t *testing.T) {
+	statement := NewStatement(dialect, tagParser, time.Local)
+	statement.SetJSON("attrs", "$.color", "red").
+		SetJSON("meta", "$.n", 1).
+		SetJSON("attrs", "$.size", "M")
+	assert.NoError(t, statement.LastError)
+	assert.Len(t, statement.ExprColumns, 2)
+
+	// the paths of the same column are set by the nested expression
+	sql, args, err := builder.ToSQL(statement.ExprColumns[0].Arg.(builder.Cond))
+	assert.NoError(t, err)
+	assert.EqualValues(t, "json_set(json_set(`attrs`, '$.color', json(?)), '$.size', json(?))", sql)
+	assert.EqualValues(t, []interface{}{`"red"`, `"M"`}, args)
+
+	statement = NewStatement(dialect, tagParser, time.Local)
+	statement.SetJSON("attrs", "color", "red")
+	assert.Error(t, statement.LastError)
+
+	// the expressions of SetExpr are not nested
+	statement = NewStatement(dialect, tagParser, time.Local)
+	statement.SetExpr("attrs", "'{}'").SetJSON("attrs", "$.color", "red")
+	assert.Error(t, statement.LastError)
+
+	statement = NewStatement(dialect, tagParser, time.Local)
+	statement.SetJSON("attrs", "$.color", "red").SetExpr("attrs", "'{}'")
+	assert.Error(t, statement.LastError)
+	assert.Len(t, statement.ExprColumns, 1)
+}
+
 func BenchmarkGetFlagForColumnWithICKey_ContainsKey(b *testing.B) {
 	b.StopTimer()
 
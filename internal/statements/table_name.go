@@ -9,16 +9,17 @@ import (
 	"strings"
 
 	"xorm.io/builder"
+	"xorm.io/xorm/dialects"
 	"xorm.io/xorm/schemas"
 )
 
 // TableName return current tableName
 func (statement *Statement) TableName() string {
 	if statement.AltTableName != "" {
-		return statement.AltTableName
+		return dialects.TableNameInSchema(statement.Schema, statement.AltTableName)
 	}
 
-	return statement.tableName
+	return dialects.TableNameInSchema(statement.Schema, statement.tableName)
 }
 
 // Alias set the table alias
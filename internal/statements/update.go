@@ -266,7 +266,16 @@ func (statement *Statement) BuildUpdates(tableValue reflect.Value,
 				}
 			}
 
-			if col.SQLType.IsText() {
+			if literal, ok, err := pgLiteral(col, fieldValue); ok {
+				if err != nil {
+					return nil, nil, err
+				}
+				if fieldType.Kind() != reflect.Array && fieldValue.IsNil() && col.Nullable {
+					val = nil
+				} else {
+					val = literal
+				}
+			} else if col.SQLType.IsText() {
 				bytes, err := json.DefaultJSONHandler.Marshal(fieldValue.Interface())
 				if err != nil {
 					return nil, nil, err
@@ -142,6 +142,13 @@ func (statement *Statement) Value2Interface(col *schemas.Column, fieldValue refl
 			return fieldValue.Interface(), nil
 		}
 
+		if literal, ok, err := pgLiteral(col, fieldValue); ok {
+			if err != nil || (k != reflect.Array && fieldValue.IsNil() && col.Nullable) {
+				return nil, err
+			}
+			return literal, nil
+		}
+
 		if col.SQLType.IsText() {
 			bytes, err := json.DefaultJSONHandler.Marshal(fieldValue.Interface())
 			if err != nil {
@@ -169,3 +176,16 @@ func (statement *Statement) Value2Interface(col *schemas.Column, fieldValue refl
 		return fieldValue.Interface(), nil
 	}
 }
+
+// pgLiteral returns the literal of a native postgres array or hstore column
+func pgLiteral(col *schemas.Column, fieldValue reflect.Value) (string, bool, error) {
+	switch {
+	case col.SQLType.IsArray():
+		literal, err := dialects.FormatPgArray(fieldValue)
+		return literal, true, err
+	case col.SQLType.Name == schemas.Hstore:
+		literal, err := dialects.FormatHstore(fieldValue)
+		return literal, true, err
+	}
+	return "", false, nil
+}
@@ -12,3 +12,24 @@ import (
 func ReflectValue(bean interface{}) reflect.Value {
 	return reflect.Indirect(reflect.ValueOf(bean))
 }
+
+// LookupTableInterface returns the bean or the pointer of the bean which implements the
+// interface type, it returns nil if neither of them implements the interface
+func LookupTableInterface(v reflect.Value, tp reflect.Type) interface{} {
+	if v.Type().Implements(tp) {
+		return v.Interface()
+	}
+
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+		if v.Type().Implements(tp) {
+			return v.Interface()
+		}
+	} else if v.CanAddr() {
+		v1 := v.Addr()
+		if v1.Type().Implements(tp) {
+			return v1.Interface()
+		}
+	}
+	return nil
+}
@@ -4,7 +4,10 @@
 
 package utils
 
-import "sort"
+import (
+	"sort"
+	"strings"
+)
 
 // SliceEq return true if two slice have the same elements even if different sort.
 func SliceEq(left, right []string) bool {
@@ -30,3 +33,13 @@ func IndexSlice(s []string, c string) int {
 	}
 	return -1
 }
+
+// ContainsFold returns true if s contains c under Unicode case-folding
+func ContainsFold(s []string, c string) bool {
+	for _, ss := range s {
+		if strings.EqualFold(c, ss) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xorm
+
+import (
+	"fmt"
+
+	"xorm.io/builder"
+	"xorm.io/xorm/dialects"
+)
+
+// JSONPathExpr represents the value at a path of a JSON column
+type JSONPathExpr struct {
+	column string
+	path   string
+}
+
+// JSONPath returns the value at the path of the JSON column, like $.color or $.sizes[0],
+// to build the conditions of the dialect, i.e.
+//
+//	engine.Where(xorm.JSONPath("attrs", "$.color").Eq("red")).Find(&products)
+func JSONPath(column, path string) JSONPathExpr {
+	return JSONPathExpr{column: column, path: path}
+}
+
+// Eq generates the condition that the value at the path equals to the value
+func (p JSONPathExpr) Eq(value interface{}) builder.Cond {
+	return jsonPathCond{JSONPathExpr: p, op: "=", value: value}
+}
+
+// Neq generates the condition that the value at the path does not equal to the value
+func (p JSONPathExpr) Neq(value interface{}) builder.Cond {
+	return jsonPathCond{JSONPathExpr: p, op: "<>", value: value}
+}
+
+// Contains generates the condition that the JSON array at the path contains the value
+func (p JSONPathExpr) Contains(value interface{}) builder.Cond {
+	return jsonPathCond{JSONPathExpr: p, op: "contains", value: value}
+}
+
+type jsonPathCond struct {
+	JSONPathExpr
+	op    string
+	value interface{}
+}
+
+var _ builder.Cond = jsonPathCond{}
+
+// WriteTo implements builder.Cond, the dialect is got from the writer of the session
+func (cond jsonPathCond) WriteTo(w builder.Writer) error {
+	dw, ok := w.(interface{ Dialect() dialects.Dialect })
+	if !ok {
+		return ErrJSONPathDialect
+	}
+
+	var (
+		sql  string
+		args []interface{}
+		err  error
+	)
+	if cond.op == "contains" {
+		sql, args, err = dialects.JSONContainsSQL(dw.Dialect(), cond.column, cond.path, cond.value)
+	} else {
+		sql, args, err = dialects.JSONCompareSQL(dw.Dialect(), cond.column, cond.path, cond.op, cond.value)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprint(w, sql); err != nil {
+		return err
+	}
+	w.Append(args...)
+	return nil
+}
+
+// And implements builder.Cond
+func (cond jsonPathCond) And(conds ...builder.Cond) builder.Cond {
+	return builder.And(cond, builder.And(conds...))
+}
+
+// Or implements builder.Cond
+func (cond jsonPathCond) Or(conds ...builder.Cond) builder.Cond {
+	return builder.Or(cond, builder.Or(conds...))
+}
+
+// IsValid implements builder.Cond
+func (cond jsonPathCond) IsValid() bool {
+	return len(cond.column) > 0 && len(cond.path) > 0
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.16
+// +build go1.16
+
+package migrate
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// FromFS loads the SQL migrations from the directory of the file system, i.e. an embed.FS.
+// The files are named like 001_init.up.sql and 001_init.down.sql, where 001 is the ID of
+// the migration, and the variant for a database like 001_init.postgres.up.sql will be
+// used instead of the default one on that database.
+func FromFS(fsys fs.FS, dir string) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make(sqlMigrations)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if err := migrations.addFile(entry.Name(), string(content)); err != nil {
+			return nil, err
+		}
+	}
+	return migrations.toMigrations()
+}
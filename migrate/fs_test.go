@@ -0,0 +1,59 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.16
+// +build go1.16
+
+package migrate
+
+import (
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"xorm.io/xorm"
+)
+
+func TestFromFS(t *testing.T) {
+	_ = os.Remove(dbName)
+
+	db, err := xorm.NewEngine("sqlite3", dbName)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	fsys := fstest.MapFS{
+		"migrations/201608301400_person.up.sql":         {Data: []byte("CREATE TABLE person (id INTEGER PRIMARY KEY, name TEXT);")},
+		"migrations/201608301400_person.down.sql":       {Data: []byte("DROP TABLE person;")},
+		"migrations/201608301500_pet.up.sql":            {Data: []byte("CREATE TABLE pet (id INTEGER PRIMARY KEY, name TEXT);\nINSERT INTO pet (name) VALUES ('a;b');")},
+		"migrations/201608301500_pet.postgres.up.sql":   {Data: []byte("CREATE TABLE pet (id SERIAL PRIMARY KEY, name TEXT);")},
+		"migrations/201608301500_pet.down.sql":          {Data: []byte("DROP TABLE pet;")},
+		"migrations/README.md":                          {Data: []byte("migrations")},
+		"migrations/201608301600_ignored/nested.up.sql": {Data: []byte("SELECT 1;")},
+	}
+	sqlMigrations, err := FromFS(fsys, "migrations")
+	assert.NoError(t, err)
+	assert.Len(t, sqlMigrations, 2)
+
+	// the SQL migrations are mixed with the Go migrations by IDs
+	m := New(db, DefaultOptions, append(sqlMigrations, &Migration{
+		ID: "201608301430",
+		Migrate: func(tx *xorm.Session) error {
+			_, err := tx.Exec("INSERT INTO person (name) VALUES ('a')")
+			return err
+		},
+	}))
+	assert.NoError(t, m.Migrate())
+	assert.Equal(t, 3, tableCount(db, "migrations"))
+	assert.Equal(t, 1, tableCount(db, "person"))
+	assert.Equal(t, 1, tableCount(db, "pet"))
+
+	assert.NoError(t, m.RollbackLast())
+	exist, err := db.IsTableExist("pet")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+
+	_, err = FromFS(fstest.MapFS{"migrations/init.sql": {Data: []byte("SELECT 1;")}}, "migrations")
+	assert.Error(t, err)
+}
@@ -0,0 +1,226 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
+
+	"xorm.io/xorm"
+	"xorm.io/xorm/convert"
+	"xorm.io/xorm/internal/utils"
+	"xorm.io/xorm/schemas"
+)
+
+// enumerates the states of the migrations
+const (
+	StateApplied  = "applied"  // the migration ran
+	StatePending  = "pending"  // the migration did not run yet
+	StateModified = "modified" // the migration was modified after it ran
+	StateUnknown  = "unknown"  // the migration ran but it's not defined
+)
+
+const xormPath = "xorm.io/xorm"
+
+// MigrationStatus represents the state and the history of a migration
+type MigrationStatus struct {
+	ID        string
+	State     string
+	AppliedAt time.Time
+	Duration  time.Duration
+	Checksum  string // the checksum when the migration ran
+	Version   string // the version of xorm when the migration ran
+}
+
+// historyColumns returns the columns of the migration table except the ID column
+func historyColumns() []*schemas.Column {
+	return []*schemas.Column{
+		schemas.NewColumn("applied_at", "", schemas.SQLType{Name: schemas.DateTime}, 0, 0, true),
+		schemas.NewColumn("duration_ms", "", schemas.SQLType{Name: schemas.BigInt}, 0, 0, true),
+		schemas.NewColumn("checksum", "", schemas.SQLType{Name: schemas.Varchar}, 64, 0, true),
+		schemas.NewColumn("xorm_version", "", schemas.SQLType{Name: schemas.Varchar}, 64, 0, true),
+	}
+}
+
+// Status returns the states of the defined migrations in order, and the migrations
+// which ran but not defined are listed at the end.
+func (m *Migrate) Status() ([]*MigrationStatus, error) {
+	exists, err := m.db.IsTableExist(m.options.TableName)
+	if err != nil {
+		return nil, err
+	}
+	var history = make(map[string]*MigrationStatus)
+	if exists {
+		if history, err = m.loadHistory(); err != nil {
+			return nil, err
+		}
+	}
+
+	var results = make([]*MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status, ok := history[migration.ID]
+		if !ok {
+			results = append(results, &MigrationStatus{ID: migration.ID, State: StatePending})
+			continue
+		}
+		delete(history, migration.ID)
+		if isModified(migration, status) {
+			status.State = StateModified
+		} else {
+			status.State = StateApplied
+		}
+		results = append(results, status)
+	}
+
+	var unknowns = make([]*MigrationStatus, 0, len(history))
+	for _, status := range history {
+		status.State = StateUnknown
+		unknowns = append(unknowns, status)
+	}
+	sort.Slice(unknowns, func(i, j int) bool {
+		return lessID(unknowns[i].ID, unknowns[j].ID)
+	})
+	return append(results, unknowns...), nil
+}
+
+func isModified(migration *Migration, status *MigrationStatus) bool {
+	return migration.Checksum != "" && status.Checksum != "" && migration.Checksum != status.Checksum
+}
+
+// checkChecksums returns an error if any applied migration was modified
+func (m *Migrate) checkChecksums(history map[string]*MigrationStatus) error {
+	if m.options.IgnoreChecksums {
+		return nil
+	}
+	var modified []string
+	for _, migration := range m.migrations {
+		if status, ok := history[migration.ID]; ok && isModified(migration, status) {
+			modified = append(modified, migration.ID)
+		}
+	}
+	if len(modified) > 0 {
+		return fmt.Errorf("%w: %s", ErrChecksumMismatch, strings.Join(modified, ", "))
+	}
+	return nil
+}
+
+// loadHistory reads the migration table, the columns of the history may be NULL if
+// the migrations ran before the columns were added, and NULL is read instead of the
+// columns which are not added yet to the migration table created by the old versions.
+func (m *Migrate) loadHistory() (map[string]*MigrationStatus, error) {
+	colNames, _, err := m.db.Dialect().GetColumns(m.db.DB(), context.Background(), m.options.TableName)
+	if err != nil {
+		return nil, err
+	}
+	var selects = []string{m.options.IDColumnName}
+	for _, col := range historyColumns() {
+		if utils.ContainsFold(colNames, col.Name) {
+			selects = append(selects, col.Name)
+		} else {
+			selects = append(selects, "NULL")
+		}
+	}
+
+	rows, err := m.db.DB().Query(fmt.Sprintf("SELECT %s FROM %s", strings.Join(selects, ", "), m.options.TableName))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history = make(map[string]*MigrationStatus)
+	for rows.Next() {
+		var (
+			id        string
+			appliedAt interface{}
+			duration  sql.NullInt64
+			checksum  sql.NullString
+			version   sql.NullString
+		)
+		if err := rows.Scan(&id, &appliedAt, &duration, &checksum, &version); err != nil {
+			return nil, err
+		}
+		status := &MigrationStatus{
+			ID:       id,
+			Duration: time.Duration(duration.Int64) * time.Millisecond,
+			Checksum: checksum.String,
+			Version:  version.String,
+		}
+		if appliedAt != nil {
+			t, err := convert.AsTime(appliedAt, time.UTC, time.Local)
+			if err != nil {
+				return nil, err
+			}
+			status.AppliedAt = *t
+		}
+		history[id] = status
+	}
+	return history, rows.Err()
+}
+
+// createMigrationTableIfNotExists creates the migration table, or adds the history
+// columns to the migration table created by the old versions.
+func (m *Migrate) createMigrationTableIfNotExists() error {
+	exists, err := m.db.IsTableExist(m.options.TableName)
+	if err != nil {
+		return err
+	}
+	dialect := m.db.Dialect()
+	if exists {
+		colNames, _, err := dialect.GetColumns(m.db.DB(), context.Background(), m.options.TableName)
+		if err != nil {
+			return err
+		}
+		for _, col := range historyColumns() {
+			if utils.ContainsFold(colNames, col.Name) {
+				continue
+			}
+			if _, err := m.db.Exec(dialect.AddColumnSQL(m.options.TableName, col)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var colDefs = []string{m.options.IDColumnName + " VARCHAR(255) PRIMARY KEY"}
+	for _, col := range historyColumns() {
+		colDefs = append(colDefs, col.Name+" "+dialect.SQLType(col))
+	}
+	sql := fmt.Sprintf("CREATE TABLE %s (%s)", m.options.TableName, strings.Join(colDefs, ", "))
+	if _, err := m.db.Exec(sql); err != nil {
+		return err
+	}
+	return nil
+}
+
+// insertMigration records the migration which started at the time
+func (m *Migrate) insertMigration(session *xorm.Session, migration *Migration, start time.Time) error {
+	sql := fmt.Sprintf("INSERT INTO %s (%s, applied_at, duration_ms, checksum, xorm_version) VALUES (?, ?, ?, ?, ?)",
+		m.options.TableName, m.options.IDColumnName)
+	_, err := session.Exec(sql, migration.ID, start.UTC(), int64(time.Since(start)/time.Millisecond),
+		migration.Checksum, xormVersion())
+	return err
+}
+
+// xormVersion returns the version of the xorm module which the program is built with
+func xormVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	if info.Main.Path == xormPath {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == xormPath {
+			return dep.Version
+		}
+	}
+	return ""
+}
@@ -0,0 +1,231 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"time"
+
+	"xorm.io/xorm/schemas"
+)
+
+const (
+	// DefaultLockTimeout is the time to wait for the migration lock if no LockTimeout is set
+	DefaultLockTimeout = time.Minute
+
+	lockRetryInterval = 200 * time.Millisecond
+	// lockGracePeriod is the extra time for the databases waiting for the lock by themselves
+	lockGracePeriod = 5 * time.Second
+)
+
+// unlockFunc releases the migration lock
+type unlockFunc func() error
+
+// lock acquires a cross-process lock so that only one process runs the migrations at the
+// same time. The session level locks of postgres, mysql and mssql are held by a dedicated
+// connection, and a row of the lock table is inserted as the lock for other databases.
+func (m *Migrate) lock() (unlockFunc, error) {
+	timeout := m.options.LockTimeout
+	if timeout <= 0 {
+		timeout = DefaultLockTimeout
+	}
+	lockName := "xorm_migrate_" + m.options.TableName
+
+	var unlock unlockFunc
+	var err error
+	switch m.db.Dialect().URI().DBType {
+	case schemas.POSTGRES:
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(lockName))
+		key := int64(h.Sum64())
+		unlock, err = m.connLock(timeout, func(ctx context.Context, conn *sql.Conn) (bool, error) {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			return pollLock(ctx, func() (bool, error) {
+				var locked bool
+				err := conn.QueryRowContext(ctx, fmt.Sprintf("SELECT pg_try_advisory_lock(%d)", key)).Scan(&locked)
+				return locked, err
+			})
+		}, fmt.Sprintf("SELECT pg_advisory_unlock(%d)", key))
+	case schemas.MYSQL:
+		unlock, err = m.connLock(timeout, func(ctx context.Context, conn *sql.Conn) (bool, error) {
+			var locked sql.NullInt64
+			err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)",
+				lockName, int(math.Ceil(timeout.Seconds()))).Scan(&locked)
+			return locked.Valid && locked.Int64 == 1, err
+		}, "SELECT RELEASE_LOCK(?)", lockName)
+	case schemas.MSSQL:
+		quotedLockName := strings.ReplaceAll(lockName, "'", "''")
+		unlock, err = m.connLock(timeout, func(ctx context.Context, conn *sql.Conn) (bool, error) {
+			var result int
+			err := conn.QueryRowContext(ctx, fmt.Sprintf(`DECLARE @result INT;
+EXEC @result = sp_getapplock @Resource = '%s', @LockMode = 'Exclusive', @LockOwner = 'Session', @LockTimeout = %d;
+SELECT @result`, quotedLockName, timeout.Milliseconds())).Scan(&result)
+			return result >= 0, err
+		}, fmt.Sprintf("EXEC sp_releaseapplock @Resource = '%s', @LockOwner = 'Session'", quotedLockName))
+	default:
+		unlock, err = m.rowLock(timeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if unlock == nil {
+		return nil, fmt.Errorf("%w %s in %v", ErrLockTimeout, lockName, timeout)
+	}
+	return unlock, nil
+}
+
+// withLock runs the function with the migration lock
+func (m *Migrate) withLock(fn func() error) (err error) {
+	unlock, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if unlockErr := unlock(); err == nil {
+			err = unlockErr
+		}
+	}()
+	return fn()
+}
+
+// connLock acquires the lock on a dedicated connection which will be closed after unlocking,
+// it returns a nil unlockFunc if the lock cannot be acquired in time. The migrations run on
+// the other connections of the pool, so it fails instead of deadlocking when the pool could
+// open only one connection.
+func (m *Migrate) connLock(timeout time.Duration, acquire func(context.Context, *sql.Conn) (bool, error), unlockSQL string, unlockArgs ...interface{}) (unlockFunc, error) {
+	if m.db.DB().Stats().MaxOpenConnections == 1 {
+		return nil, ErrLockSingleConnection
+	}
+
+	conn, err := m.db.DB().Conn(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout+lockGracePeriod)
+	defer cancel()
+	locked, err := acquire(ctx, conn)
+	if err != nil || !locked {
+		conn.Close()
+		if ctx.Err() != nil {
+			err = nil
+		}
+		return nil, err
+	}
+
+	return func() error {
+		defer conn.Close()
+		_, err := conn.ExecContext(context.Background(), unlockSQL, unlockArgs...)
+		return err
+	}, nil
+}
+
+// rowLock acquires the lock by inserting a row to the lock table, the primary key makes
+// sure only one row could be inserted. The row will be left if the process is killed while
+// migrating, then it will be taken over once it's older than LockStaleTimeout or it should
+// be deleted by ForceUnlock.
+func (m *Migrate) rowLock(timeout time.Duration) (unlockFunc, error) {
+	lockTable := m.lockTableName()
+	exists, err := m.db.IsTableExist(lockTable)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		query := fmt.Sprintf("CREATE TABLE %s (id INTEGER PRIMARY KEY, locked_at VARCHAR(64))", lockTable)
+		if _, err := m.db.Exec(query); err != nil {
+			// the table may be created by another process at the same time
+			if exists, _ = m.db.IsTableExist(lockTable); !exists {
+				return nil, err
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	locked, err := pollLock(ctx, func() (bool, error) {
+		query := fmt.Sprintf("INSERT INTO %s (id, locked_at) VALUES (1, ?)", lockTable)
+		_, err := m.db.Exec(query, time.Now().UTC().Format(time.RFC3339))
+		if err == nil {
+			return true, nil
+		}
+		// a failed insert means the row exists unless the row cannot be found
+		lockedAts, err2 := m.db.QueryString(fmt.Sprintf("SELECT locked_at FROM %s WHERE id = 1", lockTable))
+		if err2 != nil {
+			return false, err2
+		} else if len(lockedAts) == 0 {
+			return false, err
+		}
+
+		// the stale row is deleted only if it's not taken over by another process
+		lockedAt := lockedAts[0]["locked_at"]
+		if m.options.LockStaleTimeout > 0 {
+			if t, err := time.Parse(time.RFC3339, lockedAt); err == nil && time.Since(t) > m.options.LockStaleTimeout {
+				query := fmt.Sprintf("DELETE FROM %s WHERE id = 1 AND locked_at = ?", lockTable)
+				if _, err := m.db.Exec(query, lockedAt); err != nil {
+					return false, err
+				}
+			}
+		}
+		return false, nil
+	})
+	if err != nil || !locked {
+		return nil, err
+	}
+
+	return func() error {
+		_, err := m.db.Exec(fmt.Sprintf("DELETE FROM %s WHERE id = 1", lockTable))
+		return err
+	}, nil
+}
+
+// ForceUnlock releases the migration lock which is left by a killed process. The session
+// level locks of postgres, mysql and mssql are released with the connections, so it only
+// deletes the row of the lock table for other databases.
+func (m *Migrate) ForceUnlock() error {
+	switch m.db.Dialect().URI().DBType {
+	case schemas.POSTGRES, schemas.MYSQL, schemas.MSSQL:
+		return nil
+	}
+	lockTable := m.lockTableName()
+	exists, err := m.db.IsTableExist(lockTable)
+	if err != nil || !exists {
+		return err
+	}
+	_, err = m.db.Exec(fmt.Sprintf("DELETE FROM %s WHERE id = 1", lockTable))
+	return err
+}
+
+func (m *Migrate) lockTableName() string {
+	return m.options.TableName + "_lock"
+}
+
+// pollLock tries to acquire the lock until it's acquired or the context is done
+func pollLock(ctx context.Context, try func() (bool, error)) (bool, error) {
+	for {
+		locked, err := try()
+		if locked {
+			return true, nil
+		}
+		// the error may be caused by the done context
+		if ctx.Err() != nil {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, nil
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
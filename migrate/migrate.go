@@ -3,18 +3,21 @@ package migrate
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"xorm.io/xorm"
+	"xorm.io/xorm/schemas"
 )
 
-// MigrateFunc is the func signature for migrating.
-type MigrateFunc func(*xorm.Engine) error
+// MigrateFunc is the func signature for migrating. The session is in a transaction
+// if the database supports transactional DDL, see Migration.NoTransaction.
+type MigrateFunc func(*xorm.Session) error
 
 // RollbackFunc is the func signature for rollbacking.
-type RollbackFunc func(*xorm.Engine) error
+type RollbackFunc func(*xorm.Session) error
 
 // InitSchemaFunc is the func signature for initializing the schemas.
-type InitSchemaFunc func(*xorm.Engine) error
+type InitSchemaFunc func(*xorm.Session) error
 
 // Options define options for all migrations.
 type Options struct {
@@ -22,6 +25,15 @@ type Options struct {
 	TableName string
 	// IDColumnName is the name of column where the migration id will be stored.
 	IDColumnName string
+	// LockTimeout is the time to wait for the lock which prevents other processes from
+	// migrating at the same time, default is DefaultLockTimeout.
+	LockTimeout time.Duration
+	// LockStaleTimeout takes over the lock which has been held longer than it, it's only
+	// used by the databases locked by a row of the lock table, zero means the lock will
+	// never be taken over and it should be released by ForceUnlock.
+	LockStaleTimeout time.Duration
+	// IgnoreChecksums runs the migrations even if the applied migrations were modified.
+	IgnoreChecksums bool
 }
 
 // Migration represents a database migration (a modification to be made on the database).
@@ -32,6 +44,14 @@ type Migration struct {
 	Migrate MigrateFunc
 	// Rollback will be executed on rollback. Can be nil.
 	Rollback RollbackFunc
+	// Checksum is recorded when the migration runs to detect the modification after
+	// that. The checksums of the migrations loaded from files are calculated from the
+	// contents. Can be empty.
+	Checksum string
+	// NoTransaction runs the migration outside a transaction, i.e. CREATE INDEX CONCURRENTLY
+	// of postgres cannot run inside a transaction. Otherwise the migration and its history
+	// record run in the same transaction on postgres, sqlite3 and mssql.
+	NoTransaction bool
 }
 
 // Migrate represents a collection of all migrations of a database schemas.
@@ -62,10 +82,30 @@ var (
 	// ErrNoRunnedMigration is returned when any runned migration was found while
 	// running RollbackLast
 	ErrNoRunnedMigration = errors.New("Could not find last runned migration")
+
+	// ErrLockTimeout is returned when the migration lock cannot be acquired in time
+	// because another process is migrating.
+	ErrLockTimeout = errors.New("Could not acquire the migration lock")
+
+	// ErrLockSingleConnection is returned when the migration lock of postgres, mysql or mssql
+	// is acquired by an engine which could open only one connection.
+	ErrLockSingleConnection = errors.New("Could not hold the migration lock with only one connection")
+
+	// ErrMigrationNotFound is returned when the migration of the ID is not defined.
+	ErrMigrationNotFound = errors.New("Could not find the migration")
+
+	// ErrChecksumMismatch is returned when the checksum of an applied migration is
+	// different with the recorded one, which means the migration was modified.
+	ErrChecksumMismatch = errors.New("Migration was modified after applied")
 )
 
-// New returns a new Gormigrate.
+// New returns a new Gormigrate. The migrations are ordered by IDs so that the
+// migrations loaded from files could be mixed with the Go ones. On postgres, mysql
+// and mssql the migration lock holds a connection while the migrations run on the
+// others, so the engine should be able to open at least two connections.
 func New(db *xorm.Engine, options *Options, migrations []*Migration) *Migrate {
+	migrations = append([]*Migration(nil), migrations...)
+	sortMigrations(migrations)
 	return &Migrate{
 		db:         db,
 		options:    options,
@@ -83,16 +123,43 @@ func (m *Migrate) InitSchema(initSchema InitSchemaFunc) {
 
 // Migrate executes all migrations that did not run yet.
 func (m *Migrate) Migrate() error {
-	if err := m.createMigrationTableIfNotExists(); err != nil {
-		return err
+	return m.withLock(func() error {
+		if err := m.createMigrationTableIfNotExists(); err != nil {
+			return err
+		}
+
+		if m.initSchema != nil && m.isFirstRun() {
+			return m.runInitSchema()
+		}
+		return m.migrateTo(len(m.migrations) - 1)
+	})
+}
+
+// MigrateTo executes the migrations that did not run yet until the migration of the ID.
+func (m *Migrate) MigrateTo(id string) error {
+	idx := m.indexOf(id)
+	if idx < 0 {
+		return fmt.Errorf("%w: %s", ErrMigrationNotFound, id)
 	}
+	return m.withLock(func() error {
+		if err := m.createMigrationTableIfNotExists(); err != nil {
+			return err
+		}
+		return m.migrateTo(idx)
+	})
+}
 
-	if m.initSchema != nil && m.isFirstRun() {
-		return m.runInitSchema()
+func (m *Migrate) migrateTo(idx int) error {
+	history, err := m.loadHistory()
+	if err != nil {
+		return err
+	}
+	if err := m.checkChecksums(history); err != nil {
+		return err
 	}
 
-	for _, migration := range m.migrations {
-		if err := m.runMigration(migration); err != nil {
+	for _, migration := range m.migrations[:idx+1] {
+		if err := m.runMigration(migration, history); err != nil {
 			return err
 		}
 	}
@@ -105,12 +172,48 @@ func (m *Migrate) RollbackLast() error {
 		return ErrNoMigrationDefined
 	}
 
-	lastRunnedMigration, err := m.getLastRunnedMigration()
-	if err != nil {
-		return err
+	return m.withLock(func() error {
+		lastRunnedMigration, err := m.getLastRunnedMigration()
+		if err != nil {
+			return err
+		}
+
+		return m.rollbackMigration(lastRunnedMigration)
+	})
+}
+
+// RollbackTo undo the migrations which ran after the migration of the ID, and the
+// migration of the ID will be the last runned migration.
+func (m *Migrate) RollbackTo(id string) error {
+	idx := m.indexOf(id)
+	if idx < 0 {
+		return fmt.Errorf("%w: %s", ErrMigrationNotFound, id)
 	}
 
-	return m.RollbackMigration(lastRunnedMigration)
+	return m.withLock(func() error {
+		history, err := m.loadHistory()
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i > idx; i-- {
+			if _, ok := history[m.migrations[i].ID]; !ok {
+				continue
+			}
+			if err := m.rollbackMigration(m.migrations[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (m *Migrate) indexOf(id string) int {
+	for i, migration := range m.migrations {
+		if migration.ID == id {
+			return i
+		}
+	}
+	return -1
 }
 
 func (m *Migrate) getLastRunnedMigration() (*Migration, error) {
@@ -128,71 +231,86 @@ func (m *Migrate) getLastRunnedMigration() (*Migration, error) {
 
 // RollbackMigration undo a migration.
 func (m *Migrate) RollbackMigration(mig *Migration) error {
+	return m.withLock(func() error {
+		return m.rollbackMigration(mig)
+	})
+}
+
+func (m *Migrate) rollbackMigration(mig *Migration) error {
 	if mig.Rollback == nil {
 		return ErrRollbackImpossible
 	}
 
-	if err := mig.Rollback(m.db); err != nil {
-		return err
-	}
+	return m.runInSession(mig.NoTransaction, func(session *xorm.Session) error {
+		if err := mig.Rollback(session); err != nil {
+			return err
+		}
 
-	sql := fmt.Sprintf("DELETE FROM %s WHERE %s = ?", m.options.TableName, m.options.IDColumnName)
-	if _, err := m.db.Exec(sql, mig.ID); err != nil {
-		return err
-	}
-	return nil
+		sql := fmt.Sprintf("DELETE FROM %s WHERE %s = ?", m.options.TableName, m.options.IDColumnName)
+		if _, err := session.Exec(sql, mig.ID); err != nil {
+			return err
+		}
+		return nil
+	})
 }
 
 func (m *Migrate) runInitSchema() error {
-	if err := m.initSchema(m.db); err != nil {
-		return err
-	}
-
-	for _, migration := range m.migrations {
-		if err := m.insertMigration(migration.ID); err != nil {
+	return m.runInSession(false, func(session *xorm.Session) error {
+		start := time.Now()
+		if err := m.initSchema(session); err != nil {
 			return err
 		}
-	}
 
-	return nil
+		for _, migration := range m.migrations {
+			if err := m.insertMigration(session, migration, start); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
-func (m *Migrate) runMigration(migration *Migration) error {
+func (m *Migrate) runMigration(migration *Migration, history map[string]*MigrationStatus) error {
 	if len(migration.ID) == 0 {
 		return ErrMissingID
 	}
 
-	run, err := m.migrationDidRun(migration)
-	if err != nil {
-		return err
+	if _, ok := history[migration.ID]; ok {
+		return nil
 	}
 
-	if !run {
-		if err := migration.Migrate(m.db); err != nil {
+	return m.runInSession(migration.NoTransaction, func(session *xorm.Session) error {
+		start := time.Now()
+		if err := migration.Migrate(session); err != nil {
 			return err
 		}
+		return m.insertMigration(session, migration, start)
+	})
+}
 
-		if err := m.insertMigration(migration.ID); err != nil {
+// runInSession runs the function in a transaction if the database supports transactional
+// DDL and noTransaction is false, the transaction will be rolled back if any error occurs.
+func (m *Migrate) runInSession(noTransaction bool, fn func(*xorm.Session) error) error {
+	session := m.db.NewSession()
+	defer session.Close()
+
+	if !noTransaction && supportsTransactionalDDL(m.db.Dialect().URI().DBType) {
+		if err := session.Begin(); err != nil {
 			return err
 		}
 	}
-	return nil
-}
-
-func (m *Migrate) createMigrationTableIfNotExists() error {
-	exists, err := m.db.IsTableExist(m.options.TableName)
-	if err != nil {
+	if err := fn(session); err != nil {
 		return err
 	}
-	if exists {
-		return nil
-	}
+	return session.Commit()
+}
 
-	sql := fmt.Sprintf("CREATE TABLE %s (%s VARCHAR(255) PRIMARY KEY)", m.options.TableName, m.options.IDColumnName)
-	if _, err := m.db.Exec(sql); err != nil {
-		return err
+func supportsTransactionalDDL(dbType schemas.DBType) bool {
+	switch dbType {
+	case schemas.POSTGRES, schemas.SQLITE, schemas.MSSQL:
+		return true
 	}
-	return nil
+	return false
 }
 
 func (m *Migrate) migrationDidRun(mig *Migration) (bool, error) {
@@ -206,9 +324,3 @@ func (m *Migrate) isFirstRun() bool {
 	_ = row.Scan(&count)
 	return count == 0
 }
-
-func (m *Migrate) insertMigration(id string) error {
-	sql := fmt.Sprintf("INSERT INTO %s (%s) VALUES (?)", m.options.TableName, m.options.IDColumnName)
-	_, err := m.db.Exec(sql, id)
-	return err
-}
@@ -1,10 +1,14 @@
 package migrate
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
@@ -30,20 +34,20 @@ var (
 	migrations = []*Migration{
 		{
 			ID: "201608301400",
-			Migrate: func(tx *xorm.Engine) error {
+			Migrate: func(tx *xorm.Session) error {
 				return tx.Sync(&Person{})
 			},
-			Rollback: func(tx *xorm.Engine) error {
-				return tx.DropTables(&Person{})
+			Rollback: func(tx *xorm.Session) error {
+				return tx.DropTable(&Person{})
 			},
 		},
 		{
 			ID: "201608301430",
-			Migrate: func(tx *xorm.Engine) error {
+			Migrate: func(tx *xorm.Session) error {
 				return tx.Sync(&Pet{})
 			},
-			Rollback: func(tx *xorm.Engine) error {
-				return tx.DropTables(&Pet{})
+			Rollback: func(tx *xorm.Session) error {
+				return tx.DropTable(&Pet{})
 			},
 		},
 	}
@@ -102,7 +106,7 @@ func TestInitSchema(t *testing.T) {
 	}
 
 	m := New(db, DefaultOptions, migrations)
-	m.InitSchema(func(tx *xorm.Engine) error {
+	m.InitSchema(func(tx *xorm.Session) error {
 		if err := tx.Sync(&Person{}); err != nil {
 			return err
 		}
@@ -130,7 +134,7 @@ func TestMissingID(t *testing.T) {
 
 	migrationsMissingID := []*Migration{
 		{
-			Migrate: func(tx *xorm.Engine) error {
+			Migrate: func(tx *xorm.Session) error {
 				return nil
 			},
 		},
@@ -145,3 +149,212 @@ func tableCount(db *xorm.Engine, tableName string) (count int) {
 	_ = row.Scan(&count)
 	return
 }
+
+func TestMigrationLock(t *testing.T) {
+	os.Remove(dbName)
+
+	db, err := xorm.NewEngine("sqlite3", dbName)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	m := New(db, &Options{
+		TableName:    "migrations",
+		IDColumnName: "id",
+		LockTimeout:  500 * time.Millisecond,
+	}, migrations)
+
+	// another process is migrating
+	unlock, err := m.lock()
+	assert.NoError(t, err)
+	err = m.Migrate()
+	assert.True(t, errors.Is(err, ErrLockTimeout))
+	assert.Equal(t, 0, tableCount(db, "migrations"))
+
+	assert.NoError(t, unlock())
+	assert.NoError(t, m.Migrate())
+	assert.Equal(t, 2, tableCount(db, "migrations"))
+	assert.Equal(t, 0, tableCount(db, "migrations_lock"))
+}
+
+func TestMigrationLockSingleConnection(t *testing.T) {
+	os.Remove(dbName)
+
+	db, err := xorm.NewEngine("sqlite3", dbName)
+	assert.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	m := New(db, DefaultOptions, migrations)
+
+	// the lock held by a connection would block the migrations forever
+	_, err = m.connLock(time.Second, func(ctx context.Context, conn *sql.Conn) (bool, error) {
+		return true, nil
+	}, "SELECT 1")
+	assert.True(t, errors.Is(err, ErrLockSingleConnection))
+
+	// the lock of the table row doesn't hold a connection
+	assert.NoError(t, m.Migrate())
+	assert.Equal(t, 2, tableCount(db, "migrations"))
+	assert.Equal(t, 0, tableCount(db, "migrations_lock"))
+}
+
+func TestMigrationStaleLock(t *testing.T) {
+	os.Remove(dbName)
+
+	db, err := xorm.NewEngine("sqlite3", dbName)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	options := &Options{
+		TableName:    "migrations",
+		IDColumnName: "id",
+		LockTimeout:  500 * time.Millisecond,
+	}
+	m := New(db, options, migrations)
+
+	// the process holding the lock has been killed
+	_, err = m.lock()
+	assert.NoError(t, err)
+	_, err = db.Exec("UPDATE migrations_lock SET locked_at = ?", time.Now().Add(-time.Hour).UTC().Format(time.RFC3339))
+	assert.NoError(t, err)
+	err = m.Migrate()
+	assert.True(t, errors.Is(err, ErrLockTimeout))
+
+	options.LockStaleTimeout = time.Minute
+	assert.NoError(t, m.Migrate())
+	assert.Equal(t, 2, tableCount(db, "migrations"))
+	assert.Equal(t, 0, tableCount(db, "migrations_lock"))
+
+	_, err = m.lock()
+	assert.NoError(t, err)
+	assert.NoError(t, m.ForceUnlock())
+	assert.Equal(t, 0, tableCount(db, "migrations_lock"))
+}
+
+func TestMigrationHistory(t *testing.T) {
+	os.Remove(dbName)
+
+	db, err := xorm.NewEngine("sqlite3", dbName)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// the migration table created by the old versions has only the ID column
+	_, err = db.Exec("CREATE TABLE migrations (id VARCHAR(255) PRIMARY KEY)")
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT INTO migrations (id) VALUES ('201608301300')")
+	assert.NoError(t, err)
+
+	var historyMigrations = []*Migration{
+		{
+			ID:       "201608301400",
+			Checksum: "a",
+			Migrate: func(tx *xorm.Session) error {
+				return tx.Sync(&Person{})
+			},
+			Rollback: func(tx *xorm.Session) error {
+				return tx.DropTable(&Person{})
+			},
+		},
+		{
+			ID:       "201608301430",
+			Checksum: "b",
+			Migrate: func(tx *xorm.Session) error {
+				return tx.Sync(&Pet{})
+			},
+			Rollback: func(tx *xorm.Session) error {
+				return tx.DropTable(&Pet{})
+			},
+		},
+	}
+	m := New(db, DefaultOptions, historyMigrations)
+
+	// the status is read before the history columns are added
+	statuses, err := m.Status()
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 3)
+	assert.EqualValues(t, StatePending, statuses[0].State)
+	assert.EqualValues(t, "201608301300", statuses[2].ID)
+	assert.EqualValues(t, StateUnknown, statuses[2].State)
+	assert.True(t, statuses[2].AppliedAt.IsZero())
+
+	assert.True(t, errors.Is(m.MigrateTo("201608301500"), ErrMigrationNotFound))
+	assert.NoError(t, m.MigrateTo("201608301400"))
+
+	statuses, err = m.Status()
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 3)
+	assert.EqualValues(t, "201608301400", statuses[0].ID)
+	assert.EqualValues(t, StateApplied, statuses[0].State)
+	assert.EqualValues(t, "a", statuses[0].Checksum)
+	assert.EqualValues(t, xormVersion(), statuses[0].Version)
+	assert.False(t, statuses[0].AppliedAt.IsZero())
+	assert.EqualValues(t, StatePending, statuses[1].State)
+	assert.EqualValues(t, "201608301300", statuses[2].ID)
+	assert.EqualValues(t, StateUnknown, statuses[2].State)
+
+	// the applied migration was modified
+	historyMigrations[0].Checksum = "c"
+	m = New(db, DefaultOptions, historyMigrations)
+	statuses, err = m.Status()
+	assert.NoError(t, err)
+	assert.EqualValues(t, StateModified, statuses[0].State)
+	assert.True(t, errors.Is(m.Migrate(), ErrChecksumMismatch))
+	assert.Equal(t, 2, tableCount(db, "migrations"))
+
+	m = New(db, &Options{
+		TableName:       "migrations",
+		IDColumnName:    "id",
+		IgnoreChecksums: true,
+	}, historyMigrations)
+	assert.NoError(t, m.Migrate())
+	assert.Equal(t, 3, tableCount(db, "migrations"))
+
+	assert.NoError(t, m.RollbackTo("201608301400"))
+	exists, _ := db.IsTableExist(&Pet{})
+	assert.False(t, exists)
+	exists, _ = db.IsTableExist(&Person{})
+	assert.True(t, exists)
+	assert.Equal(t, 2, tableCount(db, "migrations"))
+}
+
+func TestTransactionalMigration(t *testing.T) {
+	os.Remove(dbName)
+
+	db, err := xorm.NewEngine("sqlite3", dbName)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	failed := errors.New("failed")
+	m := New(db, DefaultOptions, []*Migration{
+		{
+			ID: "201608301400",
+			Migrate: func(tx *xorm.Session) error {
+				if err := tx.Sync(&Person{}); err != nil {
+					return err
+				}
+				return failed
+			},
+		},
+	})
+	assert.Equal(t, failed, m.Migrate())
+	exists, _ := db.IsTableExist(&Person{})
+	assert.False(t, exists)
+	assert.Equal(t, 0, tableCount(db, "migrations"))
+
+	m = New(db, DefaultOptions, []*Migration{
+		{
+			ID:            "201608301400",
+			NoTransaction: true,
+			Migrate: func(tx *xorm.Session) error {
+				if err := tx.Sync(&Person{}); err != nil {
+					return err
+				}
+				return failed
+			},
+		},
+	})
+	assert.Equal(t, failed, m.Migrate())
+	exists, _ = db.IsTableExist(&Person{})
+	assert.True(t, exists)
+	assert.Equal(t, 0, tableCount(db, "migrations"))
+}
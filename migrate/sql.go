@@ -0,0 +1,317 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package migrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"xorm.io/xorm"
+	"xorm.io/xorm/schemas"
+)
+
+var (
+	// sqlFileReg matches the migration file names like 001_init.up.sql or 001_init.postgres.down.sql
+	sqlFileReg = regexp.MustCompile(`^(\d+)_(.+?)(\.(postgres|mysql|sqlite3|mssql|oracle|dameng))?\.(up|down)\.sql$`)
+
+	dollarQuoteReg    = regexp.MustCompile(`^\$([A-Za-z_][A-Za-z0-9_]*)?\$`)
+	plsqlBlockReg     = regexp.MustCompile(`(?i)^(CREATE\s+(OR\s+REPLACE\s+)?((EDITIONABLE|NONEDITIONABLE)\s+)?(FUNCTION|PROCEDURE|TRIGGER|PACKAGE|TYPE\s+BODY)|DECLARE|BEGIN)\b`)
+	sqliteTriggerReg  = regexp.MustCompile(`(?i)^CREATE\s+(TEMP\s+|TEMPORARY\s+)?TRIGGER\b`)
+	mysqlDelimiterReg = regexp.MustCompile(`(?i)^DELIMITER\s+(\S+)\s*$`)
+)
+
+// sqlMigration holds the contents of the files of a SQL migration, the key of the
+// contents is the database type of the variant or empty for the default one.
+type sqlMigration struct {
+	id    string
+	ups   map[schemas.DBType]string
+	downs map[schemas.DBType]string
+}
+
+type sqlMigrations map[string]*sqlMigration
+
+// addFile adds the content of a migration file
+func (migrations sqlMigrations) addFile(fileName, content string) error {
+	matches := sqlFileReg.FindStringSubmatch(fileName)
+	if matches == nil {
+		return fmt.Errorf("invalid migration file name %s, it should be like 001_name.up.sql", fileName)
+	}
+
+	id, dbType, direction := matches[1], schemas.DBType(matches[4]), matches[5]
+	mig, ok := migrations[id]
+	if !ok {
+		mig = &sqlMigration{
+			id:    id,
+			ups:   make(map[schemas.DBType]string),
+			downs: make(map[schemas.DBType]string),
+		}
+		migrations[id] = mig
+	}
+
+	contents := mig.ups
+	if direction == "down" {
+		contents = mig.downs
+	}
+	if _, ok := contents[dbType]; ok {
+		return fmt.Errorf("duplicated migration file %s of migration %s", fileName, id)
+	}
+	contents[dbType] = content
+	return nil
+}
+
+// toMigrations converts the SQL files to the migrations ordered by IDs
+func (migrations sqlMigrations) toMigrations() ([]*Migration, error) {
+	var results = make([]*Migration, 0, len(migrations))
+	for _, mig := range migrations {
+		if len(mig.ups) == 0 {
+			return nil, fmt.Errorf("migration %s has no up file", mig.id)
+		}
+		results = append(results, mig.toMigration())
+	}
+	sortMigrations(results)
+	return results, nil
+}
+
+func (mig *sqlMigration) toMigration() *Migration {
+	migration := &Migration{
+		ID:       mig.id,
+		Checksum: mig.checksum(),
+		Migrate: func(session *xorm.Session) error {
+			return execSQL(session, mig.ups)
+		},
+	}
+	if len(mig.downs) > 0 {
+		migration.Rollback = func(session *xorm.Session) error {
+			return execSQL(session, mig.downs)
+		}
+	}
+	return migration
+}
+
+// checksum returns the SHA-256 checksum of the up and down files
+func (mig *sqlMigration) checksum() string {
+	h := sha256.New()
+	writeContents(h, "up", mig.ups)
+	writeContents(h, "down", mig.downs)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeContents writes the contents ordered by the database types
+func writeContents(w io.Writer, direction string, contents map[schemas.DBType]string) {
+	var dbTypes = make([]string, 0, len(contents))
+	for dbType := range contents {
+		dbTypes = append(dbTypes, string(dbType))
+	}
+	sort.Strings(dbTypes)
+
+	for _, dbType := range dbTypes {
+		_, _ = io.WriteString(w, direction+"\x00"+dbType+"\x00"+contents[schemas.DBType(dbType)]+"\x00")
+	}
+}
+
+// execSQL executes the variant of the database or the default one
+func execSQL(session *xorm.Session, contents map[schemas.DBType]string) error {
+	dbType := session.Engine().Dialect().URI().DBType
+	content, ok := contents[dbType]
+	if !ok {
+		if content, ok = contents[""]; !ok {
+			return fmt.Errorf("migration has no SQL file for %s", dbType)
+		}
+	}
+	for _, stmt := range SplitSQL(dbType, content) {
+		if _, err := session.Exec(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortMigrations sorts the migrations by IDs, the numeric IDs are compared by numbers
+func sortMigrations(migrations []*Migration) {
+	sort.SliceStable(migrations, func(i, j int) bool {
+		return lessID(migrations[i].ID, migrations[j].ID)
+	})
+}
+
+func lessID(a, b string) bool {
+	if isDigits(a) && isDigits(b) {
+		a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+	}
+	return a < b
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// SplitSQL splits the SQL script to statements. The quoted strings, identifiers and comments
+// are respected, and the dialect specific syntaxes are supported:
+//
+//	postgres: dollar quoted strings like $$ ... $$ or $body$ ... $body$
+//	mysql: DELIMITER commands to change the delimiter from ;
+//	mssql: the batches are separated by GO lines instead of ;
+//	oracle and dameng: PL/SQL blocks are ended by / lines
+//	sqlite3: the statements of CREATE TRIGGER are ended by END;
+func SplitSQL(dbType schemas.DBType, content string) []string {
+	var (
+		stmts     []string
+		buf       strings.Builder
+		delimiter = ";"
+		i         int
+		depth     int // the nesting depth of BEGIN and CASE in the sqlite statement
+	)
+	if dbType == schemas.MSSQL {
+		delimiter = ""
+	}
+
+	flush := func() {
+		if stmt := strings.TrimSpace(buf.String()); stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+		buf.Reset()
+		depth = 0
+	}
+	// inBlock returns true if the delimiter in the statement doesn't end the statement
+	inBlock := func() bool {
+		stmt := strings.TrimSpace(buf.String())
+		switch dbType {
+		case schemas.ORACLE, schemas.DAMENG:
+			return plsqlBlockReg.MatchString(stmt)
+		case schemas.SQLITE:
+			return depth > 0 && sqliteTriggerReg.MatchString(stmt)
+		}
+		return false
+	}
+
+	for i < len(content) {
+		// the commands occupy whole lines
+		if i == 0 || content[i-1] == '\n' {
+			end := strings.IndexByte(content[i:], '\n')
+			if end < 0 {
+				end = len(content) - i
+			}
+			line := strings.TrimSpace(content[i : i+end])
+			switch {
+			case dbType == schemas.MSSQL && strings.EqualFold(line, "GO"),
+				(dbType == schemas.ORACLE || dbType == schemas.DAMENG) && line == "/":
+				flush()
+				i += end
+				continue
+			case dbType == schemas.MYSQL && mysqlDelimiterReg.MatchString(line):
+				flush()
+				delimiter = mysqlDelimiterReg.FindStringSubmatch(line)[1]
+				i += end
+				continue
+			}
+		}
+
+		c := content[i]
+		switch {
+		case c == '-' && strings.HasPrefix(content[i:], "--"):
+			end := strings.IndexByte(content[i:], '\n')
+			if end < 0 {
+				end = len(content) - i
+			}
+			i += end
+		case c == '/' && strings.HasPrefix(content[i:], "/*"):
+			end := strings.Index(content[i+2:], "*/")
+			if end < 0 {
+				end = len(content) - i
+			} else {
+				end += 4
+			}
+			// the optimizer hints and the mysql conditional comments should be kept
+			if strings.HasPrefix(content[i:], "/*+") || strings.HasPrefix(content[i:], "/*!") {
+				buf.WriteString(content[i : i+end])
+			}
+			i += end
+		case c == '\'' || c == '"' || c == '`' && dbType == schemas.MYSQL || c == '[' && dbType == schemas.MSSQL:
+			// the backslashes escape the quotes in the mysql strings and the postgres E'...' strings
+			backslash := dbType == schemas.MYSQL && c != '`' ||
+				dbType == schemas.POSTGRES && c == '\'' && i > 0 && (content[i-1] == 'E' || content[i-1] == 'e') &&
+					(i == 1 || !isWordByte(content[i-2]))
+			end := quotedEnd(content, i, backslash)
+			buf.WriteString(content[i:end])
+			i = end
+		case c == '$' && dbType == schemas.POSTGRES && dollarQuoteReg.MatchString(content[i:]):
+			tag := dollarQuoteReg.FindString(content[i:])
+			end := strings.Index(content[i+len(tag):], tag)
+			if end < 0 {
+				end = len(content)
+			} else {
+				end += i + 2*len(tag)
+			}
+			buf.WriteString(content[i:end])
+			i = end
+		case delimiter != "" && strings.HasPrefix(content[i:], delimiter):
+			if delimiter == ";" && inBlock() {
+				buf.WriteString(delimiter)
+			} else {
+				flush()
+			}
+			i += len(delimiter)
+		case dbType == schemas.SQLITE && isWordByte(c) && (i == 0 || !isWordByte(content[i-1])):
+			end := i + 1
+			for end < len(content) && isWordByte(content[end]) {
+				end++
+			}
+			word := content[i:end]
+			if strings.EqualFold(word, "BEGIN") || strings.EqualFold(word, "CASE") {
+				depth++
+			} else if strings.EqualFold(word, "END") && depth > 0 {
+				depth--
+			}
+			buf.WriteString(word)
+			i = end
+		default:
+			buf.WriteByte(c)
+			i++
+		}
+	}
+	flush()
+	return stmts
+}
+
+// quotedEnd returns the end position of the quoted string or identifier started at i,
+// backslash means the quotes could be escaped by backslashes
+func quotedEnd(content string, i int, backslash bool) int {
+	quote := content[i]
+	if quote == '[' {
+		quote = ']'
+	}
+	for j := i + 1; j < len(content); j++ {
+		switch content[j] {
+		case '\\':
+			if backslash {
+				j++
+			}
+		case quote:
+			// the doubled quote is an escaped quote
+			if j+1 < len(content) && content[j+1] == quote {
+				j++
+				continue
+			}
+			return j + 1
+		}
+	}
+	return len(content)
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"xorm.io/xorm/schemas"
+)
+
+func TestSplitSQL(t *testing.T) {
+	var kases = []struct {
+		dbType  schemas.DBType
+		content string
+		stmts   []string
+	}{
+		{
+			schemas.SQLITE,
+			"-- create tables\nCREATE TABLE a (id INTEGER, name TEXT DEFAULT 'a;b');\n/* comment; */\nINSERT INTO a VALUES (1, 'it''s');",
+			[]string{"CREATE TABLE a (id INTEGER, name TEXT DEFAULT 'a;b')", "INSERT INTO a VALUES (1, 'it''s')"},
+		},
+		{
+			schemas.SQLITE,
+			"CREATE TRIGGER t AFTER INSERT ON a BEGIN\n  UPDATE a SET name = 'x';\nEND;\nDELETE FROM a;",
+			[]string{"CREATE TRIGGER t AFTER INSERT ON a BEGIN\n  UPDATE a SET name = 'x';\nEND", "DELETE FROM a"},
+		},
+		{
+			schemas.SQLITE,
+			"BEGIN;\nCREATE TRIGGER t AFTER INSERT ON a BEGIN\n  UPDATE a SET name = CASE WHEN new.id > 0 THEN 'x' ELSE 'y' END;\n  SELECT \"end\";\nEND;\nCOMMIT;",
+			[]string{"BEGIN", "CREATE TRIGGER t AFTER INSERT ON a BEGIN\n  UPDATE a SET name = CASE WHEN new.id > 0 THEN 'x' ELSE 'y' END;\n  SELECT \"end\";\nEND", "COMMIT"},
+		},
+		{
+			schemas.POSTGRES,
+			"CREATE FUNCTION f() RETURNS int AS $$ BEGIN RETURN 1; END; $$ LANGUAGE plpgsql;\nSELECT $body$;$body$, \"a;b\";",
+			[]string{"CREATE FUNCTION f() RETURNS int AS $$ BEGIN RETURN 1; END; $$ LANGUAGE plpgsql", "SELECT $body$;$body$, \"a;b\""},
+		},
+		{
+			schemas.POSTGRES,
+			"SELECT E'a\\';b', 'c\\';\nSELECT 1;",
+			[]string{"SELECT E'a\\';b', 'c\\'", "SELECT 1"},
+		},
+		{
+			schemas.MYSQL,
+			"SELECT 'a\\';b', `c;d`;\nDELIMITER //\nCREATE PROCEDURE p() BEGIN SELECT 1; END//\nDELIMITER ;\nSELECT /*!40101 1 */;",
+			[]string{"SELECT 'a\\';b', `c;d`", "CREATE PROCEDURE p() BEGIN SELECT 1; END", "SELECT /*!40101 1 */"},
+		},
+		{
+			schemas.MSSQL,
+			"CREATE TABLE [a;b] (id INT);\ngo\nCREATE PROCEDURE p AS SELECT 1; SELECT 2;\nGO\n",
+			[]string{"CREATE TABLE [a;b] (id INT);", "CREATE PROCEDURE p AS SELECT 1; SELECT 2;"},
+		},
+		{
+			schemas.ORACLE,
+			"CREATE TABLE a (id NUMBER);\nCREATE OR REPLACE TRIGGER t BEFORE INSERT ON a FOR EACH ROW\nBEGIN\n  NULL;\nEND;\n/\nINSERT INTO a VALUES (1);",
+			[]string{"CREATE TABLE a (id NUMBER)", "CREATE OR REPLACE TRIGGER t BEFORE INSERT ON a FOR EACH ROW\nBEGIN\n  NULL;\nEND;", "INSERT INTO a VALUES (1)"},
+		},
+	}
+
+	for _, kase := range kases {
+		t.Run(string(kase.dbType), func(t *testing.T) {
+			assert.EqualValues(t, kase.stmts, SplitSQL(kase.dbType, kase.content))
+		})
+	}
+}
+
+func TestSQLMigrations(t *testing.T) {
+	migrations := make(sqlMigrations)
+	assert.NoError(t, migrations.addFile("10_b.up.sql", "SELECT 1"))
+	assert.NoError(t, migrations.addFile("9_a.up.sql", "SELECT 1"))
+	assert.NoError(t, migrations.addFile("9_a.postgres.up.sql", "SELECT 2"))
+	assert.NoError(t, migrations.addFile("9_a.down.sql", "SELECT 3"))
+	assert.Error(t, migrations.addFile("9_a.down.sql", "SELECT 3"))
+	assert.Error(t, migrations.addFile("init.sql", "SELECT 1"))
+
+	results, err := migrations.toMigrations()
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.EqualValues(t, "9", results[0].ID)
+	assert.NotNil(t, results[0].Rollback)
+	assert.EqualValues(t, "10", results[1].ID)
+	assert.Nil(t, results[1].Rollback)
+
+	// the checksum covers the down files
+	checksum := results[0].Checksum
+	migrations["9"].downs[""] = "SELECT 4"
+	assert.NotEqual(t, checksum, migrations["9"].checksum())
+
+	assert.NoError(t, migrations.addFile("11_c.down.sql", "SELECT 1"))
+	_, err = migrations.toMigrations()
+	assert.Error(t, err)
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package names
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NamingStrategy generates the names of the indexes, the constraints and the sequences of
+// the tables. The table names have no schema. A name is expected to start with the name
+// generated for an empty name, so that the names read from the database can be recognized.
+type NamingStrategy interface {
+	IndexName(tableName, indexName string) string
+	UniqueName(tableName, indexName string) string
+	ForeignKeyName(tableName, fkName string) string
+	CheckName(tableName, checkName string) string
+	SequenceName(tableName string) string
+}
+
+// PrefixNamingStrategy is the default naming strategy which names the indexes as
+// IDX_<table>_<name>, the uniques as UQE_<table>_<name>, the foreign keys as FK_<table>_<name>,
+// the check constraints as CHK_<table>_<name> and the sequences as SEQ_<TABLE>
+type PrefixNamingStrategy struct{}
+
+var _ NamingStrategy = PrefixNamingStrategy{}
+
+// IndexName implements NamingStrategy
+func (PrefixNamingStrategy) IndexName(tableName, indexName string) string {
+	if strings.HasPrefix(indexName, "UQE_") || strings.HasPrefix(indexName, "IDX_") {
+		return indexName
+	}
+	return fmt.Sprintf("IDX_%v_%v", tableName, indexName)
+}
+
+// UniqueName implements NamingStrategy
+func (PrefixNamingStrategy) UniqueName(tableName, indexName string) string {
+	if strings.HasPrefix(indexName, "UQE_") || strings.HasPrefix(indexName, "IDX_") {
+		return indexName
+	}
+	return fmt.Sprintf("UQE_%v_%v", tableName, indexName)
+}
+
+// ForeignKeyName implements NamingStrategy
+func (PrefixNamingStrategy) ForeignKeyName(tableName, fkName string) string {
+	if strings.HasPrefix(fkName, "FK_") {
+		return fkName
+	}
+	return fmt.Sprintf("FK_%v_%v", tableName, fkName)
+}
+
+// CheckName implements NamingStrategy
+func (PrefixNamingStrategy) CheckName(tableName, checkName string) string {
+	if strings.HasPrefix(checkName, "CHK_") {
+		return checkName
+	}
+	return fmt.Sprintf("CHK_%v_%v", tableName, checkName)
+}
+
+// SequenceName implements NamingStrategy
+func (PrefixNamingStrategy) SequenceName(tableName string) string {
+	return "SEQ_" + strings.ToUpper(tableName)
+}
@@ -7,6 +7,8 @@ package names
 import (
 	"reflect"
 	"sync"
+
+	"xorm.io/xorm/internal/utils"
 )
 
 // TableName table name interface to define customerize table name
@@ -18,30 +20,30 @@ type TableComment interface {
 	TableComment() string
 }
 
+// TableSchema is an interface to define the schema of the table, it overrides the
+// schema of the engine
+type TableSchema interface {
+	TableSchema() string
+}
+
 var (
 	tpTableName    = reflect.TypeOf((*TableName)(nil)).Elem()
 	tpTableComment = reflect.TypeOf((*TableComment)(nil)).Elem()
+	tpTableSchema  = reflect.TypeOf((*TableSchema)(nil)).Elem()
 	tvCache        sync.Map
 	tcCache        sync.Map
+	tsCache        sync.Map
 )
 
 // GetTableName returns table name
 func GetTableName(mapper Mapper, v reflect.Value) string {
-	if v.Type().Implements(tpTableName) {
-		return v.Interface().(TableName).TableName()
+	if i, ok := utils.LookupTableInterface(v, tpTableName).(TableName); ok {
+		return i.TableName()
 	}
 
 	if v.Kind() == reflect.Ptr {
 		v = v.Elem()
-		if v.Type().Implements(tpTableName) {
-			return v.Interface().(TableName).TableName()
-		}
-	} else if v.CanAddr() {
-		v1 := v.Addr()
-		if v1.Type().Implements(tpTableName) {
-			return v1.Interface().(TableName).TableName()
-		}
-	} else {
+	} else if !v.CanAddr() {
 		name, ok := tvCache.Load(v.Type())
 		if ok {
 			if name.(string) != "" {
@@ -64,21 +66,11 @@ func GetTableName(mapper Mapper, v reflect.Value) string {
 
 // GetTableComment returns table comment
 func GetTableComment(v reflect.Value) string {
-	if v.Type().Implements(tpTableComment) {
-		return v.Interface().(TableComment).TableComment()
+	if i, ok := utils.LookupTableInterface(v, tpTableComment).(TableComment); ok {
+		return i.TableComment()
 	}
 
-	if v.Kind() == reflect.Ptr {
-		v = v.Elem()
-		if v.Type().Implements(tpTableComment) {
-			return v.Interface().(TableComment).TableComment()
-		}
-	} else if v.CanAddr() {
-		v1 := v.Addr()
-		if v1.Type().Implements(tpTableComment) {
-			return v1.Interface().(TableComment).TableComment()
-		}
-	} else {
+	if v.Kind() != reflect.Ptr && !v.CanAddr() {
 		comment, ok := tcCache.Load(v.Type())
 		if ok {
 			if comment.(string) != "" {
@@ -98,3 +90,27 @@ func GetTableComment(v reflect.Value) string {
 
 	return ""
 }
+
+// GetTableSchema returns the schema of the table, it's empty if the bean doesn't
+// implement TableSchema
+func GetTableSchema(v reflect.Value) string {
+	if i, ok := utils.LookupTableInterface(v, tpTableSchema).(TableSchema); ok {
+		return i.TableSchema()
+	}
+
+	if v.Kind() != reflect.Ptr && !v.CanAddr() {
+		schema, ok := tsCache.Load(v.Type())
+		if ok {
+			return schema.(string)
+		}
+		v2 := reflect.New(v.Type())
+		if v2.Type().Implements(tpTableSchema) {
+			tableSchema := v2.Interface().(TableSchema).TableSchema()
+			tsCache.Store(v.Type(), tableSchema)
+			return tableSchema
+		}
+		tsCache.Store(v.Type(), "")
+	}
+
+	return ""
+}
@@ -138,3 +138,15 @@ func TestMyTable(t *testing.T) {
 		assert.EqualValues(t, fmt.Sprintf("mytable_%d", i), GetTableName(SameMapper{}, reflect.ValueOf(&table)))
 	}
 }
+
+type MySchemaTable struct{}
+
+func (MySchemaTable) TableSchema() string {
+	return "other"
+}
+
+func TestGetTableSchema(t *testing.T) {
+	assert.EqualValues(t, "other", GetTableSchema(reflect.ValueOf(MySchemaTable{})))
+	assert.EqualValues(t, "other", GetTableSchema(reflect.ValueOf(new(MySchemaTable))))
+	assert.EqualValues(t, "", GetTableSchema(reflect.ValueOf(new(MyTable))))
+}
@@ -0,0 +1,513 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package reverse generates Go structs with xorm tags from the tables of an existing database.
+//
+// The generated structs can be synced back to the database without any difference, i.e.
+//
+//	var buf bytes.Buffer
+//	err := reverse.Generate(engine, &buf, &reverse.Options{PackageName: "models"})
+package reverse
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"xorm.io/xorm"
+	"xorm.io/xorm/dialects"
+	"xorm.io/xorm/internal/utils"
+	"xorm.io/xorm/names"
+	"xorm.io/xorm/schemas"
+)
+
+// DefaultTemplate is the template used when no template is set in the options. The
+// template is executed with a *File and the functions of TemplateFuncs.
+const DefaultTemplate = `package {{.PackageName}}
+{{if .Imports}}
+import (
+{{- range .Imports}}
+	{{quote .}}
+{{- end}}
+)
+{{end}}
+{{- range .Structs}}
+{{if .Table.Comment}}// {{.Name}} {{.Table.Comment}}
+{{end -}}
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} {{tag .}}
+{{- end}}
+}
+
+// TableName returns the name of the table
+func ({{.Name}}) TableName() string {
+	return {{quote .Table.Name}}
+}
+{{if .Table.IsView}}
+// TableView returns the query of the view
+func ({{.Name}}) TableView() *schemas.View {
+	return schemas.NewView({{quote .Table.View.Query}}, {{.Table.View.Materialized}})
+}
+{{end}}
+{{- if .Checks}}
+// TableChecks returns the check constraints of the table
+func ({{.Name}}) TableChecks() []*schemas.Check {
+	return []*schemas.Check{
+{{- range .Checks}}
+		schemas.NewCheck({{quote .Name}}, {{quote .Expr}}),
+{{- end}}
+	}
+}
+{{end}}
+{{- end}}
+`
+
+// TemplateFuncs are the functions which can be used in the templates
+var TemplateFuncs = template.FuncMap{
+	"quote": strconv.Quote,
+	"tag": func(field *Field) string {
+		return "`xorm:" + strconv.Quote(field.Tag) + "`"
+	},
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// ParseTemplate parses a template which can use the functions of TemplateFuncs
+func ParseTemplate(text string) (*template.Template, error) {
+	return template.New("reverse").Funcs(TemplateFuncs).Parse(text)
+}
+
+// Options represents the options of generating
+type Options struct {
+	// PackageName is the package name of the generated file, default is models
+	PackageName string
+	// TableMapper maps the table names to the struct names, default is the table mapper of the engine
+	TableMapper names.Mapper
+	// ColumnMapper maps the column names to the field names, default is the column mapper of the engine
+	ColumnMapper names.Mapper
+	// Tables are the names of the tables to be generated, empty means all the tables
+	Tables []string
+	// Template is the template of the generated file, default is DefaultTemplate
+	Template *template.Template
+	// SkipFormat will not format the output with gofmt, it should be set if the template
+	// doesn't generate Go code
+	SkipFormat bool
+}
+
+// File represents the generated file
+type File struct {
+	PackageName string
+	Imports     []string
+	Structs     []*Struct
+}
+
+// Struct represents the struct generated from a table or a view
+type Struct struct {
+	Name   string
+	Table  *schemas.Table
+	Fields []*Field
+	Checks []*schemas.Check // the check constraints which are not added to any field
+}
+
+// Field represents the struct field generated from a column
+type Field struct {
+	Name   string
+	Type   string
+	Tag    string // the content of the xorm tag
+	Column *schemas.Column
+}
+
+// Generate reads the tables of the database and writes the generated Go code to w
+func Generate(engine *xorm.Engine, w io.Writer, opts *Options) error {
+	tables, err := engine.DBMetas()
+	if err != nil {
+		return err
+	}
+
+	var o = *opts
+	if o.TableMapper == nil {
+		o.TableMapper = engine.GetTableMapper()
+	}
+	if o.ColumnMapper == nil {
+		o.ColumnMapper = engine.GetColumnMapper()
+	}
+	return GenerateTables(engine.Dialect(), tables, w, &o)
+}
+
+// GenerateTables writes the Go code generated from the tables to w
+func GenerateTables(dialect dialects.Dialect, tables []*schemas.Table, w io.Writer, opts *Options) error {
+	tmpl := opts.Template
+	if tmpl == nil {
+		var err error
+		if tmpl, err = ParseTemplate(DefaultTemplate); err != nil {
+			return err
+		}
+	}
+
+	file := NewFile(dialect, tables, opts)
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, file); err != nil {
+		return err
+	}
+
+	content := buf.Bytes()
+	if !opts.SkipFormat {
+		var err error
+		if content, err = format.Source(content); err != nil {
+			return fmt.Errorf("format generated code failed: %v", err)
+		}
+	}
+	_, err := w.Write(content)
+	return err
+}
+
+// NewFile converts the tables to the structs, the tables are sorted by names
+func NewFile(dialect dialects.Dialect, tables []*schemas.Table, opts *Options) *File {
+	tableMapper, columnMapper := opts.TableMapper, opts.ColumnMapper
+	if tableMapper == nil {
+		tableMapper = names.SnakeMapper{}
+	}
+	if columnMapper == nil {
+		columnMapper = names.SnakeMapper{}
+	}
+
+	file := &File{PackageName: opts.PackageName}
+	if file.PackageName == "" {
+		file.PackageName = "models"
+	}
+
+	tables = filterTables(tables, opts.Tables)
+	var (
+		imports     = make(map[string]bool)
+		structNames = make(map[string]bool)
+	)
+	for _, table := range tables {
+		st := &Struct{
+			Name:  uniqueName(identifier(tableMapper.Table2Obj(table.Name)), structNames),
+			Table: table,
+		}
+		if !table.IsView() {
+			st.Checks = tableChecks(table)
+		}
+		if table.IsView() || len(st.Checks) > 0 {
+			imports["xorm.io/xorm/schemas"] = true
+		}
+
+		var fieldNames = make(map[string]bool)
+		for _, col := range table.Columns() {
+			field := &Field{
+				Name:   uniqueName(identifier(columnMapper.Table2Obj(col.Name)), fieldNames),
+				Type:   goType(dialect, col),
+				Column: col,
+			}
+			field.Tag = columnTag(table, col)
+			if field.Type == "time.Time" {
+				imports["time"] = true
+			}
+			st.Fields = append(st.Fields, field)
+		}
+		file.Structs = append(file.Structs, st)
+	}
+
+	for imp := range imports {
+		file.Imports = append(file.Imports, imp)
+	}
+	sort.Strings(file.Imports)
+	return file
+}
+
+func filterTables(tables []*schemas.Table, includes []string) []*schemas.Table {
+	var results = make([]*schemas.Table, 0, len(tables))
+	for _, table := range tables {
+		if len(includes) == 0 || utils.ContainsFold(includes, table.Name) {
+			results = append(results, table)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+	return results
+}
+
+// identifier converts the name to an exported Go identifier
+func identifier(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	ident := b.String()
+	if ident == "" || !unicode.IsUpper([]rune(ident)[0]) {
+		ident = "X" + ident
+	}
+	return ident
+}
+
+func uniqueName(name string, used map[string]bool) string {
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	used[unique] = true
+	return unique
+}
+
+// goType returns the Go type of the column according to the kind of the column type
+func goType(dialect dialects.Dialect, col *schemas.Column) string {
+	switch dialect.ColumnTypeKind(col.SQLType.Name) {
+	case schemas.TIME_TYPE:
+		return "time.Time"
+	case schemas.BOOL_TYPE:
+		return "bool"
+	case schemas.BLOB_TYPE:
+		return "[]byte"
+	case schemas.TEXT_TYPE:
+		return "string"
+	}
+
+	switch t := schemas.SQLType2Type(col.SQLType); t {
+	case schemas.TimeType:
+		return "time.Time"
+	case schemas.BytesType:
+		return "[]byte"
+	default:
+		if t.Kind() == reflect.Int && col.IsAutoIncrement {
+			return "int64"
+		}
+		return t.String()
+	}
+}
+
+var (
+	createdNames = []string{"created", "created_at", "created_time", "create_time", "gmt_create"}
+	updatedNames = []string{"updated", "updated_at", "updated_time", "update_time", "modified_at", "gmt_modified"}
+)
+
+// columnTag returns the xorm tag of the column which could be synced to the same column
+func columnTag(table *schemas.Table, col *schemas.Column) string {
+	var tags = []string{"'" + col.Name + "'"}
+	if table.IsView() {
+		return tags[0]
+	}
+
+	if col.IsPrimaryKey {
+		tags = append(tags, "pk")
+	}
+	if col.IsAutoIncrement {
+		tags = append(tags, "autoincr")
+	}
+	if !col.Nullable && !col.IsPrimaryKey && !col.IsAutoIncrement {
+		tags = append(tags, "notnull")
+	}
+	if sqlType := columnType(col); sqlType != "" {
+		tags = append(tags, sqlType)
+	}
+	if !col.DefaultIsEmpty && !col.IsAutoIncrement && isPlainParam(col.Default) {
+		tags = append(tags, "default("+col.Default+")")
+	}
+	tags = append(tags, indexTags(table, col)...)
+
+	if col.SQLType.IsTime() || col.SQLType.IsNumeric() {
+		switch {
+		case utils.ContainsFold(createdNames, col.Name):
+			tags = append(tags, "created")
+		case utils.ContainsFold(updatedNames, col.Name):
+			tags = append(tags, "updated")
+		}
+	}
+	if col.Comment != "" {
+		tags = append(tags, "comment("+quoteParam(col.Comment)+")")
+	}
+	tags = append(tags, foreignKeyTags(table, col)...)
+	tags = append(tags, checkTags(table, col)...)
+	return strings.Join(tags, " ")
+}
+
+// columnType returns the SQL type tag of the column, an unknown type will be mapped from the Go type
+func columnType(col *schemas.Column) string {
+	name := strings.ToUpper(col.SQLType.Name)
+	if _, ok := schemas.SqlTypes[name]; !ok {
+		return ""
+	}
+
+	switch {
+	case len(col.EnumOptions) > 0:
+		return name + "(" + optionsParam(col.EnumOptions) + ")"
+	case len(col.SetOptions) > 0:
+		return name + "(" + optionsParam(col.SetOptions) + ")"
+	case col.Length2 > 0:
+		return fmt.Sprintf("%s(%d,%d)", name, col.Length, col.Length2)
+	case col.Length > 0:
+		return fmt.Sprintf("%s(%d)", name, col.Length)
+	}
+	return name
+}
+
+func optionsParam(options map[string]int) string {
+	values := make([]string, len(options))
+	for value, i := range options {
+		values[i] = "'" + value + "'"
+	}
+	return strings.Join(values, ",")
+}
+
+// isPlainParam returns true if the value could be a tag param, the spaces, commas and
+// parentheses are only allowed in quotes
+func isPlainParam(value string) bool {
+	var inQuote bool
+	for _, c := range value {
+		switch c {
+		case '\'':
+			inQuote = !inQuote
+		case ' ', ',', '(', ')', '`', '"':
+			if !inQuote {
+				return false
+			}
+		}
+	}
+	return value != "" && !inQuote
+}
+
+// indexTags returns the index and unique tags of the column, the expression indexes are ignored
+func indexTags(table *schemas.Table, col *schemas.Column) []string {
+	var tags []string
+	for _, index := range table.SortedIndexes() {
+		var (
+			params    []string
+			isInclude = utils.ContainsFold(index.Include, col.Name)
+		)
+		if !utils.ContainsFold(index.Cols, col.Name) && !isInclude || hasExpr(index) {
+			continue
+		}
+		if len(index.Cols) > 1 || isInclude || !strings.EqualFold(index.Name, col.Name) {
+			params = append(params, index.Name)
+		}
+		if index.Desc[col.Name] {
+			params = append(params, "desc")
+		}
+		if index.Lengths[col.Name] > 0 {
+			params = append(params, "length:"+strconv.Itoa(index.Lengths[col.Name]))
+		}
+		if isInclude {
+			params = append(params, "include")
+		}
+		if len(index.Cols) > 0 && strings.EqualFold(index.Cols[0], col.Name) {
+			if index.Method != "" {
+				params = append(params, "using:"+index.Method)
+			}
+			if index.Where != "" {
+				params = append(params, "where:"+quoteParam(index.Where))
+			}
+		}
+
+		tag := "index"
+		if index.Type == schemas.UniqueType {
+			tag = "unique"
+		}
+		if len(params) > 0 {
+			tag += "(" + strings.Join(params, ",") + ")"
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+func hasExpr(index *schemas.Index) bool {
+	for _, col := range index.Cols {
+		if schemas.IsIndexExpr(col) {
+			return true
+		}
+	}
+	return false
+}
+
+// foreignKeyTags returns the fk tags of the column, the columns of a composite foreign key
+// share the same foreign key name
+func foreignKeyTags(table *schemas.Table, col *schemas.Column) []string {
+	var fkNames = make([]string, 0, len(table.ForeignKeys))
+	for name := range table.ForeignKeys {
+		fkNames = append(fkNames, name)
+	}
+	sort.Strings(fkNames)
+
+	var tags []string
+	for _, name := range fkNames {
+		fk := table.ForeignKeys[name]
+		for i, fkCol := range fk.Cols {
+			if !strings.EqualFold(fkCol, col.Name) {
+				continue
+			}
+			tags = append(tags, fmt.Sprintf("fk(%s.%s,%s)", fk.RefTable, fk.RefCols[i], fk.Name))
+			if action := schemas.ReferentialAction(fk.OnDelete); action != schemas.NoAction {
+				tags = append(tags, "on_delete("+strings.ReplaceAll(strings.ToLower(action), " ", "_")+")")
+			}
+			if action := schemas.ReferentialAction(fk.OnUpdate); action != schemas.NoAction {
+				tags = append(tags, "on_update("+strings.ReplaceAll(strings.ToLower(action), " ", "_")+")")
+			}
+		}
+	}
+	return tags
+}
+
+// quoteParam quotes the param of a tag, the single quotes inside it are doubled
+func quoteParam(param string) string {
+	return "'" + strings.ReplaceAll(param, "'", "''") + "'"
+}
+
+// checkTags returns the check tags of the column, a check constraint will be added to
+// the first column used in the expression
+func checkTags(table *schemas.Table, col *schemas.Column) []string {
+	var checkNames = make([]string, 0, len(table.Checks))
+	for name := range table.Checks {
+		checkNames = append(checkNames, name)
+	}
+	sort.Strings(checkNames)
+
+	var tags []string
+	for _, name := range checkNames {
+		check := table.Checks[name]
+		if checkColumn(table, check) == col {
+			tags = append(tags, fmt.Sprintf("check(%s,%s)", quoteParam(check.Expr), check.Name))
+		}
+	}
+	return tags
+}
+
+func checkColumn(table *schemas.Table, check *schemas.Check) *schemas.Column {
+	words := strings.FieldsFunc(check.Expr, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+	for _, col := range table.Columns() {
+		if utils.ContainsFold(words, col.Name) {
+			return col
+		}
+	}
+	return nil
+}
+
+// tableChecks returns the check constraints which use no column of the table, they are
+// returned by the TableChecks method of the struct
+func tableChecks(table *schemas.Table) []*schemas.Check {
+	var checks []*schemas.Check
+	for _, check := range table.Checks {
+		if checkColumn(table, check) == nil {
+			checks = append(checks, check)
+		}
+	}
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].Name < checks[j].Name
+	})
+	return checks
+}
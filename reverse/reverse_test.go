@@ -0,0 +1,99 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package reverse
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"xorm.io/xorm/dialects"
+	"xorm.io/xorm/schemas"
+)
+
+func TestGenerateTables(t *testing.T) {
+	table := schemas.NewTable("user_info", nil)
+	id := schemas.NewColumn("id", "", schemas.SQLType{Name: schemas.Integer}, 0, 0, false)
+	id.IsPrimaryKey, id.IsAutoIncrement = true, true
+	table.AddColumn(id)
+	name := schemas.NewColumn("name", "", schemas.SQLType{Name: schemas.Varchar}, 50, 0, false)
+	name.Comment = "user's name"
+	table.AddColumn(name)
+	status := schemas.NewColumn("status", "", schemas.SQLType{Name: schemas.Integer}, 0, 0, true)
+	status.Default, status.DefaultIsEmpty = "1", false
+	table.AddColumn(status)
+	table.AddColumn(schemas.NewColumn("created_at", "", schemas.SQLType{Name: schemas.DateTime}, 0, 0, true))
+	table.AddIndex(&schemas.Index{IsRegular: true, Name: "name", Type: schemas.UniqueType, Cols: []string{"name"},
+		Where: "name <> 'admin'"})
+	table.AddIndex(&schemas.Index{IsRegular: true, Name: "s", Type: schemas.IndexType, Cols: []string{"status", "created_at"},
+		Desc: map[string]bool{"created_at": true}})
+	table.AddCheck(schemas.NewCheck("CHK_user_info_status", "status >= 0"))
+	table.AddCheck(schemas.NewCheck("CHK_user_info_name", "name <> 'root'"))
+	table.AddCheck(schemas.NewCheck("CHK_user_info_true", "1 = 1"))
+
+	view := schemas.NewTable("active_user", nil)
+	view.View = schemas.NewView("SELECT id FROM user_info WHERE status = 1", false)
+	view.AddColumn(schemas.NewColumn("id", "", schemas.SQLType{Name: schemas.Integer}, 0, 0, true))
+
+	var buf bytes.Buffer
+	assert.NoError(t, GenerateTables(dialects.QueryDialect(schemas.SQLITE), []*schemas.Table{table, view}, &buf, &Options{}))
+	assert.EqualValues(t, `package models
+
+import (
+	"time"
+	"xorm.io/xorm/schemas"
+)
+
+type ActiveUser struct {
+	Id int `+"`"+`xorm:"'id'"`+"`"+`
+}
+
+// TableName returns the name of the table
+func (ActiveUser) TableName() string {
+	return "active_user"
+}
+
+// TableView returns the query of the view
+func (ActiveUser) TableView() *schemas.View {
+	return schemas.NewView("SELECT id FROM user_info WHERE status = 1", false)
+}
+
+type UserInfo struct {
+	Id        int64     `+"`"+`xorm:"'id' pk autoincr INTEGER"`+"`"+`
+	Name      string    `+"`"+`xorm:"'name' notnull VARCHAR(50) unique(where:'name <> ''admin''') comment('user''s name') check('name <> ''root''',CHK_user_info_name)"`+"`"+`
+	Status    int       `+"`"+`xorm:"'status' INTEGER default(1) index(s) check('status >= 0',CHK_user_info_status)"`+"`"+`
+	CreatedAt time.Time `+"`"+`xorm:"'created_at' DATETIME index(s,desc) created"`+"`"+`
+}
+
+// TableName returns the name of the table
+func (UserInfo) TableName() string {
+	return "user_info"
+}
+
+// TableChecks returns the check constraints of the table
+func (UserInfo) TableChecks() []*schemas.Check {
+	return []*schemas.Check{
+		schemas.NewCheck("CHK_user_info_true", "1 = 1"),
+	}
+}
+`, buf.String())
+
+	tmpl, err := ParseTemplate(`{{range .Structs}}{{.Name}}:{{range .Fields}} {{.Name}}{{end}}
+{{end}}`)
+	assert.NoError(t, err)
+	buf.Reset()
+	assert.NoError(t, GenerateTables(dialects.QueryDialect(schemas.SQLITE), []*schemas.Table{table, view}, &buf,
+		&Options{Template: tmpl, Tables: []string{"user_info"}, SkipFormat: true}))
+	assert.EqualValues(t, "UserInfo: Id Name Status CreatedAt\n", buf.String())
+}
+
+func TestIsPlainParam(t *testing.T) {
+	assert.True(t, isPlainParam("1"))
+	assert.True(t, isPlainParam("'a b'"))
+	assert.True(t, isPlainParam("CURRENT_TIMESTAMP"))
+	assert.False(t, isPlainParam("(datetime('now'))"))
+	assert.False(t, isPlainParam("'a"))
+	assert.False(t, isPlainParam(""))
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package schemas
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Check represents a check constraint of a table
+type Check struct {
+	Name string
+	Expr string
+}
+
+// NewCheck new a check constraint object
+func NewCheck(name, expr string) *Check {
+	return &Check{Name: name, Expr: expr}
+}
+
+var (
+	exprCastsReg = regexp.MustCompile(`::[a-z]+( varying| precision| with(out)? time zone)?(\[\])?`)
+	exprCharsReg = regexp.MustCompile("[\\s`\"\\[\\]()]+")
+)
+
+// Equal return true if the two check constraints have the same expression. Databases
+// may rewrite the expressions, so the quotes, parentheses, spaces and postgres type
+// casts are ignored, and != is the same as <>.
+func (check *Check) Equal(dst *Check) bool {
+	return normalizeExpr(check.Expr) == normalizeExpr(dst.Expr)
+}
+
+func normalizeExpr(expr string) string {
+	// the string literals are case sensitive
+	parts := strings.Split(expr, "'")
+	for i := 0; i < len(parts); i += 2 {
+		parts[i] = strings.ToLower(parts[i])
+		parts[i] = exprCastsReg.ReplaceAllString(parts[i], "")
+		parts[i] = exprCharsReg.ReplaceAllString(parts[i], "")
+		parts[i] = strings.ReplaceAll(parts[i], "!=", "<>")
+	}
+	return strings.Join(parts, "'")
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package schemas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckEqual(t *testing.T) {
+	var kases = []struct {
+		expr   string
+		dbExpr string
+		equal  bool
+	}{
+		{"amount >= 0", "(`amount` >= 0)", true},
+		{"amount >= 0", "([amount]>=(0))", true},
+		{"amount >= 0", "((amount >= 0))", true},
+		{"name <> ''", "((name)::text <> ''::text)", true},
+		{"name <> ''", "((name)::character varying <> ''::character varying)", true},
+		{"status <> 0", "(status != 0)", true},
+		{"amount >= 0", "(amount > 0)", false},
+		{"status = 'open'", "(STATUS = 'open')", true},
+		{"status = 'open'", "status = 'Open'", false},
+	}
+
+	for _, kase := range kases {
+		assert.EqualValues(t, kase.equal, NewCheck("c", kase.expr).Equal(NewCheck("c", kase.dbExpr)), kase.dbExpr)
+	}
+}
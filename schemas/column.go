@@ -42,9 +42,12 @@ type Column struct {
 	DefaultIsEmpty  bool // false means column has no default set, but not default value is empty
 	EnumOptions     map[string]int
 	SetOptions      map[string]int
+	TypeParams      []string // the element types of LIST, MAP and ARRAY or the fields of STRUCT
 	DisableTimeZone bool
 	TimeZone        *time.Location // column specified time zone
 	Comment         string
+	RenamedFrom     string // the old name of the column which will be renamed by Sync
+	Sequence        string // the sequence bound to the autoincrement column by the seq tag
 }
 
 // NewColumn creates a new column
@@ -0,0 +1,207 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package schemas
+
+import (
+	"sort"
+	"strings"
+)
+
+// SchemaDiff represents the differences between two database schemas, it describes
+// the changes which will bring the from schema in line with the to schema
+type SchemaDiff struct {
+	AddedTables   []*Table // the tables which are only in the to schema
+	DroppedTables []*Table // the tables which are only in the from schema
+	ChangedTables []*TableDiff
+}
+
+// IsEmpty returns true if the two schemas are the same
+func (diff *SchemaDiff) IsEmpty() bool {
+	return len(diff.AddedTables) == 0 && len(diff.DroppedTables) == 0 && len(diff.ChangedTables) == 0
+}
+
+// TableDiff represents the differences between two tables with the same name
+type TableDiff struct {
+	Name        string
+	From        *Table
+	To          *Table
+	ViewChanged bool // true if the tables are views and the queries are different
+
+	AddedColumns   []*Column
+	DroppedColumns []*Column
+	ChangedColumns []*ColumnDiff
+	AddedIndexes   []*Index
+	DroppedIndexes []*Index
+
+	AddedForeignKeys   []*ForeignKey
+	DroppedForeignKeys []*ForeignKey
+	AddedChecks        []*Check
+	DroppedChecks      []*Check
+}
+
+// IsEmpty returns true if the two tables are the same
+func (diff *TableDiff) IsEmpty() bool {
+	return !diff.ViewChanged &&
+		len(diff.AddedColumns) == 0 && len(diff.DroppedColumns) == 0 && len(diff.ChangedColumns) == 0 &&
+		len(diff.AddedIndexes) == 0 && len(diff.DroppedIndexes) == 0 &&
+		len(diff.AddedForeignKeys) == 0 && len(diff.DroppedForeignKeys) == 0 &&
+		len(diff.AddedChecks) == 0 && len(diff.DroppedChecks) == 0
+}
+
+// ColumnDiff represents the differences between two columns with the same name
+type ColumnDiff struct {
+	From *Column
+	To   *Column
+
+	TypeChanged     bool
+	NullableChanged bool
+	DefaultChanged  bool
+}
+
+// Diff compares the tables of two schemas, i.e. the results of DBMetas. The tables and
+// the columns are matched by names case-insensitively, while the indexes and the foreign
+// keys are matched by definitions. The column types are compared by the type names and
+// lengths, so the two schemas should be read from the same kind of databases.
+func Diff(from, to []*Table) *SchemaDiff {
+	var diff SchemaDiff
+	fromTables := tablesByName(from)
+	toTables := tablesByName(to)
+
+	for _, name := range sortedTableNames(toTables) {
+		toTable := toTables[name]
+		fromTable, ok := fromTables[name]
+		if !ok {
+			diff.AddedTables = append(diff.AddedTables, toTable)
+			continue
+		}
+		if fromTable.IsView() != toTable.IsView() {
+			diff.DroppedTables = append(diff.DroppedTables, fromTable)
+			diff.AddedTables = append(diff.AddedTables, toTable)
+			continue
+		}
+		if tableDiff := DiffTable(fromTable, toTable); !tableDiff.IsEmpty() {
+			diff.ChangedTables = append(diff.ChangedTables, tableDiff)
+		}
+	}
+	for _, name := range sortedTableNames(fromTables) {
+		if _, ok := toTables[name]; !ok {
+			diff.DroppedTables = append(diff.DroppedTables, fromTables[name])
+		}
+	}
+	return &diff
+}
+
+// DiffTable compares two tables with the same name
+func DiffTable(from, to *Table) *TableDiff {
+	diff := &TableDiff{Name: to.Name, From: from, To: to}
+	if from.IsView() && to.IsView() {
+		diff.ViewChanged = !from.View.Equal(to.View)
+		return diff
+	}
+
+	for _, col := range to.Columns() {
+		fromCol := from.GetColumn(col.Name)
+		if fromCol == nil {
+			diff.AddedColumns = append(diff.AddedColumns, col)
+			continue
+		}
+		// the type parameters are the element types of the arrays
+		typeChanged := !strings.EqualFold(fromCol.SQLType.Name, col.SQLType.Name) || fromCol.Length != col.Length ||
+			fromCol.Length2 != col.Length2 || !equalFoldNames(fromCol.TypeParams, col.TypeParams)
+		colDiff := &ColumnDiff{
+			From:            fromCol,
+			To:              col,
+			TypeChanged:     typeChanged,
+			NullableChanged: fromCol.Nullable != col.Nullable,
+			DefaultChanged:  !col.IsAutoIncrement && (fromCol.DefaultIsEmpty != col.DefaultIsEmpty || fromCol.Default != col.Default),
+		}
+		if colDiff.TypeChanged || colDiff.NullableChanged || colDiff.DefaultChanged {
+			diff.ChangedColumns = append(diff.ChangedColumns, colDiff)
+		}
+	}
+	for _, col := range from.Columns() {
+		if to.GetColumn(col.Name) == nil {
+			diff.DroppedColumns = append(diff.DroppedColumns, col)
+		}
+	}
+
+	var matchedIndexes = make(map[*Index]bool)
+	for _, index := range to.SortedIndexes() {
+		var found bool
+		for _, fromIndex := range from.SortedIndexes() {
+			if !matchedIndexes[fromIndex] && index.Equal(fromIndex) {
+				matchedIndexes[fromIndex] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			diff.AddedIndexes = append(diff.AddedIndexes, index)
+		}
+	}
+	for _, fromIndex := range from.SortedIndexes() {
+		// some databases create the indexes of foreign keys automatically
+		if _, ok := from.ForeignKeys[fromIndex.Name]; ok {
+			continue
+		}
+		if !matchedIndexes[fromIndex] {
+			diff.DroppedIndexes = append(diff.DroppedIndexes, fromIndex)
+		}
+	}
+
+	var matchedFKs = make(map[*ForeignKey]bool)
+	for _, fk := range to.SortedForeignKeys() {
+		var found bool
+		for _, fromFK := range from.SortedForeignKeys() {
+			if !matchedFKs[fromFK] && fk.Equal(fromFK) {
+				matchedFKs[fromFK] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			diff.AddedForeignKeys = append(diff.AddedForeignKeys, fk)
+		}
+	}
+	for _, fromFK := range from.SortedForeignKeys() {
+		if !matchedFKs[fromFK] {
+			diff.DroppedForeignKeys = append(diff.DroppedForeignKeys, fromFK)
+		}
+	}
+
+	for _, check := range to.SortedChecks() {
+		fromCheck, ok := from.Checks[check.Name]
+		if ok && check.Equal(fromCheck) {
+			continue
+		}
+		if ok {
+			diff.DroppedChecks = append(diff.DroppedChecks, fromCheck)
+		}
+		diff.AddedChecks = append(diff.AddedChecks, check)
+	}
+	for _, fromCheck := range from.SortedChecks() {
+		if _, ok := to.Checks[fromCheck.Name]; !ok {
+			diff.DroppedChecks = append(diff.DroppedChecks, fromCheck)
+		}
+	}
+	return diff
+}
+
+func tablesByName(tables []*Table) map[string]*Table {
+	results := make(map[string]*Table, len(tables))
+	for _, table := range tables {
+		results[strings.ToLower(table.Name)] = table
+	}
+	return results
+}
+
+func sortedTableNames(tables map[string]*Table) []string {
+	names := make([]string, 0, len(tables))
+	for name := range tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package schemas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	newTable := func(name string, cols ...*Column) *Table {
+		table := NewTable(name, nil)
+		for _, col := range cols {
+			table.AddColumn(col)
+		}
+		return table
+	}
+	newColumn := func(name, tp string, nullable bool) *Column {
+		return &Column{Name: name, SQLType: SQLType{Name: tp}, Nullable: nullable, DefaultIsEmpty: true}
+	}
+
+	fromUser := newTable("user", newColumn("id", BigInt, false), newColumn("name", Varchar, true), newColumn("age", Int, true))
+	fromUser.AddIndex(&Index{IsRegular: true, Name: "name", Type: IndexType, Cols: []string{"name"}})
+	fromUser.AddCheck(NewCheck("CHK_user_age", "age >= 0"))
+
+	toUser := newTable("User", newColumn("id", BigInt, false), newColumn("name", Varchar, false), newColumn("email", Varchar, true))
+	toUser.AddIndex(&Index{IsRegular: true, Name: "name", Type: UniqueType, Cols: []string{"name"}})
+	toUser.AddCheck(NewCheck("CHK_user_age", "(age > 0)"))
+
+	diff := Diff(
+		[]*Table{fromUser, newTable("log", newColumn("id", BigInt, false))},
+		[]*Table{toUser, newTable("order", newColumn("id", BigInt, false))},
+	)
+	assert.False(t, diff.IsEmpty())
+	assert.Len(t, diff.AddedTables, 1)
+	assert.EqualValues(t, "order", diff.AddedTables[0].Name)
+	assert.Len(t, diff.DroppedTables, 1)
+	assert.EqualValues(t, "log", diff.DroppedTables[0].Name)
+
+	assert.Len(t, diff.ChangedTables, 1)
+	tableDiff := diff.ChangedTables[0]
+	assert.Len(t, tableDiff.AddedColumns, 1)
+	assert.EqualValues(t, "email", tableDiff.AddedColumns[0].Name)
+	assert.Len(t, tableDiff.DroppedColumns, 1)
+	assert.EqualValues(t, "age", tableDiff.DroppedColumns[0].Name)
+	assert.Len(t, tableDiff.ChangedColumns, 1)
+	assert.True(t, tableDiff.ChangedColumns[0].NullableChanged)
+	assert.False(t, tableDiff.ChangedColumns[0].TypeChanged)
+	assert.Len(t, tableDiff.AddedIndexes, 1)
+	assert.Len(t, tableDiff.DroppedIndexes, 1)
+	assert.Len(t, tableDiff.AddedChecks, 1)
+	assert.Len(t, tableDiff.DroppedChecks, 1)
+
+	assert.True(t, Diff([]*Table{fromUser}, []*Table{fromUser}).IsEmpty())
+}
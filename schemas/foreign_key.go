@@ -0,0 +1,130 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package schemas
+
+import (
+	"strings"
+)
+
+// enumerate all the referential actions of foreign keys
+const (
+	NoAction   = "NO ACTION"
+	Restrict   = "RESTRICT"
+	Cascade    = "CASCADE"
+	SetNull    = "SET NULL"
+	SetDefault = "SET DEFAULT"
+)
+
+// ForeignKey represents a foreign key constraint of a table
+type ForeignKey struct {
+	Name     string
+	Cols     []string
+	RefTable string
+	RefCols  []string
+	OnDelete string
+	OnUpdate string
+}
+
+// NewForeignKey new a foreign key object which references the table
+func NewForeignKey(name, refTable string) *ForeignKey {
+	return &ForeignKey{
+		Name:     name,
+		RefTable: refTable,
+		Cols:     make([]string, 0),
+		RefCols:  make([]string, 0),
+	}
+}
+
+// AddColumn adds a column and the column it references to the foreign key
+func (fk *ForeignKey) AddColumn(col, refCol string) {
+	fk.Cols = append(fk.Cols, col)
+	fk.RefCols = append(fk.RefCols, refCol)
+}
+
+// Equal return true if the two foreign keys have the same definition, the names are ignored
+func (fk *ForeignKey) Equal(dst *ForeignKey) bool {
+	if !strings.EqualFold(tableNameNoSchema(fk.RefTable), tableNameNoSchema(dst.RefTable)) {
+		return false
+	}
+	if !equalFoldNames(fk.Cols, dst.Cols) || !equalFoldNames(fk.RefCols, dst.RefCols) {
+		return false
+	}
+	return ReferentialAction(fk.OnDelete) == ReferentialAction(dst.OnDelete) &&
+		ReferentialAction(fk.OnUpdate) == ReferentialAction(dst.OnUpdate)
+}
+
+// ReferentialAction normalizes a referential action of foreign keys, i.e. set_null
+// will be SET NULL and an empty action will be NO ACTION
+func ReferentialAction(action string) string {
+	action = strings.ToUpper(strings.TrimSpace(strings.Trim(action, "'")))
+	action = strings.Join(strings.Fields(strings.ReplaceAll(action, "_", " ")), " ")
+	if action == "" {
+		return NoAction
+	}
+	return action
+}
+
+func tableNameNoSchema(tableName string) string {
+	tableParts := strings.Split(strings.ReplaceAll(tableName, `"`, ""), ".")
+	return tableParts[len(tableParts)-1]
+}
+
+func equalFoldNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !strings.EqualFold(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// References returns true if the table has a foreign key which references the other table
+func (table *Table) References(other *Table) bool {
+	if table == nil || other == nil {
+		return false
+	}
+	for _, fk := range table.ForeignKeys {
+		if strings.EqualFold(tableNameNoSchema(fk.RefTable), tableNameNoSchema(other.Name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// SortByForeignKeys returns the positions of the tables sorted so that every table
+// comes after the tables it references and the views come after all the tables. The
+// tables which reference each other in a cycle can only be partially sorted.
+func SortByForeignKeys(tables []*Table) []int {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	var (
+		states = make([]int, len(tables))
+		sorted = make([]int, 0, len(tables))
+		visit  func(i int)
+	)
+	visit = func(i int) {
+		if states[i] != 0 {
+			return
+		}
+		states[i] = visiting
+		for j, refTable := range tables {
+			if j != i && (tables[i].References(refTable) ||
+				tables[i].IsView() && refTable != nil && !refTable.IsView()) {
+				visit(j)
+			}
+		}
+		states[i] = visited
+		sorted = append(sorted, i)
+	}
+	for i := range tables {
+		visit(i)
+	}
+	return sorted
+}
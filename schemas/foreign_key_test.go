@@ -0,0 +1,52 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package schemas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForeignKeyEqual(t *testing.T) {
+	fk := NewForeignKey("user_id", "user")
+	fk.AddColumn("user_id", "id")
+	fk.OnDelete = "cascade"
+
+	fk2 := NewForeignKey("FK_post_user_id", `public."user"`)
+	fk2.AddColumn("USER_ID", "ID")
+	fk2.OnDelete = Cascade
+	fk2.OnUpdate = NoAction
+	assert.True(t, fk.Equal(fk2))
+
+	fk2.OnDelete = SetNull
+	assert.False(t, fk.Equal(fk2))
+
+	assert.EqualValues(t, SetNull, ReferentialAction("set_null"))
+	assert.EqualValues(t, SetDefault, ReferentialAction("'set default'"))
+	assert.EqualValues(t, NoAction, ReferentialAction(""))
+}
+
+func TestSortByForeignKeys(t *testing.T) {
+	newTable := func(name string, refTables ...string) *Table {
+		table := NewTable(name, nil)
+		for _, refTable := range refTables {
+			fk := NewForeignKey(refTable+"_id", refTable)
+			fk.AddColumn(refTable+"_id", "id")
+			table.AddForeignKey(fk)
+		}
+		return table
+	}
+
+	tables := []*Table{
+		newTable("comment", "post", "user"),
+		nil,
+		newTable("post", "user"),
+		newTable("user"),
+		newTable("a", "b"),
+		newTable("b", "a"),
+	}
+	assert.EqualValues(t, []int{3, 2, 0, 1, 5, 4}, SortByForeignKeys(tables))
+}
@@ -20,20 +20,36 @@ type Index struct {
 	IsRegular bool
 	Name      string
 	Type      int
-	Cols      []string
+	// Cols are the column names or the expressions like lower(email)
+	Cols []string
+	// Desc contains the columns which are sorted in descending order
+	Desc map[string]bool
+	// Lengths are the prefix lengths of the columns, mysql only
+	Lengths map[string]int
+	// Include are the covering columns which are not parts of the key, postgres and mssql only
+	Include []string
+	// Where is the predicate of a partial index, postgres, sqlite and mssql only
+	Where string
+	// Method is the index method like btree, hash, gin or brin, postgres and mysql only
+	Method string
 }
 
 // NewIndex new an index object
 func NewIndex(name string, indexType int) *Index {
-	return &Index{true, name, indexType, make([]string, 0)}
+	return &Index{IsRegular: true, Name: name, Type: indexType, Cols: make([]string, 0)}
+}
+
+// IsIndexExpr returns true if the index column is an expression but not a column name,
+// the expressions should contain parentheses like lower(email) or (a + b)
+func IsIndexExpr(col string) bool {
+	return strings.Contains(col, "(")
 }
 
 // XName returns the special index name for the table
 func (index *Index) XName(tableName string) string {
 	if !strings.HasPrefix(index.Name, "UQE_") &&
 		!strings.HasPrefix(index.Name, "IDX_") {
-		tableParts := strings.Split(strings.ReplaceAll(tableName, `"`, ""), ".")
-		tableName = tableParts[len(tableParts)-1]
+		tableName = tableNameNoSchema(tableName)
 		if index.Type == UniqueType {
 			return fmt.Sprintf("UQE_%v_%v", tableName, index.Name)
 		}
@@ -47,6 +63,48 @@ func (index *Index) AddColumn(cols ...string) {
 	index.Cols = append(index.Cols, cols...)
 }
 
+// SetDesc sets the columns to be sorted in descending order
+func (index *Index) SetDesc(cols ...string) {
+	if index.Desc == nil {
+		index.Desc = make(map[string]bool)
+	}
+	for _, col := range cols {
+		index.Desc[col] = true
+	}
+}
+
+// SetLength sets the prefix length of the column
+func (index *Index) SetLength(col string, length int) {
+	if index.Lengths == nil {
+		index.Lengths = make(map[string]int)
+	}
+	index.Lengths[col] = length
+}
+
+// AddInclude adds the covering columns
+func (index *Index) AddInclude(cols ...string) {
+	index.Include = append(index.Include, cols...)
+}
+
+// HasInclude returns true if the column is a covering column of the index
+func (index *Index) HasInclude(col string) bool {
+	for _, c := range index.Include {
+		if c == col {
+			return true
+		}
+	}
+	return false
+}
+
+func (index *Index) colIndex(col string) int {
+	for i, c := range index.Cols {
+		if c == col || IsIndexExpr(c) && IsIndexExpr(col) && normalizeExpr(c) == normalizeExpr(col) {
+			return i
+		}
+	}
+	return -1
+}
+
 // Equal return true if the two Index is equal
 func (index *Index) Equal(dst *Index) bool {
 	if index.Type != dst.Type {
@@ -57,16 +115,33 @@ func (index *Index) Equal(dst *Index) bool {
 	}
 
 	for i := 0; i < len(index.Cols); i++ {
-		var found bool
-		for j := 0; j < len(dst.Cols); j++ {
-			if index.Cols[i] == dst.Cols[j] {
-				found = true
-				break
-			}
+		j := dst.colIndex(index.Cols[i])
+		if j < 0 {
+			return false
 		}
-		if !found {
+		if index.Desc[index.Cols[i]] != dst.Desc[dst.Cols[j]] ||
+			index.Lengths[index.Cols[i]] != dst.Lengths[dst.Cols[j]] {
 			return false
 		}
 	}
-	return true
+
+	if len(index.Include) != len(dst.Include) {
+		return false
+	}
+	for _, col := range index.Include {
+		if !dst.HasInclude(col) {
+			return false
+		}
+	}
+
+	return normalizeExpr(index.Where) == normalizeExpr(dst.Where) &&
+		indexMethod(index.Method) == indexMethod(dst.Method)
+}
+
+// indexMethod returns the index method in lower case, btree is the default method
+func indexMethod(method string) string {
+	if method == "" {
+		return "btree"
+	}
+	return strings.ToLower(method)
 }
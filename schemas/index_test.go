@@ -0,0 +1,42 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package schemas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexEqual(t *testing.T) {
+	index := NewIndex("email", UniqueType)
+	index.AddColumn("lower(email)", "tenant_id")
+	index.SetDesc("tenant_id")
+	index.Where = "deleted_at IS NULL"
+
+	dbIndex := NewIndex("UQE_user_email", UniqueType)
+	dbIndex.AddColumn("tenant_id", "lower((email)::text)")
+	dbIndex.SetDesc("tenant_id")
+	dbIndex.Where = "(deleted_at IS NULL)"
+	dbIndex.Method = "btree"
+	assert.True(t, index.Equal(dbIndex))
+
+	dbIndex.Desc = nil
+	assert.False(t, index.Equal(dbIndex))
+	dbIndex.SetDesc("tenant_id")
+
+	dbIndex.Where = ""
+	assert.False(t, index.Equal(dbIndex))
+	dbIndex.Where = index.Where
+
+	dbIndex.Method = "hash"
+	assert.False(t, index.Equal(dbIndex))
+	dbIndex.Method = ""
+
+	dbIndex.AddInclude("name")
+	assert.False(t, index.Equal(dbIndex))
+	index.AddInclude("name")
+	assert.True(t, index.Equal(dbIndex))
+}
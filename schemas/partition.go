@@ -0,0 +1,74 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package schemas
+
+// enumerate all the partitioning types
+const (
+	RangePartitioning = "RANGE"
+	ListPartitioning  = "LIST"
+	HashPartitioning  = "HASH"
+)
+
+// Partitioning represents how a table is partitioned
+type Partitioning struct {
+	Type string
+	// Cols are the column names or the expressions like year(created) of the partition key
+	Cols []string
+	// Partitions are the partitions created with the table
+	Partitions []*Partition
+}
+
+// NewPartitioning new a partitioning object with the partition key
+func NewPartitioning(partitioningType string, cols ...string) *Partitioning {
+	return &Partitioning{Type: partitioningType, Cols: cols}
+}
+
+// AddPartition adds the partitions which will be created with the table
+func (p *Partitioning) AddPartition(partitions ...*Partition) *Partitioning {
+	p.Partitions = append(p.Partitions, partitions...)
+	return p
+}
+
+// Partition represents a partition of a table. The bounds are SQL literals like
+// '2024-01-01' or MAXVALUE, and only the bounds of the table's partitioning type
+// should be set. MySQL range partitions have no lower bounds.
+type Partition struct {
+	Name string
+	// From and To are the bounds of a range partition, From is inclusive and To is exclusive
+	From string
+	To   string
+	// In are the values of a list partition
+	In []string
+	// Modulus and Remainder are the bounds of a hash partition, postgres only
+	Modulus   int
+	Remainder int
+	// Default is true if the partition holds the rows which don't fit into other partitions, postgres only
+	Default bool
+}
+
+// RangePartition new a range partition with the bounds
+func RangePartition(name, from, to string) *Partition {
+	return &Partition{Name: name, From: from, To: to}
+}
+
+// ListPartition new a list partition with the values
+func ListPartition(name string, values ...string) *Partition {
+	return &Partition{Name: name, In: values}
+}
+
+// HashPartition new a hash partition with the modulus and the remainder
+func HashPartition(name string, modulus, remainder int) *Partition {
+	return &Partition{Name: name, Modulus: modulus, Remainder: remainder}
+}
+
+// DefaultPartition new a default partition
+func DefaultPartition(name string) *Partition {
+	return &Partition{Name: name, Default: true}
+}
+
+// IsPartitioned returns true if the table is partitioned
+func (table *Table) IsPartitioned() bool {
+	return table != nil && table.Partitioning != nil
+}
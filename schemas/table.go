@@ -6,6 +6,7 @@ package schemas
 
 import (
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -18,6 +19,10 @@ type Table struct {
 	columnsMap    map[string][]*Column
 	columns       []*Column
 	Indexes       map[string]*Index
+	ForeignKeys   map[string]*ForeignKey
+	Checks        map[string]*Check
+	View          *View         // not nil if the table is a view
+	Partitioning  *Partitioning // not nil if the table is partitioned
 	PrimaryKeys   []string
 	AutoIncrement string
 	Created       map[string]bool
@@ -41,6 +46,8 @@ func NewTable(name string, t reflect.Type) *Table {
 		columns:     make([]*Column, 0),
 		columnsMap:  make(map[string][]*Column),
 		Indexes:     make(map[string]*Index),
+		ForeignKeys: make(map[string]*ForeignKey),
+		Checks:      make(map[string]*Check),
 		Created:     make(map[string]bool),
 		PrimaryKeys: make([]string, 0),
 	}
@@ -146,11 +153,107 @@ func (table *Table) AddColumn(col *Column) {
 	}
 }
 
+// RenameColumn renames the column and the references of the column in the indexes and
+// the foreign keys of the table
+func (table *Table) RenameColumn(oldName, newName string) {
+	col := table.GetColumn(oldName)
+	if col == nil {
+		return
+	}
+	renameName := func(name string) string {
+		if strings.EqualFold(name, oldName) {
+			return newName
+		}
+		return name
+	}
+	renameNames := func(names []string) {
+		for i, name := range names {
+			names[i] = renameName(name)
+		}
+	}
+
+	col.Name = newName
+	renameNames(table.columnsSeq)
+	delete(table.columnsMap, strings.ToLower(oldName))
+	table.columnsMap[strings.ToLower(newName)] = append(table.columnsMap[strings.ToLower(newName)], col)
+	renameNames(table.PrimaryKeys)
+	table.AutoIncrement = renameName(table.AutoIncrement)
+	if table.Created[oldName] {
+		delete(table.Created, oldName)
+		table.Created[newName] = true
+	}
+	table.Updated = renameName(table.Updated)
+	table.Deleted = renameName(table.Deleted)
+	table.Version = renameName(table.Version)
+
+	for _, index := range table.Indexes {
+		renameNames(index.Cols)
+		renameNames(index.Include)
+		if desc, ok := index.Desc[oldName]; ok {
+			delete(index.Desc, oldName)
+			index.Desc[newName] = desc
+		}
+		if length, ok := index.Lengths[oldName]; ok {
+			delete(index.Lengths, oldName)
+			index.Lengths[newName] = length
+		}
+	}
+	for _, fk := range table.ForeignKeys {
+		renameNames(fk.Cols)
+	}
+}
+
 // AddIndex adds an index or an unique to table
 func (table *Table) AddIndex(index *Index) {
 	table.Indexes[index.Name] = index
 }
 
+// AddForeignKey adds a foreign key to table
+func (table *Table) AddForeignKey(fk *ForeignKey) {
+	table.ForeignKeys[fk.Name] = fk
+}
+
+// AddCheck adds a check constraint to table
+func (table *Table) AddCheck(check *Check) {
+	table.Checks[check.Name] = check
+}
+
+// SortedIndexes returns the indexes of the table in name order
+func (table *Table) SortedIndexes() []*Index {
+	indexes := make([]*Index, 0, len(table.Indexes))
+	for _, index := range table.Indexes {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return indexes[i].Name < indexes[j].Name
+	})
+	return indexes
+}
+
+// SortedForeignKeys returns the foreign keys of the table in name order
+func (table *Table) SortedForeignKeys() []*ForeignKey {
+	fks := make([]*ForeignKey, 0, len(table.ForeignKeys))
+	for _, fk := range table.ForeignKeys {
+		fks = append(fks, fk)
+	}
+	sort.Slice(fks, func(i, j int) bool {
+		return fks[i].Name < fks[j].Name
+	})
+	return fks
+}
+
+// SortedChecks returns the check constraints of the table in name order
+func (table *Table) SortedChecks() []*Check {
+	checks := make([]*Check, 0, len(table.Checks))
+	for _, check := range table.Checks {
+		checks = append(checks, check)
+	}
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].Name < checks[j].Name
+	})
+	return checks
+}
+
 // IDOfV get id from one value of struct
 func (table *Table) IDOfV(rv reflect.Value) (PK, error) {
 	v := reflect.Indirect(rv)
@@ -23,6 +23,7 @@ const (
 	MSSQL    DBType = "mssql"
 	ORACLE   DBType = "oracle"
 	DAMENG   DBType = "dameng"
+	DUCKDB   DBType = "duckdb"
 )
 
 // SQLType represents SQL types
@@ -163,6 +164,20 @@ var (
 	XML   = "XML"
 	Array = "ARRAY"
 
+	// the nested types of duckdb, the values are read and written as JSON
+	List   = "LIST"
+	Struct = "STRUCT"
+	Map    = "MAP"
+
+	// the types of postgres, the ranges are read and written as the text like [1,10)
+	Hstore    = "HSTORE"
+	Int4Range = "INT4RANGE"
+	Int8Range = "INT8RANGE"
+	NumRange  = "NUMRANGE"
+	TsRange   = "TSRANGE"
+	TsTzRange = "TSTZRANGE"
+	DateRange = "DATERANGE"
+
 	SqlTypes = map[string]int{
 		Bit:               NUMERIC_TYPE,
 		UnsignedBit:       NUMERIC_TYPE,
@@ -186,6 +201,18 @@ var (
 
 		XML: TEXT_TYPE,
 
+		List:   TEXT_TYPE,
+		Struct: TEXT_TYPE,
+		Map:    TEXT_TYPE,
+
+		Hstore:    TEXT_TYPE,
+		Int4Range: TEXT_TYPE,
+		Int8Range: TEXT_TYPE,
+		NumRange:  TEXT_TYPE,
+		TsRange:   TEXT_TYPE,
+		TsTzRange: TEXT_TYPE,
+		DateRange: TEXT_TYPE,
+
 		Char:       TEXT_TYPE,
 		NChar:      TEXT_TYPE,
 		Varchar:    TEXT_TYPE,
@@ -0,0 +1,32 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package schemas
+
+import "strings"
+
+// View represents the definition of a view or a materialized view
+type View struct {
+	Query        string
+	Materialized bool
+}
+
+// NewView new a view object with the query
+func NewView(query string, materialized bool) *View {
+	return &View{Query: query, Materialized: materialized}
+}
+
+// Equal return true if the two views have the same query. Databases may rewrite the
+// queries, so the quotes, parentheses, spaces, postgres type casts and the trailing
+// semicolons are ignored.
+func (view *View) Equal(dst *View) bool {
+	return view.Materialized == dst.Materialized &&
+		normalizeExpr(strings.TrimSuffix(strings.TrimSpace(view.Query), ";")) ==
+			normalizeExpr(strings.TrimSuffix(strings.TrimSpace(dst.Query), ";"))
+}
+
+// IsView returns true if the table is a view or a materialized view
+func (table *Table) IsView() bool {
+	return table != nil && table.View != nil
+}
@@ -0,0 +1,22 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package schemas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewEqual(t *testing.T) {
+	view := NewView("SELECT id, name FROM user WHERE active = 1", false)
+	assert.True(t, view.Equal(NewView(" SELECT id,\n    name\n   FROM \"user\"\n  WHERE (active = 1);", false)))
+	assert.False(t, view.Equal(NewView("SELECT id, name FROM user WHERE active = 1", true)))
+	assert.False(t, view.Equal(NewView("SELECT id FROM user WHERE active = 1", false)))
+
+	tables := []*Table{NewTable("user_view", nil), NewTable("user", nil)}
+	tables[0].View = view
+	assert.EqualValues(t, []int{1, 0}, SortByForeignKeys(tables))
+}
@@ -21,6 +21,7 @@ import (
 	"xorm.io/xorm/contexts"
 	"xorm.io/xorm/convert"
 	"xorm.io/xorm/core"
+	"xorm.io/xorm/dialects"
 	"xorm.io/xorm/internal/json"
 	"xorm.io/xorm/internal/statements"
 	"xorm.io/xorm/log"
@@ -206,6 +207,14 @@ func (session *Session) IsClosed() bool {
 	return session.isClosed
 }
 
+// checkWritable returns an error if the table of the statement is a view
+func (session *Session) checkWritable() error {
+	if session.statement.RefTable.IsView() {
+		return fmt.Errorf("%w: %s", ErrViewNotWritable, session.statement.TableName())
+	}
+	return nil
+}
+
 func (session *Session) resetStatement() {
 	if session.autoResetStatement {
 		session.statement.Reset()
@@ -243,6 +252,13 @@ func (session *Session) Table(tableNameOrBean interface{}) *Session {
 	return session
 }
 
+// Schema sets the schema of the tables for the next operation, it overrides the schema
+// of the engine and the bean's TableSchema
+func (session *Session) Schema(schema string) *Session {
+	session.statement.Schema = schema
+	return session
+}
+
 // Alias set the table alias
 func (session *Session) Alias(alias string) *Session {
 	session.statement.Alias(alias)
@@ -466,6 +482,71 @@ func (session *Session) row2Slice(rows *core.Rows, fields []string, types []*sql
 	return scanResults, nil
 }
 
+// setPgArray sets the slice field by the literal of a postgres array
+func (session *Session) setPgArray(col *schemas.Column, fieldValue *reflect.Value, scanResult interface{}) error {
+	bs, ok := convert.AsBytes(scanResult)
+	if !ok {
+		return fmt.Errorf("unsupported database data type: %#v", scanResult)
+	}
+	elements, err := dialects.ParsePgArray(string(bs))
+	if err != nil {
+		return err
+	}
+
+	dbTZ := session.engine.DatabaseTZ
+	if col.TimeZone != nil {
+		dbTZ = col.TimeZone
+	}
+	slice := reflect.MakeSlice(fieldValue.Type(), len(elements), len(elements))
+	for i, element := range elements {
+		if element == nil {
+			continue
+		}
+		v := slice.Index(i)
+		if v.Kind() == reflect.Ptr {
+			v.Set(reflect.New(v.Type().Elem()))
+			v = v.Elem()
+		}
+		if v.Type().ConvertibleTo(schemas.TimeType) {
+			t, err := dialects.ParsePgTime(*element, dbTZ)
+			if err != nil {
+				return err
+			}
+			v.Set(reflect.ValueOf(t.In(session.engine.TZLocation)).Convert(v.Type()))
+		} else if err := convert.AssignValue(v, *element); err != nil {
+			return err
+		}
+	}
+	fieldValue.Set(slice)
+	return nil
+}
+
+// setHstore sets the map field by the literal of a hstore
+func setHstore(fieldValue *reflect.Value, scanResult interface{}) error {
+	bs, ok := convert.AsBytes(scanResult)
+	if !ok {
+		return fmt.Errorf("unsupported database data type: %#v", scanResult)
+	}
+	pairs, err := dialects.ParseHstore(string(bs))
+	if err != nil {
+		return err
+	}
+
+	fieldType := fieldValue.Type()
+	m := reflect.MakeMapWithSize(fieldType, len(pairs))
+	for k, v := range pairs {
+		value := reflect.New(fieldType.Elem()).Elem()
+		if v != nil {
+			if err := convert.AssignValue(value, *v); err != nil {
+				return err
+			}
+		}
+		m.SetMapIndex(reflect.ValueOf(k).Convert(fieldType.Key()), value)
+	}
+	fieldValue.Set(m)
+	return nil
+}
+
 func setJSON(fieldValue *reflect.Value, fieldType reflect.Type, scanResult interface{}) error {
 	bs, ok := convert.AsBytes(scanResult)
 	if !ok {
@@ -600,7 +681,14 @@ func (session *Session) convertBeanField(col *schemas.Column, fieldValue *reflec
 		return nil
 	case reflect.Complex64, reflect.Complex128:
 		return setJSON(fieldValue, fieldType, scanResult)
+	case reflect.Map:
+		if col.SQLType.Name == schemas.Hstore {
+			return setHstore(fieldValue, scanResult)
+		}
 	case reflect.Slice:
+		if col.SQLType.IsArray() {
+			return session.setPgArray(col, fieldValue, scanResult)
+		}
 		bs, ok := convert.AsBytes(scanResult)
 		if ok && fieldType.Elem().Kind() == reflect.Uint8 {
 			if col.SQLType.IsText() {
@@ -0,0 +1,271 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xorm
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"xorm.io/xorm/core"
+	"xorm.io/xorm/dialects"
+	"xorm.io/xorm/internal/utils"
+	"xorm.io/xorm/schemas"
+)
+
+// BulkLoad inserts the records of the slice in bulk and returns the number of the loaded records.
+// The records are streamed by COPY FROM STDIN on the postgres drivers and inserted by multiple
+// records INSERT in chunks on the others. For the throughput the insert processors are not called
+// and the autoincrement ids are not filled back into the records. The connection of a transaction
+// is not reachable by CopyFrom of pgx, so the records are inserted in chunks when the session with
+// the pgx driver is in a transaction.
+func (session *Session) BulkLoad(rowsSlicePtr interface{}) (int64, error) {
+	if session.isAutoClose {
+		defer session.Close()
+	}
+
+	session.autoResetStatement = false
+	defer func() {
+		session.autoResetStatement = true
+		session.resetStatement()
+	}()
+
+	sliceValue := reflect.Indirect(reflect.ValueOf(rowsSlicePtr))
+	if sliceValue.Kind() != reflect.Slice {
+		return 0, ErrPtrSliceType
+	}
+	size := sliceValue.Len()
+	if size == 0 {
+		return 0, ErrNoElementsOnSlice
+	}
+
+	if err := session.statement.SetRefBean(sliceValue.Index(0).Interface()); err != nil {
+		return 0, err
+	}
+	if err := session.checkWritable(); err != nil {
+		return 0, err
+	}
+	tableName := session.statement.TableName()
+	if len(tableName) == 0 {
+		return 0, ErrTableNotFound
+	}
+
+	elemValue := func(i int) reflect.Value {
+		v := sliceValue.Index(i)
+		if v.Kind() == reflect.Interface {
+			v = v.Elem()
+		}
+		return reflect.Indirect(v)
+	}
+
+	var (
+		table    = session.statement.RefTable
+		first    = elemValue(0)
+		cols     []*schemas.Column
+		seqCol   string
+		nextval  string
+		autoTime = make(map[string]time.Time)
+		autoVals = make(map[string]interface{})
+	)
+	for _, col := range table.Columns() {
+		if col.MapType == schemas.ONLYFROMDB || col.IsDeleted {
+			continue
+		}
+		if col.IsAutoIncrement {
+			fieldValue, err := col.ValueOfV(&first)
+			if err != nil {
+				return 0, err
+			}
+			if utils.IsZero(fieldValue.Interface()) {
+				if nextval = dialects.AutoincrNextvalSQL(session.engine.dialect, tableName, col); nextval != "" {
+					seqCol = col.Name
+				}
+				continue
+			}
+		}
+		if session.statement.OmitColumnMap.Contain(col.Name) {
+			continue
+		}
+		if len(session.statement.ColumnMap) > 0 && !session.statement.ColumnMap.Contain(col.Name) {
+			continue
+		}
+		// the created and updated times are the same for all the records
+		if (col.IsCreated || col.IsUpdated) && session.statement.UseAutoTime {
+			val, t, err := session.engine.nowTime(col)
+			if err != nil {
+				return 0, err
+			}
+			autoVals[col.Name] = val
+			autoTime[col.Name] = t
+		}
+		cols = append(cols, col)
+	}
+
+	rowArgs := func(i int) ([]interface{}, error) {
+		vv := elemValue(i)
+		args := make([]interface{}, 0, len(cols))
+		for _, col := range cols {
+			if t, ok := autoTime[col.Name]; ok {
+				setColumnTime(vv.Addr().Interface(), col, t)
+				args = append(args, autoVals[col.Name])
+				continue
+			}
+			if col.IsVersion && session.statement.CheckVersion {
+				setColumnInt(vv.Addr().Interface(), col, 1)
+				args = append(args, 1)
+				continue
+			}
+
+			ptrFieldValue, err := col.ValueOfV(&vv)
+			if err != nil {
+				return nil, err
+			}
+			fieldValue := *ptrFieldValue
+			if _, ok := getFlagForColumn(session.statement.NullableMap, col); ok {
+				if col.Nullable && utils.IsValueZero(fieldValue) {
+					var nilValue *int
+					fieldValue = reflect.ValueOf(nilValue)
+				}
+			}
+			arg, err := session.statement.Value2Interface(col, fieldValue)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+		}
+		return args, nil
+	}
+
+	quoter := session.engine.dialect.Quoter()
+	colNames := make([]string, 0, len(cols)+1)
+	for _, col := range cols {
+		colNames = append(colNames, col.Name)
+	}
+
+	// COPY cannot insert the next values of the sequence bound by the seq tag
+	if loader, ok := session.engine.driver.(dialects.BulkLoader); ok && seqCol == "" {
+		var tx *core.Tx
+		if !session.isAutoCommit {
+			tx = session.tx
+		}
+		quotedNames := make([]string, 0, len(colNames))
+		for _, colName := range colNames {
+			quotedNames = append(quotedNames, quoter.Quote(colName))
+		}
+
+		var i int
+		loaded, err := loader.BulkLoad(session.ctx, session.DB(), tx, quoter.Quote(tableName), quotedNames, func() ([]interface{}, error) {
+			if i >= size {
+				return nil, io.EOF
+			}
+			i++
+			return rowArgs(i - 1)
+		})
+		if err != dialects.ErrBulkLoadUnsupported {
+			if err != nil {
+				return 0, err
+			}
+			_ = session.cacheInsert(tableName)
+			return loaded, nil
+		}
+		session.engine.logger.Warnf("[bulk] COPY is unavailable for %v on driver %v, the records are inserted in chunks", tableName, session.engine.DriverName())
+	}
+
+	places := strings.TrimSuffix(strings.Repeat("?, ", len(cols)), ", ")
+	if seqCol != "" {
+		colNames = append(colNames, seqCol)
+		if len(places) > 0 {
+			places += ", "
+		}
+		places += nextval
+	}
+
+	chunkSize := bulkInsertChunkSize(session.engine.dialect.URI().DBType, len(cols))
+	// the nextval of the sequence is evaluated once in an INSERT ALL statement of oracle
+	if seqCol != "" && session.engine.dialect.URI().DBType == schemas.ORACLE {
+		chunkSize = 1
+	}
+
+	// the chunks are inserted in a transaction unless the session is already in one
+	ownTx := session.isAutoCommit
+	if ownTx {
+		if err := session.Begin(); err != nil {
+			return 0, err
+		}
+		// it's a no-op when the transaction has been committed
+		defer func() {
+			_ = session.Rollback()
+		}()
+	}
+
+	var loaded int64
+	for start := 0; start < size; start += chunkSize {
+		end := start + chunkSize
+		if end > size {
+			end = size
+		}
+		args := make([]interface{}, 0, (end-start)*len(cols))
+		for i := start; i < end; i++ {
+			values, err := rowArgs(i)
+			if err != nil {
+				return 0, err
+			}
+			args = append(args, values...)
+		}
+
+		res, err := session.exec(genBulkInsertSQL(session.engine.dialect, tableName, colNames, places, end-start), args...)
+		if err != nil {
+			return 0, err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		loaded += affected
+	}
+	if ownTx {
+		if err := session.Commit(); err != nil {
+			return 0, err
+		}
+	}
+	_ = session.cacheInsert(tableName)
+	return loaded, nil
+}
+
+// bulkInsertChunkSize returns the number of the records of every INSERT statement,
+// so that the number of the arguments is under the limit of the database
+func bulkInsertChunkSize(dbType schemas.DBType, colsPerRow int) int {
+	maxArgs := 65535
+	switch dbType {
+	case schemas.MSSQL:
+		maxArgs = 2000
+	case schemas.SQLITE:
+		maxArgs = 999
+	}
+	chunkSize := 1000
+	if colsPerRow > 0 && maxArgs/colsPerRow < chunkSize {
+		chunkSize = maxArgs / colsPerRow
+	}
+	if chunkSize < 1 {
+		chunkSize = 1
+	}
+	return chunkSize
+}
+
+// genBulkInsertSQL generates the INSERT statement of the records whose values are the places
+func genBulkInsertSQL(dialect dialects.Dialect, tableName string, colNames []string, places string, rows int) string {
+	quoter := dialect.Quoter()
+	colStr := quoter.Join(colNames, ",")
+	if dialect.URI().DBType == schemas.ORACLE {
+		values := strings.TrimSuffix(strings.Repeat(fmt.Sprintf("INTO %s (%v) VALUES (%v) ", quoter.Quote(tableName), colStr, places), rows), " ")
+		return fmt.Sprintf("INSERT ALL %s SELECT 1 FROM DUAL", values)
+	}
+	return fmt.Sprintf("INSERT INTO %s (%v) VALUES (%v)",
+		quoter.Quote(tableName),
+		colStr,
+		strings.TrimSuffix(strings.Repeat(places+"),(", rows), "),("))
+}
@@ -81,6 +81,13 @@ func (session *Session) SetExpr(column string, expression interface{}) *Session
 	return session
 }
 
+// SetJSON provides a update string like "column = json_set(column, path, value)", only the value
+// at the path of the JSON column is updated. The column should not be set by SetExpr at the same time.
+func (session *Session) SetJSON(column, path string, value interface{}) *Session {
+	session.statement.SetJSON(column, path, value)
+	return session
+}
+
 // Select provides some columns to special
 func (session *Session) Select(str string) *Session {
 	session.statement.Select(str)
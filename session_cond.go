@@ -49,6 +49,24 @@ func (session *Session) NotIn(column string, args ...interface{}) *Session {
 	return session
 }
 
+// ArrayContains provides a query string like "tags @> ?" for the postgres array column
+func (session *Session) ArrayContains(column string, values interface{}) *Session {
+	session.statement.ArrayContains(column, values)
+	return session
+}
+
+// ArrayOverlaps provides a query string like "tags && ?" for the postgres array column
+func (session *Session) ArrayOverlaps(column string, values interface{}) *Session {
+	session.statement.ArrayOverlaps(column, values)
+	return session
+}
+
+// InArray provides a query string like "id = ANY(?)" which passes the values as one postgres array
+func (session *Session) InArray(column string, values interface{}) *Session {
+	session.statement.InArray(column, values)
+	return session
+}
+
 // Conds returns session query conditions except auto bean conditions
 func (session *Session) Conds() builder.Cond {
 	return session.statement.Conds()
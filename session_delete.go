@@ -110,6 +110,9 @@ func (session *Session) Delete(beans ...interface{}) (int64, error) {
 		if err = session.statement.SetRefBean(bean); err != nil {
 			return 0, err
 		}
+		if err = session.checkWritable(); err != nil {
+			return 0, err
+		}
 
 		executeBeforeClosures(session, bean)
 
@@ -164,7 +167,7 @@ func (session *Session) Delete(beans ...interface{}) (int64, error) {
 			}
 			fmt.Fprintf(orderCondWriter, "ctid IN (SELECT ctid FROM %s%s)", tableName, orderSQLWriter.String())
 			orderCondWriter.Append(orderSQLWriter.Args()...)
-		case schemas.SQLITE:
+		case schemas.SQLITE, schemas.DUCKDB:
 			if condWriter.Len() > 0 {
 				fmt.Fprintf(orderCondWriter, " AND ")
 			} else {
@@ -5,6 +5,7 @@
 package xorm
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"reflect"
@@ -78,6 +79,9 @@ func (session *Session) insertMultipleStruct(rowsSlicePtr interface{}) (int64, e
 	if err := session.statement.SetRefBean(sliceValue.Index(0).Interface()); err != nil {
 		return 0, err
 	}
+	if err := session.checkWritable(); err != nil {
+		return 0, err
+	}
 
 	tableName := session.statement.TableName()
 	if len(tableName) == 0 {
@@ -90,6 +94,7 @@ func (session *Session) insertMultipleStruct(rowsSlicePtr interface{}) (int64, e
 		colNames       []string
 		colMultiPlaces []string
 		args           []interface{}
+		rowArgStarts   []int
 	)
 
 	for i := 0; i < size; i++ {
@@ -103,6 +108,7 @@ func (session *Session) insertMultipleStruct(rowsSlicePtr interface{}) (int64, e
 		}
 		elemValue := v.Interface()
 		var colPlaces []string
+		rowArgStarts = append(rowArgStarts, len(args))
 
 		// handle BeforeInsertProcessor
 		// !nashtsai! does user expect it's same slice to passed closure when using Before()/After() when insert multi??
@@ -122,11 +128,11 @@ func (session *Session) insertMultipleStruct(rowsSlicePtr interface{}) (int64, e
 			}
 			fieldValue := *ptrFieldValue
 			if col.IsAutoIncrement && utils.IsZero(fieldValue.Interface()) {
-				if session.engine.dialect.Features().AutoincrMode == dialects.SequenceAutoincrMode {
+				if nextval := dialects.AutoincrNextvalSQL(session.engine.dialect, tableName, col); nextval != "" {
 					if i == 0 {
 						colNames = append(colNames, col.Name)
 					}
-					colPlaces = append(colPlaces, utils.SeqName(tableName)+".nextval")
+					colPlaces = append(colPlaces, nextval)
 				}
 				continue
 			}
@@ -187,25 +193,53 @@ func (session *Session) insertMultipleStruct(rowsSlicePtr interface{}) (int64, e
 	cleanupProcessorsClosures(&session.beforeClosures)
 
 	quoter := session.engine.dialect.Quoter()
-	var sql string
+	var sqlStr string
 	colStr := quoter.Join(colNames, ",")
 	if session.engine.dialect.URI().DBType == schemas.ORACLE {
 		temp := fmt.Sprintf(") INTO %s (%v) VALUES (",
 			quoter.Quote(tableName),
 			colStr)
-		sql = fmt.Sprintf("INSERT ALL INTO %s (%v) VALUES (%v) SELECT 1 FROM DUAL",
+		sqlStr = fmt.Sprintf("INSERT ALL INTO %s (%v) VALUES (%v) SELECT 1 FROM DUAL",
 			quoter.Quote(tableName),
 			colStr,
 			strings.Join(colMultiPlaces, temp))
 	} else {
-		sql = fmt.Sprintf("INSERT INTO %s (%v) VALUES (%v)",
+		sqlStr = fmt.Sprintf("INSERT INTO %s (%v) VALUES (%v)",
 			quoter.Quote(tableName),
 			colStr,
 			strings.Join(colMultiPlaces, "),("))
 	}
-	res, err := session.exec(sql, args...)
-	if err != nil {
-		return 0, err
+	var affected int64
+	if len(table.AutoIncrement) > 0 && session.engine.dialect.URI().DBType == schemas.ORACLE {
+		// INSERT ALL cannot return the ids and evaluates nextval once, so the records are inserted
+		// one by one and the ids are returned into the out parameters
+		ids, err := session.insertOracleRows(tableName, table, colStr, colMultiPlaces, args, rowArgStarts)
+		if err != nil {
+			return 0, err
+		}
+		if err := session.setAutoIncrIDs(table, sliceValue, ids); err != nil {
+			return 0, err
+		}
+		affected = int64(len(ids))
+	} else if len(table.AutoIncrement) > 0 && session.engine.dialect.Features().SupportReturning {
+		// the ids of the inserted records are returned in the order of the values
+		sqlStr += " RETURNING " + quoter.Quote(table.AutoIncrement)
+		ids, err := session.queryInt64s(sqlStr, args...)
+		if err != nil {
+			return 0, err
+		}
+		if err := session.setAutoIncrIDs(table, sliceValue, ids); err != nil {
+			return 0, err
+		}
+		affected = int64(len(ids))
+	} else {
+		res, err := session.exec(sqlStr, args...)
+		if err != nil {
+			return 0, err
+		}
+		if affected, err = res.RowsAffected(); err != nil {
+			return 0, err
+		}
 	}
 
 	_ = session.cacheInsert(tableName)
@@ -241,7 +275,94 @@ func (session *Session) insertMultipleStruct(rowsSlicePtr interface{}) (int64, e
 	}
 
 	cleanupProcessorsClosures(&session.afterClosures)
-	return res.RowsAffected()
+	return affected, nil
+}
+
+// queryInt64s returns the first column of all the rows
+func (session *Session) queryInt64s(sqlStr string, args ...interface{}) ([]int64, error) {
+	rows, err := session.queryRows(sqlStr, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		res = append(res, id)
+	}
+	return res, rows.Err()
+}
+
+// insertOracleRows inserts the records one by one in a transaction, the ids are returned into
+// the out parameters by RETURNING INTO
+func (session *Session) insertOracleRows(tableName string, table *schemas.Table, colStr string, colMultiPlaces []string, args []interface{}, rowArgStarts []int) ([]int64, error) {
+	ownTx := session.isAutoCommit
+	if ownTx {
+		if err := session.Begin(); err != nil {
+			return nil, err
+		}
+		// it's a no-op when the transaction has been committed
+		defer func() {
+			_ = session.Rollback()
+		}()
+	}
+
+	quoter := session.engine.dialect.Quoter()
+	ids := make([]int64, 0, len(colMultiPlaces))
+	for i, places := range colMultiPlaces {
+		end := len(args)
+		if i+1 < len(rowArgStarts) {
+			end = rowArgStarts[i+1]
+		}
+		var id int64
+		sqlStr := fmt.Sprintf("INSERT INTO %s (%v) VALUES (%v) RETURNING %s INTO ?",
+			quoter.Quote(tableName),
+			colStr,
+			places,
+			quoter.Quote(table.AutoIncrement))
+		rowArgs := append(args[rowArgStarts[i]:end:end], sql.Out{Dest: &id})
+		if _, err := session.exec(sqlStr, rowArgs...); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if ownTx {
+		if err := session.Commit(); err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+// setAutoIncrIDs assigns the returned ids to the autoincrement fields of the inserted records
+func (session *Session) setAutoIncrIDs(table *schemas.Table, sliceValue reflect.Value, ids []int64) error {
+	if len(ids) != sliceValue.Len() {
+		return fmt.Errorf("%d records inserted but %d ids returned", sliceValue.Len(), len(ids))
+	}
+	col := table.AutoIncrColumn()
+	for i, id := range ids {
+		v := sliceValue.Index(i)
+		if v.Kind() == reflect.Interface {
+			v = v.Elem()
+		}
+		vv := reflect.Indirect(v)
+		aiValue, err := col.ValueOfV(&vv)
+		if err != nil {
+			return err
+		}
+		if aiValue == nil || !aiValue.IsValid() || !aiValue.CanSet() {
+			continue
+		}
+		if err := convert.AssignValue(*aiValue, id); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // InsertMulti insert multiple records
@@ -262,6 +383,9 @@ func (session *Session) insertStruct(bean interface{}) (int64, error) {
 	if err := session.statement.SetRefBean(bean); err != nil {
 		return 0, err
 	}
+	if err := session.checkWritable(); err != nil {
+		return 0, err
+	}
 	if len(session.statement.TableName()) == 0 {
 		return 0, ErrTableNotFound
 	}
@@ -319,11 +443,20 @@ func (session *Session) insertStruct(bean interface{}) (int64, error) {
 
 	// if there is auto increment column and driver don't support return it
 	if len(table.AutoIncrement) > 0 && !session.engine.driver.Features().SupportReturnInsertedID {
-		var sql string
+		var idSQL string
 		var newArgs []interface{}
 		var needCommit bool
 		var id int64
-		if session.engine.dialect.URI().DBType == schemas.ORACLE || session.engine.dialect.URI().DBType == schemas.DAMENG {
+		if session.engine.dialect.URI().DBType == schemas.ORACLE {
+			// the id generated by the identity column or the sequence is returned into the out parameter
+			returningSQL := sqlStr + " RETURNING " + session.engine.dialect.Quoter().Quote(table.AutoIncrement) + " INTO ?"
+			if _, err := session.exec(returningSQL, append(args, sql.Out{Dest: &id})...); err != nil {
+				return 0, err
+			}
+			if id == 0 {
+				return 0, errors.New("insert successfully but not returned id")
+			}
+		} else if session.engine.dialect.URI().DBType == schemas.DAMENG {
 			if session.isAutoCommit { // if it's not in transaction
 				if err := session.Begin(); err != nil {
 					return 0, err
@@ -341,15 +474,15 @@ func (session *Session) insertStruct(bean interface{}) (int64, error) {
 					return 0, err
 				}
 			} else {
-				sql = fmt.Sprintf("select %s.currval from dual", utils.SeqName(tableName))
+				idSQL = fmt.Sprintf("select %s.currval from dual", dialects.AutoincrSequenceName(session.engine.dialect, tableName, table.AutoIncrColumn()))
 			}
 		} else {
-			sql = sqlStr
+			idSQL = sqlStr
 			newArgs = args
 		}
 
 		if id == 0 {
-			err := session.queryRow(sql, newArgs...).Scan(&id)
+			err := session.queryRow(idSQL, newArgs...).Scan(&id)
 			if err != nil {
 				return 0, err
 			}
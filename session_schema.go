@@ -8,13 +8,14 @@ import (
 	"bufio"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strings"
 
+	"xorm.io/builder"
 	"xorm.io/xorm/dialects"
-	"xorm.io/xorm/internal/utils"
 	"xorm.io/xorm/schemas"
 )
 
@@ -38,32 +39,17 @@ func (session *Session) CreateTable(bean interface{}) error {
 }
 
 func (session *Session) createTable(bean interface{}) error {
-	if err := session.statement.SetRefBean(bean); err != nil {
+	sqls, err := session.createTableSQLs(bean)
+	if err != nil {
 		return err
 	}
-
-	session.statement.RefTable.StoreEngine = session.statement.StoreEngine
-	session.statement.RefTable.Charset = session.statement.Charset
 	tableName := session.statement.TableName()
-	refTable := session.statement.RefTable
-	if refTable.AutoIncrement != "" && session.engine.dialect.Features().AutoincrMode == dialects.SequenceAutoincrMode {
-		sqlStr, err := session.engine.dialect.CreateSequenceSQL(context.Background(), session.engine.db, utils.SeqName(tableName))
-		if err != nil {
-			return err
-		}
+	defer dialects.ResetTableAutoincrMode(session.engine.dialect, tableName)
+	for _, sqlStr := range sqls {
 		if _, err := session.exec(sqlStr); err != nil {
 			return err
 		}
 	}
-
-	sqlStr, _, err := session.engine.dialect.CreateTableSQL(context.Background(), session.engine.db, refTable, tableName)
-	if err != nil {
-		return err
-	}
-	if _, err := session.exec(sqlStr); err != nil {
-		return err
-	}
-
 	return nil
 }
 
@@ -148,7 +134,11 @@ func (session *Session) DropTable(beanOrTableName interface{}) error {
 }
 
 func (session *Session) dropTable(beanOrTableName interface{}) error {
-	tableName := session.engine.TableName(beanOrTableName)
+	tableName := session.fullTableName(beanOrTableName)
+	if view := session.engine.viewOf(beanOrTableName); view != nil {
+		return session.dropView(tableName)
+	}
+
 	sqlStr, checkIfExist := session.engine.dialect.DropTableSQL(session.engine.TableName(tableName, true))
 	if !checkIfExist {
 		exist, err := session.engine.dialect.IsTableExist(session.getQueryer(), session.ctx, tableName)
@@ -164,12 +154,13 @@ func (session *Session) dropTable(beanOrTableName interface{}) error {
 	if _, err := session.exec(sqlStr); err != nil {
 		return err
 	}
+	dialects.ResetTableAutoincrMode(session.engine.dialect, tableName)
 
 	if session.engine.dialect.Features().AutoincrMode == dialects.IncrAutoincrMode {
 		return nil
 	}
 
-	var seqName = utils.SeqName(tableName)
+	var seqName = dialects.SequenceName(session.engine.dialect, tableName)
 	exist, err := session.engine.dialect.IsSequenceExist(session.ctx, session.getQueryer(), seqName)
 	if err != nil {
 		return err
@@ -186,15 +177,151 @@ func (session *Session) dropTable(beanOrTableName interface{}) error {
 	return err
 }
 
+// CreateView creates a view from the query of the session or replaces the existing one, i.e.
+// CreateView("active_user", engine.Table("user").Cols("id", "name").Where("active = ?", true))
+func (session *Session) CreateView(viewName string, query *Session) error {
+	return session.createView(viewName, query, false)
+}
+
+// CreateMaterializedView creates a materialized view from the query of the session or replaces
+// the existing one, postgres only
+func (session *Session) CreateMaterializedView(viewName string, query *Session) error {
+	return session.createView(viewName, query, true)
+}
+
+func (session *Session) createView(viewName string, query *Session, materialized bool) error {
+	if session.isAutoClose {
+		defer session.Close()
+	}
+
+	if query.isAutoClose {
+		defer query.Close()
+	}
+
+	sqlStr, args, err := query.statement.GenQuerySQL()
+	query.resetStatement()
+	if err != nil {
+		return err
+	}
+	// the views cannot be created with parameters
+	if len(args) > 0 {
+		if sqlStr, err = builder.ConvertToBoundSQL(sqlStr, args); err != nil {
+			return err
+		}
+	}
+
+	view := schemas.NewView(sqlStr, materialized)
+	if sqlStr = session.engine.dialect.ReplaceViewSQL(session.fullTableName(viewName), view); sqlStr == "" {
+		// the view should be dropped before being created again
+		if err := session.dropView(viewName); err != nil {
+			return err
+		}
+		if sqlStr, err = session.engine.dialect.CreateViewSQL(session.fullTableName(viewName), view); err != nil {
+			return err
+		}
+	}
+	_, err = session.exec(sqlStr)
+	return err
+}
+
+// DropView drops the view or the materialized view if it exists
+func (session *Session) DropView(viewName string) error {
+	if session.isAutoClose {
+		defer session.Close()
+	}
+
+	return session.dropView(viewName)
+}
+
+func (session *Session) dropView(viewName string) error {
+	viewName = session.fullTableName(viewName)
+	schema, name := dialects.SplitTableName(viewName)
+	views, err := session.engine.dialect.GetViews(session.getQueryer(), session.schemaContext(schema))
+	if err != nil {
+		return err
+	}
+	for _, view := range views {
+		if _, viewName2 := dialects.SplitTableName(view.Name); strings.EqualFold(viewName2, name) {
+			_, err = session.exec(session.engine.dialect.DropViewSQL(viewName, view.View))
+			return err
+		}
+	}
+	return nil
+}
+
+// RefreshMaterializedView refreshes the data of the materialized view, the view will not be
+// locked against selects if concurrently is true
+func (session *Session) RefreshMaterializedView(viewName string, concurrently bool) error {
+	if session.isAutoClose {
+		defer session.Close()
+	}
+
+	sqlStr, err := session.engine.dialect.RefreshMaterializedViewSQL(session.fullTableName(viewName), concurrently)
+	if err != nil {
+		return err
+	}
+	_, err = session.exec(sqlStr)
+	return err
+}
+
+// CreatePartition creates a partition of the partitioned table, i.e. a range partition for the next month
+func (session *Session) CreatePartition(beanOrTableName interface{}, partition *schemas.Partition) error {
+	if session.isAutoClose {
+		defer session.Close()
+	}
+
+	sqlStr, err := session.engine.dialect.CreatePartitionSQL(session.fullTableName(beanOrTableName), partition)
+	if err != nil {
+		return err
+	}
+	_, err = session.exec(sqlStr)
+	return err
+}
+
+// DropPartition drops a partition of the partitioned table with the data in it
+func (session *Session) DropPartition(beanOrTableName interface{}, partitionName string) error {
+	if session.isAutoClose {
+		defer session.Close()
+	}
+
+	sqlStr, err := session.engine.dialect.DropPartitionSQL(session.fullTableName(beanOrTableName), partitionName)
+	if err != nil {
+		return err
+	}
+	_, err = session.exec(sqlStr)
+	return err
+}
+
+// ListPartitions returns the partitions of the partitioned table
+func (session *Session) ListPartitions(beanOrTableName interface{}) ([]*schemas.Partition, error) {
+	if session.isAutoClose {
+		defer session.Close()
+	}
+
+	return session.engine.dialect.GetPartitions(session.getQueryer(), session.ctx, session.fullTableName(beanOrTableName))
+}
+
+// fullTableName returns the table name of the bean or the table name in the schema of the session
+func (session *Session) fullTableName(beanOrTableName interface{}) string {
+	return dialects.TableNameInSchema(session.statement.Schema, session.engine.TableName(beanOrTableName, true))
+}
+
+// schemaContext returns the context to read the metadata of the schema, the default schema
+// is used if it's empty
+func (session *Session) schemaContext(schema string) context.Context {
+	if schema == "" {
+		return session.ctx
+	}
+	return dialects.WithSchema(session.ctx, schema)
+}
+
 // IsTableExist if a table is exist
 func (session *Session) IsTableExist(beanOrTableName interface{}) (bool, error) {
 	if session.isAutoClose {
 		defer session.Close()
 	}
 
-	tableName := session.engine.TableName(beanOrTableName)
-
-	return session.isTableExist(tableName)
+	return session.isTableExist(session.fullTableName(beanOrTableName))
 }
 
 func (session *Session) isTableExist(tableName string) (bool, error) {
@@ -206,7 +333,7 @@ func (session *Session) IsTableEmpty(bean interface{}) (bool, error) {
 	if session.isAutoClose {
 		defer session.Close()
 	}
-	return session.isTableEmpty(session.engine.TableName(bean))
+	return session.isTableEmpty(session.fullTableName(bean))
 }
 
 func (session *Session) isTableEmpty(tableName string) (bool, error) {
@@ -223,27 +350,6 @@ func (session *Session) isTableEmpty(tableName string) (bool, error) {
 	return total == 0, nil
 }
 
-func (session *Session) addColumn(colName string) error {
-	col := session.statement.RefTable.GetColumn(colName)
-	sql := session.engine.dialect.AddColumnSQL(session.statement.TableName(), col)
-	_, err := session.exec(sql)
-	return err
-}
-
-func (session *Session) addIndex(tableName, idxName string) error {
-	index := session.statement.RefTable.Indexes[idxName]
-	sqlStr := session.engine.dialect.CreateIndexSQL(tableName, index)
-	_, err := session.exec(sqlStr)
-	return err
-}
-
-func (session *Session) addUnique(tableName, uqeName string) error {
-	index := session.statement.RefTable.Indexes[uqeName]
-	sqlStr := session.engine.dialect.CreateIndexSQL(tableName, index)
-	_, err := session.exec(sqlStr)
-	return err
-}
-
 // Sync2 synchronize structs to database tables
 // Depricated
 func (session *Session) Sync2(beans ...interface{}) error {
@@ -252,6 +358,11 @@ func (session *Session) Sync2(beans ...interface{}) error {
 
 // Sync synchronize structs to database tables
 func (session *Session) Sync(beans ...interface{}) error {
+	return session.SyncWithOptions(SyncOptions{}, beans...)
+}
+
+// SyncWithOptions synchronize structs to database tables with options
+func (session *Session) SyncWithOptions(opts SyncOptions, beans ...interface{}) error {
 	engine := session.engine
 
 	if session.isAutoClose {
@@ -259,210 +370,87 @@ func (session *Session) Sync(beans ...interface{}) error {
 		defer session.Close()
 	}
 
-	tables, err := engine.dialect.GetTables(session.getQueryer(), session.ctx)
-	if err != nil {
-		return err
-	}
-
 	session.autoResetStatement = false
 	defer func() {
 		session.autoResetStatement = true
 		session.resetStatement()
 	}()
 
-	for _, bean := range beans {
-		v := utils.ReflectValue(bean)
-		table, err := engine.tagParser.ParseWithCache(v)
-		if err != nil {
-			return err
-		}
-		var tbName string
-		if len(session.statement.AltTableName) > 0 {
-			tbName = session.statement.AltTableName
-		} else {
-			tbName = engine.TableName(bean)
-		}
-		tbNameWithSchema := engine.tbNameWithSchema(tbName)
+	plan, err := session.syncPlan(opts, beans...)
+	if err != nil {
+		return err
+	}
+	defer plan.resetAutoincrModes(engine.dialect)
 
-		var oriTable *schemas.Table
-		for _, tb := range tables {
-			if strings.EqualFold(engine.tbNameWithSchema(tb.Name), engine.tbNameWithSchema(tbName)) {
-				oriTable = tb
-				break
-			}
+	for _, warning := range plan.Warnings {
+		engine.logger.Warnf("%s", warning)
+	}
+	for _, col := range plan.ChangedColumns {
+		if col.FromType != col.ToType {
+			engine.logger.Infof("Table %s column %s change type from %s to %s",
+				col.TableName, col.Column.Name, col.FromType, col.ToType)
 		}
+	}
 
-		// this is a new table
-		if oriTable == nil {
-			err = session.StoreEngine(session.statement.StoreEngine).createTable(bean)
-			if err != nil {
-				return err
-			}
-
-			err = session.createUniques(bean)
-			if err != nil {
-				return err
-			}
-
-			err = session.createIndexes(bean)
-			if err != nil {
-				return err
-			}
-			continue
+	for _, sqlStr := range plan.SQLs {
+		if sqlStr == dialects.SQLiteForeignKeysOffSQL {
+			return session.execSQLiteRebuildSQLs(plan.SQLs)
 		}
+	}
 
-		// this will modify an old table
-		if err = engine.loadTableInfo(oriTable); err != nil {
+	for _, sqlStr := range plan.SQLs {
+		if _, err := session.exec(sqlStr); err != nil {
 			return err
 		}
+	}
 
-		// check columns
-		for _, col := range table.Columns() {
-			var oriCol *schemas.Column
-			for _, col2 := range oriTable.Columns() {
-				if strings.EqualFold(col.Name, col2.Name) {
-					oriCol = col2
-					break
-				}
-			}
-
-			// column is not exist on table
-			if oriCol == nil {
-				session.statement.RefTable = table
-				session.statement.SetTableName(tbNameWithSchema)
-				if err = session.addColumn(col.Name); err != nil {
-					return err
-				}
-				continue
-			}
+	return nil
+}
 
-			err = nil
-			expectedType := engine.dialect.SQLType(col)
-			curType := engine.dialect.SQLType(oriCol)
-			if expectedType != curType {
-				if expectedType == schemas.Text &&
-					strings.HasPrefix(curType, schemas.Varchar) {
-					// currently only support mysql & postgres
-					if engine.dialect.URI().DBType == schemas.MYSQL ||
-						engine.dialect.URI().DBType == schemas.POSTGRES {
-						engine.logger.Infof("Table %s column %s change type from %s to %s\n",
-							tbNameWithSchema, col.Name, curType, expectedType)
-						_, err = session.exec(engine.dialect.ModifyColumnSQL(tbNameWithSchema, col))
-					} else {
-						engine.logger.Warnf("Table %s column %s db type is %s, struct type is %s\n",
-							tbNameWithSchema, col.Name, curType, expectedType)
-					}
-				} else if strings.HasPrefix(curType, schemas.Varchar) && strings.HasPrefix(expectedType, schemas.Varchar) {
-					if engine.dialect.URI().DBType == schemas.MYSQL {
-						if oriCol.Length < col.Length {
-							engine.logger.Infof("Table %s column %s change type from varchar(%d) to varchar(%d)\n",
-								tbNameWithSchema, col.Name, oriCol.Length, col.Length)
-							_, err = session.exec(engine.dialect.ModifyColumnSQL(tbNameWithSchema, col))
-						}
-					}
-				} else {
-					if !(strings.HasPrefix(curType, expectedType) && curType[len(expectedType)] == '(') {
-						if !strings.EqualFold(schemas.SQLTypeName(curType), engine.dialect.Alias(schemas.SQLTypeName(expectedType))) {
-							engine.logger.Warnf("Table %s column %s db type is %s, struct type is %s",
-								tbNameWithSchema, col.Name, curType, expectedType)
-						}
-					}
-				}
-			} else if expectedType == schemas.Varchar {
-				if engine.dialect.URI().DBType == schemas.MYSQL {
-					if oriCol.Length < col.Length {
-						engine.logger.Infof("Table %s column %s change type from varchar(%d) to varchar(%d)\n",
-							tbNameWithSchema, col.Name, oriCol.Length, col.Length)
-						_, err = session.exec(engine.dialect.ModifyColumnSQL(tbNameWithSchema, col))
-					}
-				}
-			} else if col.Comment != oriCol.Comment {
-				_, err = session.exec(engine.dialect.ModifyColumnSQL(tbNameWithSchema, col))
-			}
+// execSQLiteRebuildSQLs executes the SQLs of the plan which rebuilds the referenced tables of sqlite
+// on one connection, since the foreign keys could only be disabled for a connection out of transactions
+func (session *Session) execSQLiteRebuildSQLs(sqls []string) error {
+	if !session.isAutoCommit {
+		return errors.New("the sqlite tables referenced by foreign keys cannot be rebuilt in a transaction")
+	}
 
-			if col.Default != oriCol.Default {
-				switch {
-				case col.IsAutoIncrement: // For autoincrement column, don't check default
-				case (col.SQLType.Name == schemas.Bool || col.SQLType.Name == schemas.Boolean) &&
-					((strings.EqualFold(col.Default, "true") && oriCol.Default == "1") ||
-						(strings.EqualFold(col.Default, "false") && oriCol.Default == "0")):
-				default:
-					engine.logger.Warnf("Table %s Column %s db default is %s, struct default is %s",
-						tbName, col.Name, oriCol.Default, col.Default)
-				}
-			}
-			if col.Nullable != oriCol.Nullable {
-				engine.logger.Warnf("Table %s Column %s db nullable is %v, struct nullable is %v",
-					tbName, col.Name, oriCol.Nullable, col.Nullable)
-			}
+	conn, err := session.DB().AcquireConn(session.ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
 
-			if err != nil {
-				return err
-			}
+	var disabled bool
+	defer func() {
+		if disabled {
+			_, _ = conn.ExecContext(session.ctx, dialects.SQLiteForeignKeysOnSQL)
 		}
+	}()
 
-		var foundIndexNames = make(map[string]bool)
-		var addedNames = make(map[string]*schemas.Index)
-
-		for name, index := range table.Indexes {
-			var oriIndex *schemas.Index
-			for name2, index2 := range oriTable.Indexes {
-				if index.Equal(index2) {
-					oriIndex = index2
-					foundIndexNames[name2] = true
-					break
-				}
-			}
-
-			if oriIndex != nil {
-				if oriIndex.Type != index.Type {
-					sql := engine.dialect.DropIndexSQL(tbNameWithSchema, oriIndex)
-					_, err = session.exec(sql)
-					if err != nil {
-						return err
-					}
-					oriIndex = nil
-				}
+	for _, sqlStr := range sqls {
+		if !strings.HasPrefix(sqlStr, dialects.SQLiteForeignKeyCheckSQL) {
+			if _, err := conn.ExecContext(session.ctx, sqlStr); err != nil {
+				return err
 			}
-
-			if oriIndex == nil {
-				addedNames[name] = index
-			}
-		}
-
-		for name2, index2 := range oriTable.Indexes {
-			if _, ok := foundIndexNames[name2]; !ok {
-				sql := engine.dialect.DropIndexSQL(tbNameWithSchema, index2)
-				_, err = session.exec(sql)
-				if err != nil {
-					return err
-				}
+			switch sqlStr {
+			case dialects.SQLiteForeignKeysOffSQL:
+				disabled = true
+			case dialects.SQLiteForeignKeysOnSQL:
+				disabled = false
 			}
+			continue
 		}
 
-		for name, index := range addedNames {
-			if index.Type == schemas.UniqueType {
-				session.statement.RefTable = table
-				session.statement.SetTableName(tbNameWithSchema)
-				err = session.addUnique(tbNameWithSchema, name)
-			} else if index.Type == schemas.IndexType {
-				session.statement.RefTable = table
-				session.statement.SetTableName(tbNameWithSchema)
-				err = session.addIndex(tbNameWithSchema, name)
-			}
-			if err != nil {
-				return err
-			}
+		rows, err := conn.QueryContext(session.ctx, sqlStr)
+		if err != nil {
+			return err
 		}
-
-		// check all the columns which removed from struct fields but left on database tables.
-		for _, colName := range oriTable.ColumnsSeq() {
-			if table.GetColumn(colName) == nil {
-				engine.logger.Warnf("Table %s has column %s but struct has not related field", engine.TableName(oriTable.Name, true), colName)
-			}
+		violated := rows.Next()
+		rows.Close()
+		if violated {
+			return fmt.Errorf("foreign keys are violated after rebuilding tables: %s", sqlStr)
 		}
 	}
-
 	return nil
 }
 
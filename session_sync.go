@@ -0,0 +1,710 @@
+// Copyright 2026 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xorm
+
+import (
+	"fmt"
+	"strings"
+
+	"xorm.io/xorm/dialects"
+	"xorm.io/xorm/internal/utils"
+	"xorm.io/xorm/schemas"
+)
+
+// SyncOptions represents the options of Sync, all the options are disabled by default
+// so that Sync will never remove anything from the database.
+type SyncOptions struct {
+	// AlterNullability changes the nullability of the columns to the struct's
+	AlterNullability bool
+	// AlterDefaults changes the default values of the columns to the struct's
+	AlterDefaults bool
+	// DropColumns drops the columns which have no related struct fields
+	DropColumns bool
+	// DropTables drops the tables which have no related structs
+	DropTables bool
+}
+
+// SyncTable represents a table which will be created by Sync
+type SyncTable struct {
+	TableName string
+	Table     *schemas.Table
+}
+
+// SyncColumn represents a column which will be added or modified by Sync
+type SyncColumn struct {
+	TableName string
+	Column    *schemas.Column // the column parsed from the struct
+	OriColumn *schemas.Column // the column in the database, nil if the column will be added
+	FromType  string          // the column type in the database
+	ToType    string          // the column type of the struct
+
+	NullableChanged bool
+	DefaultChanged  bool
+}
+
+// SyncIndex represents an index which will be added or dropped by Sync
+type SyncIndex struct {
+	TableName string
+	Index     *schemas.Index
+}
+
+// SyncForeignKey represents a foreign key which will be added or dropped by Sync
+type SyncForeignKey struct {
+	TableName  string
+	ForeignKey *schemas.ForeignKey
+}
+
+// SyncCheck represents a check constraint which will be added or dropped by Sync
+type SyncCheck struct {
+	TableName string
+	Check     *schemas.Check
+}
+
+// SyncPlan represents all the changes which Sync will apply to the database
+type SyncPlan struct {
+	AddedTables    []*SyncTable
+	DroppedTables  []string
+	RebuiltTables  []*SyncTable // tables which will be rebuilt because the columns cannot be altered
+	AddedColumns   []*SyncColumn
+	ChangedColumns []*SyncColumn
+	DroppedColumns []*SyncColumn
+	RenamedColumns []*SyncColumn // columns renamed from the old names of renamed_from tags
+	AddedIndexes   []*SyncIndex
+	DroppedIndexes []*SyncIndex
+
+	AddedForeignKeys   []*SyncForeignKey
+	DroppedForeignKeys []*SyncForeignKey
+	AddedChecks        []*SyncCheck
+	DroppedChecks      []*SyncCheck
+	AddedViews         []*SyncTable
+	ReplacedViews      []*SyncTable // views which will be replaced because the queries changed
+	// Warnings describes the differences between the structs and the database
+	// which will not be changed by Sync
+	Warnings []string
+	// SQLs are all the SQLs which will be executed by Sync in sequence
+	SQLs []string
+}
+
+// IsEmpty returns true if Sync will not change the database
+func (plan *SyncPlan) IsEmpty() bool {
+	return len(plan.SQLs) == 0
+}
+
+func (plan *SyncPlan) warnf(format string, args ...interface{}) {
+	plan.Warnings = append(plan.Warnings, fmt.Sprintf(format, args...))
+}
+
+func (plan *SyncPlan) addSQLs(sqls ...string) {
+	plan.SQLs = append(plan.SQLs, sqls...)
+}
+
+// resetAutoincrModes forgets the autoincrement modes of the tables which are created, rebuilt
+// or dropped by the plan, they will be read from the database again
+func (plan *SyncPlan) resetAutoincrModes(dialect dialects.Dialect) {
+	for _, table := range plan.AddedTables {
+		dialects.ResetTableAutoincrMode(dialect, table.TableName)
+	}
+	for _, table := range plan.RebuiltTables {
+		dialects.ResetTableAutoincrMode(dialect, table.TableName)
+	}
+	for _, tableName := range plan.DroppedTables {
+		dialects.ResetTableAutoincrMode(dialect, tableName)
+	}
+}
+
+// SyncPlan returns the changes which Sync will apply to the database without executing them
+func (session *Session) SyncPlan(beans ...interface{}) (*SyncPlan, error) {
+	return session.SyncPlanWithOptions(SyncOptions{}, beans...)
+}
+
+// SyncPlanWithOptions returns the changes which SyncWithOptions will apply to the database
+// without executing them
+func (session *Session) SyncPlanWithOptions(opts SyncOptions, beans ...interface{}) (*SyncPlan, error) {
+	if session.isAutoClose {
+		session.isAutoClose = false
+		defer session.Close()
+	}
+
+	session.autoResetStatement = false
+	defer func() {
+		session.autoResetStatement = true
+		session.resetStatement()
+	}()
+
+	return session.syncPlan(opts, beans...)
+}
+
+func (session *Session) createTableSQLs(bean interface{}) ([]string, error) {
+	if err := session.statement.SetRefBean(bean); err != nil {
+		return nil, err
+	}
+
+	session.statement.RefTable.StoreEngine = session.statement.StoreEngine
+	session.statement.RefTable.Charset = session.statement.Charset
+	tableName := session.statement.TableName()
+	refTable := session.statement.RefTable
+
+	if refTable.IsView() {
+		sqlStr, err := session.engine.dialect.CreateViewSQL(tableName, refTable.View)
+		if err != nil {
+			return nil, err
+		}
+		return []string{sqlStr}, nil
+	}
+
+	sqls, err := session.createSequenceSQLs(tableName, refTable)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlStr, _, err := session.engine.dialect.CreateTableSQL(session.ctx, session.engine.db, refTable, tableName)
+	if err != nil {
+		return nil, err
+	}
+	return append(sqls, sqlStr), nil
+}
+
+// createSequenceSQLs returns the SQL to create the sequence of the autoincrement column, the
+// sequence bound by the seq tag is created only if it doesn't exist
+func (session *Session) createSequenceSQLs(tableName string, table *schemas.Table) ([]string, error) {
+	if table.AutoIncrement == "" {
+		return nil, nil
+	}
+	col := table.AutoIncrColumn()
+	seqName := dialects.AutoincrSequenceName(session.engine.dialect, tableName, col)
+	if seqName == "" {
+		return nil, nil
+	}
+	if col.Sequence != "" {
+		exist, err := session.engine.dialect.IsSequenceExist(session.ctx, session.getQueryer(), seqName)
+		if err != nil || exist {
+			return nil, err
+		}
+	}
+	sqlStr, err := session.engine.dialect.CreateSequenceSQL(session.ctx, session.engine.db, seqName)
+	if err != nil {
+		return nil, err
+	}
+	return []string{sqlStr}, nil
+}
+
+func (session *Session) syncPlan(opts SyncOptions, beans ...interface{}) (*SyncPlan, error) {
+	engine := session.engine
+
+	// the tables and views are loaded once per schema, the empty schema is the default one
+	var (
+		schemaNames []string
+		tablesOf    = make(map[string][]*schemas.Table)
+		viewsOf     = make(map[string][]*schemas.Table)
+	)
+	loadSchema := func(schema string) error {
+		if _, ok := tablesOf[schema]; ok {
+			return nil
+		}
+		ctx := session.schemaContext(schema)
+		tables, err := engine.dialect.GetTables(session.getQueryer(), ctx)
+		if err != nil {
+			return err
+		}
+		views, err := engine.dialect.GetViews(session.getQueryer(), ctx)
+		if err != nil {
+			return err
+		}
+		schemaNames = append(schemaNames, schema)
+		tablesOf[schema] = tables
+		viewsOf[schema] = views
+		return nil
+	}
+	if err := loadSchema(""); err != nil {
+		return nil, err
+	}
+
+	// the referenced tables should be created before the referencing ones and the views
+	beans, err := engine.sortByForeignKeys(beans)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan SyncPlan
+	var syncedTables = make(map[string]bool, len(beans))
+	for _, bean := range beans {
+		v := utils.ReflectValue(bean)
+		table, err := engine.tagParser.ParseWithCache(v)
+		if err != nil {
+			return nil, err
+		}
+		var tbName string
+		if len(session.statement.AltTableName) > 0 {
+			tbName = session.statement.AltTableName
+		} else {
+			tbName = engine.TableName(bean, true)
+		}
+		tbName = dialects.TableNameInSchema(session.statement.Schema, tbName)
+		tbNameWithSchema := engine.tbNameWithSchema(tbName)
+
+		schemaName, bareName := dialects.SplitTableName(tbNameWithSchema)
+		if schemaName == engine.dialect.URI().Schema {
+			schemaName = ""
+		}
+		if err := loadSchema(schemaName); err != nil {
+			return nil, err
+		}
+
+		if table.IsView() {
+			var oriView *schemas.Table
+			for _, view := range viewsOf[schemaName] {
+				if _, viewName := dialects.SplitTableName(view.Name); strings.EqualFold(viewName, bareName) {
+					oriView = view
+					break
+				}
+			}
+			if oriView != nil && table.View.Equal(oriView.View) {
+				continue
+			}
+
+			if oriView == nil {
+				sqlStr, err := engine.dialect.CreateViewSQL(tbNameWithSchema, table.View)
+				if err != nil {
+					return nil, err
+				}
+				plan.AddedViews = append(plan.AddedViews, &SyncTable{
+					TableName: tbNameWithSchema,
+					Table:     table,
+				})
+				plan.addSQLs(sqlStr)
+			} else {
+				sqls, err := dialects.ReplaceViewSQLs(engine.dialect, tbNameWithSchema, oriView.View, table.View)
+				if err != nil {
+					return nil, err
+				}
+				plan.ReplacedViews = append(plan.ReplacedViews, &SyncTable{
+					TableName: tbNameWithSchema,
+					Table:     table,
+				})
+				plan.addSQLs(sqls...)
+			}
+			continue
+		}
+		syncedTables[strings.ToLower(tbNameWithSchema)] = true
+
+		var oriTable *schemas.Table
+		for _, tb := range tablesOf[schemaName] {
+			if _, name := dialects.SplitTableName(tb.Name); strings.EqualFold(name, bareName) {
+				oriTable = tb
+				break
+			}
+		}
+
+		// this is a new table
+		if oriTable == nil {
+			sqls, err := session.createTableSQLs(bean)
+			if err != nil {
+				return nil, err
+			}
+			plan.AddedTables = append(plan.AddedTables, &SyncTable{
+				TableName: session.statement.TableName(),
+				Table:     table,
+			})
+			plan.addSQLs(sqls...)
+			plan.addSQLs(session.statement.GenUniqueSQL()...)
+			plan.addSQLs(session.statement.GenIndexSQL()...)
+			for _, index := range table.Indexes {
+				plan.AddedIndexes = append(plan.AddedIndexes, &SyncIndex{
+					TableName: session.statement.TableName(),
+					Index:     index,
+				})
+			}
+			for _, fk := range table.SortedForeignKeys() {
+				plan.AddedForeignKeys = append(plan.AddedForeignKeys, &SyncForeignKey{
+					TableName:  session.statement.TableName(),
+					ForeignKey: fk,
+				})
+			}
+			for _, check := range table.SortedChecks() {
+				plan.AddedChecks = append(plan.AddedChecks, &SyncCheck{
+					TableName: session.statement.TableName(),
+					Check:     check,
+				})
+			}
+			continue
+		}
+
+		// this will modify an old table
+		if err = engine.loadTableInfo(session.schemaContext(schemaName), oriTable); err != nil {
+			return nil, err
+		}
+
+		// the sequence bound by the seq tag may be added to an old table
+		if table.AutoIncrement != "" && table.AutoIncrColumn().Sequence != "" {
+			sqls, err := session.createSequenceSQLs(tbNameWithSchema, table)
+			if err != nil {
+				return nil, err
+			}
+			plan.addSQLs(sqls...)
+		}
+
+		// rename the columns before checking the columns, the renamed columns on the original
+		// table are treated as the new names, so that the indexes will not be recreated
+		var rebuild bool
+		renameStart := len(plan.SQLs)
+		for _, col := range table.Columns() {
+			if col.RenamedFrom == "" || oriTable.GetColumn(col.Name) != nil {
+				continue
+			}
+			oriCol := oriTable.GetColumn(col.RenamedFrom)
+			if oriCol == nil {
+				continue
+			}
+			// the column of the original table will be renamed, keep the old one in the plan
+			renamedCol := *oriCol
+			plan.RenamedColumns = append(plan.RenamedColumns, &SyncColumn{
+				TableName: tbNameWithSchema,
+				Column:    col,
+				OriColumn: &renamedCol,
+				FromType:  engine.dialect.SQLType(oriCol),
+				ToType:    engine.dialect.SQLType(col),
+			})
+			if sqlStr := engine.dialect.RenameColumnSQL(tbNameWithSchema, renamedCol.Name, col); sqlStr != "" {
+				plan.addSQLs(sqlStr)
+			} else {
+				rebuild = true
+			}
+			oriTable.RenameColumn(renamedCol.Name, col.Name)
+		}
+		renameEnd := len(plan.SQLs)
+
+		// check columns
+		for _, col := range table.Columns() {
+			var oriCol *schemas.Column
+			for _, col2 := range oriTable.Columns() {
+				if strings.EqualFold(col.Name, col2.Name) {
+					oriCol = col2
+					break
+				}
+			}
+
+			// column is not exist on table
+			if oriCol == nil {
+				plan.AddedColumns = append(plan.AddedColumns, &SyncColumn{
+					TableName: tbNameWithSchema,
+					Column:    col,
+					ToType:    engine.dialect.SQLType(col),
+				})
+				plan.addSQLs(dialects.AddColumnSQLs(engine.dialect, tbNameWithSchema, col)...)
+				continue
+			}
+
+			var modify bool
+			expectedType := engine.dialect.SQLType(col)
+			curType := engine.dialect.SQLType(oriCol)
+			if expectedType != curType {
+				if col.SQLType.IsArray() && oriCol.SQLType.IsArray() {
+					// the element type of the postgres array is changed
+					modify = true
+				} else if expectedType == schemas.Text &&
+					strings.HasPrefix(curType, schemas.Varchar) {
+					// currently only support mysql & postgres
+					if engine.dialect.URI().DBType == schemas.MYSQL ||
+						engine.dialect.URI().DBType == schemas.POSTGRES {
+						modify = true
+					} else {
+						plan.warnf("Table %s column %s db type is %s, struct type is %s",
+							tbNameWithSchema, col.Name, curType, expectedType)
+					}
+				} else if strings.HasPrefix(curType, schemas.Varchar) && strings.HasPrefix(expectedType, schemas.Varchar) {
+					if engine.dialect.URI().DBType == schemas.MYSQL {
+						if oriCol.Length < col.Length {
+							modify = true
+						}
+					}
+				} else {
+					if !(strings.HasPrefix(curType, expectedType) && curType[len(expectedType)] == '(') {
+						if !strings.EqualFold(schemas.SQLTypeName(curType), engine.dialect.Alias(schemas.SQLTypeName(expectedType))) {
+							plan.warnf("Table %s column %s db type is %s, struct type is %s",
+								tbNameWithSchema, col.Name, curType, expectedType)
+						}
+					}
+				}
+			} else if expectedType == schemas.Varchar {
+				if engine.dialect.URI().DBType == schemas.MYSQL {
+					if oriCol.Length < col.Length {
+						modify = true
+					}
+				}
+			} else if col.Comment != oriCol.Comment {
+				modify = true
+			}
+
+			change := &SyncColumn{
+				TableName: tbNameWithSchema,
+				Column:    col,
+				OriColumn: oriCol,
+				FromType:  curType,
+				ToType:    expectedType,
+			}
+			if modify {
+				plan.addSQLs(engine.dialect.ModifyColumnSQL(tbNameWithSchema, col))
+			}
+
+			if col.Default != oriCol.Default {
+				switch {
+				case col.IsAutoIncrement: // For autoincrement column, don't check default
+				case (col.SQLType.Name == schemas.Bool || col.SQLType.Name == schemas.Boolean) &&
+					((strings.EqualFold(col.Default, "true") && oriCol.Default == "1") ||
+						(strings.EqualFold(col.Default, "false") && oriCol.Default == "0")):
+				case opts.AlterDefaults:
+					change.DefaultChanged = true
+					if sqlStr := engine.dialect.AlterColumnDefaultSQL(tbNameWithSchema, col); sqlStr != "" {
+						plan.addSQLs(sqlStr)
+					} else {
+						rebuild = true
+					}
+				default:
+					plan.warnf("Table %s Column %s db default is %s, struct default is %s",
+						tbName, col.Name, oriCol.Default, col.Default)
+				}
+			}
+			if col.Nullable != oriCol.Nullable {
+				if opts.AlterNullability {
+					change.NullableChanged = true
+					if sqlStr := engine.dialect.AlterColumnNullableSQL(tbNameWithSchema, col); sqlStr != "" {
+						plan.addSQLs(sqlStr)
+					} else {
+						rebuild = true
+					}
+				} else {
+					plan.warnf("Table %s Column %s db nullable is %v, struct nullable is %v",
+						tbName, col.Name, oriCol.Nullable, col.Nullable)
+				}
+			}
+
+			if modify || change.DefaultChanged || change.NullableChanged {
+				plan.ChangedColumns = append(plan.ChangedColumns, change)
+			}
+		}
+
+		var foundIndexNames = make(map[string]bool)
+
+		// the indexes are iterated in name order so that the SQLs are always the same
+		oriIndexes := oriTable.SortedIndexes()
+		var addedIndexes []*schemas.Index
+		for _, index := range table.SortedIndexes() {
+			var oriIndex *schemas.Index
+			supportedIndex := dialects.SupportedIndex(engine.dialect, index)
+			for _, index2 := range oriIndexes {
+				if supportedIndex.Equal(index2) {
+					oriIndex = index2
+					foundIndexNames[index2.Name] = true
+					break
+				}
+			}
+
+			if oriIndex != nil {
+				if oriIndex.Type != index.Type {
+					plan.DroppedIndexes = append(plan.DroppedIndexes, &SyncIndex{
+						TableName: tbNameWithSchema,
+						Index:     oriIndex,
+					})
+					plan.addSQLs(engine.dialect.DropIndexSQL(tbNameWithSchema, oriIndex))
+					oriIndex = nil
+				}
+			}
+
+			if oriIndex == nil {
+				addedIndexes = append(addedIndexes, index)
+			}
+		}
+
+		for _, index2 := range oriIndexes {
+			// some databases create the indexes of foreign keys automatically
+			if _, ok := oriTable.ForeignKeys[index2.Name]; ok {
+				continue
+			}
+			if _, ok := foundIndexNames[index2.Name]; !ok {
+				plan.DroppedIndexes = append(plan.DroppedIndexes, &SyncIndex{
+					TableName: tbNameWithSchema,
+					Index:     index2,
+				})
+				plan.addSQLs(engine.dialect.DropIndexSQL(tbNameWithSchema, index2))
+			}
+		}
+
+		for _, index := range addedIndexes {
+			if index.Type == schemas.UniqueType || index.Type == schemas.IndexType {
+				plan.AddedIndexes = append(plan.AddedIndexes, &SyncIndex{
+					TableName: tbNameWithSchema,
+					Index:     index,
+				})
+				plan.addSQLs(engine.dialect.CreateIndexSQL(tbNameWithSchema, index))
+			}
+		}
+
+		// check foreign keys, the dropped ones should be dropped before the columns
+		var foundFKNames = make(map[string]bool)
+		var addedFKs []*schemas.ForeignKey
+		for _, fk := range table.SortedForeignKeys() {
+			supportedFK := dialects.SupportedForeignKey(engine.dialect, fk)
+			var found bool
+			for name2, fk2 := range oriTable.ForeignKeys {
+				if !foundFKNames[name2] && supportedFK.Equal(fk2) {
+					foundFKNames[name2] = true
+					found = true
+					break
+				}
+			}
+			if !found {
+				addedFKs = append(addedFKs, fk)
+			}
+		}
+
+		for _, fk2 := range oriTable.SortedForeignKeys() {
+			if foundFKNames[fk2.Name] {
+				continue
+			}
+			// only drop the foreign keys which are named by xorm
+			if !dialects.IsRegularForeignKeyName(engine.dialect, tbName, fk2.Name) {
+				plan.warnf("Table %s has foreign key %s but struct has not related fk tag", tbNameWithSchema, fk2.Name)
+				continue
+			}
+			plan.DroppedForeignKeys = append(plan.DroppedForeignKeys, &SyncForeignKey{
+				TableName:  tbNameWithSchema,
+				ForeignKey: fk2,
+			})
+			if sqlStr := engine.dialect.DropForeignKeySQL(tbNameWithSchema, fk2); sqlStr != "" {
+				plan.addSQLs(sqlStr)
+			} else {
+				rebuild = true
+			}
+		}
+
+		for _, fk := range addedFKs {
+			plan.AddedForeignKeys = append(plan.AddedForeignKeys, &SyncForeignKey{
+				TableName:  tbNameWithSchema,
+				ForeignKey: fk,
+			})
+			if sqlStr := engine.dialect.AddForeignKeySQL(tbNameWithSchema, fk); sqlStr != "" {
+				plan.addSQLs(sqlStr)
+			} else {
+				rebuild = true
+			}
+		}
+
+		// check constraints are matched by names, or by expressions if the database doesn't keep
+		// the names, the changed ones will be dropped and added again
+		var foundCheckNames = make(map[string]bool)
+		var addedChecks []*schemas.Check
+		for _, check := range table.SortedChecks() {
+			var oriCheck *schemas.Check
+			for name2, check2 := range oriTable.Checks {
+				if strings.EqualFold(name2, dialects.CheckName(engine.dialect, tbName, check)) {
+					oriCheck = check2
+					break
+				}
+			}
+			if oriCheck == nil && engine.dialect.Features().UnnamedChecks {
+				for _, check2 := range oriTable.SortedChecks() {
+					if !foundCheckNames[check2.Name] && check.Equal(check2) {
+						oriCheck = check2
+						break
+					}
+				}
+			}
+			if oriCheck != nil {
+				foundCheckNames[oriCheck.Name] = true
+				if check.Equal(oriCheck) {
+					continue
+				}
+				plan.DroppedChecks = append(plan.DroppedChecks, &SyncCheck{
+					TableName: tbNameWithSchema,
+					Check:     oriCheck,
+				})
+				if sqlStr := engine.dialect.DropCheckSQL(tbNameWithSchema, oriCheck); sqlStr != "" {
+					plan.addSQLs(sqlStr)
+				} else {
+					rebuild = true
+				}
+			}
+			addedChecks = append(addedChecks, check)
+		}
+
+		for _, check2 := range oriTable.SortedChecks() {
+			if foundCheckNames[check2.Name] {
+				continue
+			}
+			// only drop the check constraints which are named by xorm
+			if !dialects.IsRegularCheckName(engine.dialect, tbName, check2.Name) {
+				plan.warnf("Table %s has check constraint %s but struct has not related check", tbNameWithSchema, check2.Name)
+				continue
+			}
+			plan.DroppedChecks = append(plan.DroppedChecks, &SyncCheck{
+				TableName: tbNameWithSchema,
+				Check:     check2,
+			})
+			if sqlStr := engine.dialect.DropCheckSQL(tbNameWithSchema, check2); sqlStr != "" {
+				plan.addSQLs(sqlStr)
+			} else {
+				rebuild = true
+			}
+		}
+
+		for _, check := range addedChecks {
+			plan.AddedChecks = append(plan.AddedChecks, &SyncCheck{
+				TableName: tbNameWithSchema,
+				Check:     check,
+			})
+			if sqlStr := engine.dialect.AddCheckSQL(tbNameWithSchema, check); sqlStr != "" {
+				plan.addSQLs(sqlStr)
+			} else {
+				rebuild = true
+			}
+		}
+
+		// check all the columns which removed from struct fields but left on database tables.
+		for _, colName := range oriTable.ColumnsSeq() {
+			if table.GetColumn(colName) != nil {
+				continue
+			}
+			if opts.DropColumns {
+				plan.DroppedColumns = append(plan.DroppedColumns, &SyncColumn{
+					TableName: tbNameWithSchema,
+					OriColumn: oriTable.GetColumn(colName),
+				})
+				plan.addSQLs(engine.dialect.DropColumnSQL(tbNameWithSchema, colName))
+			} else {
+				plan.warnf("Table %s has column %s but struct has not related field", engine.TableName(oriTable.Name, true), colName)
+			}
+		}
+
+		if rebuild {
+			// the renamed columns will be copied from the old columns by rebuilding
+			plan.SQLs = append(plan.SQLs[:renameStart], plan.SQLs[renameEnd:]...)
+			sqls, err := engine.dialect.RebuildTableSQLs(session.ctx, session.getQueryer(), table, tbNameWithSchema)
+			if err != nil {
+				return nil, err
+			}
+			plan.RebuiltTables = append(plan.RebuiltTables, &SyncTable{
+				TableName: tbNameWithSchema,
+				Table:     table,
+			})
+			plan.addSQLs(sqls...)
+		}
+	}
+
+	if opts.DropTables {
+		for _, schemaName := range schemaNames {
+			for _, tb := range tablesOf[schemaName] {
+				tbName := engine.tbNameWithSchema(dialects.TableNameInSchema(schemaName, tb.Name))
+				if syncedTables[strings.ToLower(tbName)] {
+					continue
+				}
+				sqlStr, _ := engine.dialect.DropTableSQL(tbName)
+				plan.DroppedTables = append(plan.DroppedTables, tbName)
+				plan.addSQLs(sqlStr)
+			}
+		}
+	}
+
+	return &plan, nil
+}
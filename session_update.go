@@ -182,6 +182,9 @@ func (session *Session) Update(bean interface{}, condiBean ...interface{}) (int6
 		if err := session.statement.SetRefBean(bean); err != nil {
 			return 0, err
 		}
+		if err := session.checkWritable(); err != nil {
+			return 0, err
+		}
 
 		if len(session.statement.TableName()) == 0 {
 			return 0, ErrTableNotFound
@@ -265,6 +268,13 @@ func (session *Session) Update(bean interface{}, condiBean ...interface{}) (int6
 			subQuery = session.statement.ReplaceQuote(subQuery)
 			colNames = append(colNames, session.engine.Quote(expr.ColName)+"=("+subQuery+")")
 			args = append(args, subArgs...)
+		case builder.Cond:
+			exprSQL, exprArgs, err := builder.ToSQL(tp)
+			if err != nil {
+				return 0, err
+			}
+			colNames = append(colNames, session.engine.Quote(expr.ColName)+"="+exprSQL)
+			args = append(args, exprArgs...)
 		default:
 			colNames = append(colNames, session.engine.Quote(expr.ColName)+"=?")
 			args = append(args, expr.Arg)
@@ -363,7 +373,7 @@ func (session *Session) Update(bean interface{}, condiBean ...interface{}) (int6
 		switch session.engine.dialect.URI().DBType {
 		case schemas.MYSQL:
 			fmt.Fprintf(whereWriter, " LIMIT %d", limitValue)
-		case schemas.SQLITE:
+		case schemas.SQLITE, schemas.DUCKDB:
 			fmt.Fprintf(whereWriter, " LIMIT %d", limitValue)
 
 			cond = cond.And(builder.Expr(fmt.Sprintf("rowid IN (SELECT rowid FROM %v %v)",
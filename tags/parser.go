@@ -17,6 +17,7 @@ import (
 	"xorm.io/xorm/caches"
 	"xorm.io/xorm/convert"
 	"xorm.io/xorm/dialects"
+	"xorm.io/xorm/internal/utils"
 	"xorm.io/xorm/names"
 	"xorm.io/xorm/schemas"
 )
@@ -31,6 +32,37 @@ type TableIndices interface {
 
 var tpTableIndices = reflect.TypeOf((*TableIndices)(nil)).Elem()
 
+// TableChecks is an interface that describes structs that provide check constraints above
+// that which are parsed from the check tags
+type TableChecks interface {
+	TableChecks() []*schemas.Check
+}
+
+var tpTableChecks = reflect.TypeOf((*TableChecks)(nil)).Elem()
+
+// TableView is an interface that describes structs which are mapped onto views or
+// materialized views, Sync will create the views from the queries
+type TableView interface {
+	TableView() *schemas.View
+}
+
+var tpTableView = reflect.TypeOf((*TableView)(nil)).Elem()
+
+// TablePartitioning is an interface that describes structs whose tables are partitioned
+type TablePartitioning interface {
+	TablePartitioning() *schemas.Partitioning
+}
+
+var tpTablePartitioning = reflect.TypeOf((*TablePartitioning)(nil)).Elem()
+
+// TableRenames is an interface that describes structs whose columns were renamed, the keys
+// are the column names and the values are the old names, the same as renamed_from tags
+type TableRenames interface {
+	TableRenames() map[string]string
+}
+
+var tpTableRenames = reflect.TypeOf((*TableRenames)(nil)).Elem()
+
 // Parser represents a parser for xorm tag
 type Parser struct {
 	identifier   string
@@ -118,15 +150,72 @@ func (parser *Parser) ClearCaches() {
 	parser.tableCache = sync.Map{}
 }
 
-func addIndex(indexName string, table *schemas.Table, col *schemas.Column, indexType int) {
-	if index, ok := table.Indexes[indexName]; ok {
-		index.AddColumn(col.Name)
-		col.Indexes[index.Name] = indexType
-	} else {
-		index := schemas.NewIndex(indexName, indexType)
-		index.AddColumn(col.Name)
+func addIndex(indexName string, table *schemas.Table, col *schemas.Column, indexType int, opts *indexOptions) {
+	index, ok := table.Indexes[indexName]
+	if !ok {
+		index = schemas.NewIndex(indexName, indexType)
 		table.AddIndex(index)
-		col.Indexes[index.Name] = indexType
+	}
+	col.Indexes[index.Name] = indexType
+	if opts == nil {
+		index.AddColumn(col.Name)
+		return
+	}
+
+	if opts.where != "" {
+		index.Where = opts.where
+	}
+	if opts.method != "" {
+		index.Method = opts.method
+	}
+	if opts.include {
+		index.AddInclude(col.Name)
+		return
+	}
+	var colName = col.Name
+	if opts.expr != "" {
+		colName = opts.expr
+	}
+	index.AddColumn(colName)
+	if opts.desc {
+		index.SetDesc(colName)
+	}
+	if opts.length > 0 {
+		index.SetLength(colName, opts.length)
+	}
+}
+
+// indexOptionsOf returns the options of the column in the index
+func indexOptionsOf(index *schemas.Index, colName string) *indexOptions {
+	var opts = indexOptions{
+		desc:    index.Desc[colName],
+		length:  index.Lengths[colName],
+		where:   index.Where,
+		method:  index.Method,
+		include: index.HasInclude(colName),
+	}
+	if opts == (indexOptions{}) {
+		return nil
+	}
+	return &opts
+}
+
+func addForeignKey(table *schemas.Table, col *schemas.Column, ref *schemas.ForeignKey) {
+	name := ref.Name
+	if name == "" {
+		name = col.Name
+	}
+	fk, ok := table.ForeignKeys[name]
+	if !ok {
+		fk = schemas.NewForeignKey(name, ref.RefTable)
+		table.AddForeignKey(fk)
+	}
+	fk.AddColumn(col.Name, ref.RefCols[0])
+	if ref.OnDelete != "" {
+		fk.OnDelete = ref.OnDelete
+	}
+	if ref.OnUpdate != "" {
+		fk.OnUpdate = ref.OnUpdate
 	}
 }
 
@@ -270,9 +359,32 @@ func (parser *Parser) parseFieldWithTags(table *schemas.Table, fieldIndex int, f
 	} else if ctx.isIndex {
 		ctx.indexNames[col.Name] = schemas.IndexType
 	}
+	if opts, ok := ctx.indexOptions[""]; ok {
+		ctx.indexOptions[col.Name] = opts
+	}
 
 	for indexName, indexType := range ctx.indexNames {
-		addIndex(indexName, table, col, indexType)
+		addIndex(indexName, table, col, indexType, ctx.indexOptions[indexName])
+	}
+
+	var unnamedChecks int
+	for _, check := range ctx.checks {
+		if check.Name == "" {
+			// the following unnamed checks of the column are numbered to keep the names unique
+			check.Name = col.Name
+			if unnamedChecks > 0 {
+				check.Name = fmt.Sprintf("%s_%d", col.Name, unnamedChecks+1)
+			}
+			unnamedChecks++
+		}
+		table.AddCheck(check)
+	}
+
+	if ctx.fk != nil {
+		ctx.fk.OnDelete, ctx.fk.OnUpdate = ctx.fkOnDelete, ctx.fkOnUpdate
+		addForeignKey(table, col, ctx.fk)
+	} else if ctx.fkOnDelete != "" || ctx.fkOnUpdate != "" {
+		return nil, fmt.Errorf("on_delete or on_update tag of field %s should be used with fk tag", field.Name)
 	}
 
 	return col, nil
@@ -334,11 +446,17 @@ func (parser *Parser) Parse(v reflect.Value) (*schemas.Table, error) {
 		table.AddColumn(col)
 	} // end for
 
-	indices := tableIndices(v)
+	var indices []*schemas.Index
+	if i, ok := utils.LookupTableInterface(v, tpTableIndices).(TableIndices); ok {
+		indices = i.TableIndices()
+	}
 	for _, index := range indices {
 		// Override old information
 		if oldIndex, ok := table.Indexes[index.Name]; ok {
-			for _, colName := range oldIndex.Cols {
+			for _, colName := range append(oldIndex.Cols, oldIndex.Include...) {
+				if schemas.IsIndexExpr(colName) {
+					continue
+				}
 				col := table.GetColumn(colName)
 				if col == nil {
 					return nil, ErrUnsupportedType
@@ -347,7 +465,10 @@ func (parser *Parser) Parse(v reflect.Value) (*schemas.Table, error) {
 			}
 		}
 		table.AddIndex(index)
-		for _, colName := range index.Cols {
+		for _, colName := range append(index.Cols, index.Include...) {
+			if schemas.IsIndexExpr(colName) {
+				continue
+			}
 			col := table.GetColumn(colName)
 			if col == nil {
 				return nil, ErrUnsupportedType
@@ -356,23 +477,45 @@ func (parser *Parser) Parse(v reflect.Value) (*schemas.Table, error) {
 		}
 	}
 
-	return table, nil
-}
+	if i, ok := utils.LookupTableInterface(v, tpTableChecks).(TableChecks); ok {
+		for _, check := range i.TableChecks() {
+			table.AddCheck(check)
+		}
+	}
 
-func tableIndices(v reflect.Value) []*schemas.Index {
-	if v.Type().Implements(tpTableIndices) {
-		return v.Interface().(TableIndices).TableIndices()
+	if i, ok := utils.LookupTableInterface(v, tpTableRenames).(TableRenames); ok {
+		for colName, oldName := range i.TableRenames() {
+			col := table.GetColumn(colName)
+			if col == nil {
+				return nil, fmt.Errorf("unknown column %s of table %s to be renamed from %s", colName, table.Name, oldName)
+			}
+			col.RenamedFrom = oldName
+		}
 	}
 
-	if v.Kind() == reflect.Ptr {
-		v = v.Elem()
-		if v.Type().Implements(tpTableIndices) {
-			return v.Interface().(TableIndices).TableIndices()
+	if i, ok := utils.LookupTableInterface(v, tpTableView).(TableView); ok {
+		table.View = i.TableView()
+	}
+	if i, ok := utils.LookupTableInterface(v, tpTablePartitioning).(TablePartitioning); ok {
+		table.Partitioning = i.TablePartitioning()
+		if err := checkPartitionKey(table); err != nil {
+			return nil, err
 		}
-	} else if v.CanAddr() {
-		v1 := v.Addr()
-		if v1.Type().Implements(tpTableIndices) {
-			return v1.Interface().(TableIndices).TableIndices()
+	}
+
+	return table, nil
+}
+
+// checkPartitionKey returns an error if the primary key doesn't include the columns of the
+// partition key, the databases require the unique keys of a partitioned table to include them
+func checkPartitionKey(table *schemas.Table) error {
+	if table.Partitioning == nil || len(table.PrimaryKeys) == 0 {
+		return nil
+	}
+	for _, col := range table.Partitioning.Cols {
+		if !schemas.IsIndexExpr(col) && !utils.ContainsFold(table.PrimaryKeys, col) {
+			return fmt.Errorf("the primary key (%s) of table %s should include the partition key column %s",
+				strings.Join(table.PrimaryKeys, ", "), table.Name, col)
 		}
 	}
 	return nil
@@ -405,6 +405,90 @@ func TestParseWithSet(t *testing.T) {
 	}, table.Columns()[0].SetOptions)
 }
 
+func TestParseWithNestedTypes(t *testing.T) {
+	parser := NewParser(
+		"db",
+		dialects.QueryDialect("duckdb"),
+		names.SnakeMapper{},
+		names.GonicMapper{},
+		caches.NewManager(),
+	)
+
+	type StructWithNestedTypes struct {
+		Tags  []string          `db:"list(varchar)"`
+		Attrs map[string]int    `db:"map(varchar,integer)"`
+		Point map[string]string `db:"struct('x DOUBLE','y DOUBLE')"`
+	}
+
+	table, err := parser.Parse(reflect.ValueOf(new(StructWithNestedTypes)))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, len(table.Columns()))
+
+	tags := table.GetColumn("tags")
+	assert.EqualValues(t, schemas.List, tags.SQLType.Name)
+	assert.EqualValues(t, []string{"VARCHAR"}, tags.TypeParams)
+	assert.True(t, tags.IsJSON)
+
+	attrs := table.GetColumn("attrs")
+	assert.EqualValues(t, schemas.Map, attrs.SQLType.Name)
+	assert.EqualValues(t, []string{"VARCHAR", "INTEGER"}, attrs.TypeParams)
+
+	point := table.GetColumn("point")
+	assert.EqualValues(t, schemas.Struct, point.SQLType.Name)
+	assert.EqualValues(t, []string{"x DOUBLE", "y DOUBLE"}, point.TypeParams)
+}
+
+func TestParseWithPgArray(t *testing.T) {
+	parser := NewParser(
+		"db",
+		dialects.QueryDialect("postgres"),
+		names.SnakeMapper{},
+		names.GonicMapper{},
+		caches.NewManager(),
+	)
+
+	type StructWithPgArray struct {
+		Names  []string          `db:"pgarray"`
+		Ids    []int64           `db:"pgarray"`
+		Scores []*float64        `db:"pgarray"`
+		Times  []time.Time       `db:"pgarray"`
+		Codes  []string          `db:"pgarray(varchar)"`
+		Attrs  map[string]string `db:"hstore"`
+		During string            `db:"tstzrange"`
+	}
+
+	table, err := parser.Parse(reflect.ValueOf(new(StructWithPgArray)))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 7, len(table.Columns()))
+
+	for name, elemType := range map[string]string{
+		"names":  schemas.Text,
+		"ids":    schemas.BigInt,
+		"scores": schemas.Double,
+		"times":  schemas.TimeStampz,
+		"codes":  schemas.Varchar,
+	} {
+		col := table.GetColumn(name)
+		assert.EqualValues(t, schemas.Array, col.SQLType.Name, name)
+		assert.EqualValues(t, []string{elemType}, col.TypeParams, name)
+		assert.False(t, col.IsJSON, name)
+	}
+	assert.EqualValues(t, schemas.Hstore, table.GetColumn("attrs").SQLType.Name)
+	assert.EqualValues(t, schemas.TsTzRange, table.GetColumn("during").SQLType.Name)
+
+	type StructWithWrongPgArray struct {
+		Name string `db:"pgarray"`
+	}
+	_, err = parser.Parse(reflect.ValueOf(new(StructWithWrongPgArray)))
+	assert.Error(t, err)
+
+	type StructWithFixedPgArray struct {
+		Names [2]string `db:"pgarray(varchar)"`
+	}
+	_, err = parser.Parse(reflect.ValueOf(new(StructWithFixedPgArray)))
+	assert.Error(t, err)
+}
+
 func TestParseWithIndex(t *testing.T) {
 	parser := NewParser(
 		"db",
@@ -594,3 +678,281 @@ func TestParseWithSQLType(t *testing.T) {
 	assert.EqualValues(t, "DATETIME", table.Columns()[3].SQLType.Name)
 	assert.EqualValues(t, "UUID", table.Columns()[4].SQLType.Name)
 }
+
+func TestParseWithForeignKey(t *testing.T) {
+	parser := NewParser(
+		"db",
+		dialects.QueryDialect("mysql"),
+		names.SnakeMapper{},
+		names.SnakeMapper{},
+		caches.NewManager(),
+	)
+
+	type StructWithForeignKey struct {
+		Id       int64
+		UserId   int64 `db:"fk(user.id) on_delete(cascade) on_update(restrict)"`
+		OrderId  int64 `db:"fk(order.id, order_line)"`
+		OrderSeq int   `db:"fk(order.seq, order_line) on_delete(set_null)"`
+	}
+
+	table, err := parser.Parse(reflect.ValueOf(new(StructWithForeignKey)))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, len(table.ForeignKeys))
+
+	fk := table.ForeignKeys["user_id"]
+	if assert.NotNil(t, fk) {
+		assert.EqualValues(t, "user", fk.RefTable)
+		assert.EqualValues(t, []string{"user_id"}, fk.Cols)
+		assert.EqualValues(t, []string{"id"}, fk.RefCols)
+		assert.EqualValues(t, schemas.Cascade, fk.OnDelete)
+		assert.EqualValues(t, schemas.Restrict, fk.OnUpdate)
+		assert.EqualValues(t, "FK_struct_with_foreign_key_user_id", dialects.ForeignKeyName(parser.dialect, table.Name, fk))
+	}
+
+	fk = table.ForeignKeys["order_line"]
+	if assert.NotNil(t, fk) {
+		assert.EqualValues(t, "order", fk.RefTable)
+		assert.EqualValues(t, []string{"order_id", "order_seq"}, fk.Cols)
+		assert.EqualValues(t, []string{"id", "seq"}, fk.RefCols)
+		assert.EqualValues(t, schemas.SetNull, fk.OnDelete)
+		assert.EqualValues(t, "", fk.OnUpdate)
+	}
+
+	type StructWithBadForeignKey struct {
+		UserId int64 `db:"fk(user)"`
+	}
+	_, err = parser.Parse(reflect.ValueOf(new(StructWithBadForeignKey)))
+	assert.Error(t, err)
+
+	type StructWithOnDeleteOnly struct {
+		UserId int64 `db:"on_delete(cascade)"`
+	}
+	_, err = parser.Parse(reflect.ValueOf(new(StructWithOnDeleteOnly)))
+	assert.Error(t, err)
+}
+
+type StructWithTableChecks struct {
+	Amount int64 `db:"check('amount >= 0')"`
+	Status string
+	Kind   string `db:"check('kind <> ''deleted''')"`
+	Min    int    `db:"check('min >= 0', positive_min)"`
+	Max    int    `db:"check('max >= 0') check('max < 1000')"`
+}
+
+func (StructWithTableChecks) TableChecks() []*schemas.Check {
+	return []*schemas.Check{
+		schemas.NewCheck("range", "min <= max"),
+		schemas.NewCheck("status", "status IN ('open','closed')"),
+	}
+}
+
+func TestParseWithChecks(t *testing.T) {
+	parser := NewParser(
+		"db",
+		dialects.QueryDialect("mysql"),
+		names.SnakeMapper{},
+		names.SnakeMapper{},
+		caches.NewManager(),
+	)
+
+	table, err := parser.Parse(reflect.ValueOf(new(StructWithTableChecks)))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 7, len(table.Checks))
+	assert.EqualValues(t, "amount >= 0", table.Checks["amount"].Expr)
+	assert.EqualValues(t, "min >= 0", table.Checks["positive_min"].Expr)
+	assert.EqualValues(t, "max >= 0", table.Checks["max"].Expr)
+	assert.EqualValues(t, "max < 1000", table.Checks["max_2"].Expr)
+	assert.EqualValues(t, "kind <> 'deleted'", table.Checks["kind"].Expr)
+	assert.EqualValues(t, "min <= max", table.Checks["range"].Expr)
+	assert.EqualValues(t, "status IN ('open','closed')", table.Checks["status"].Expr)
+	assert.EqualValues(t, "CHK_struct_with_table_checks_amount", dialects.CheckName(parser.dialect, table.Name, table.Checks["amount"]))
+
+	type StructWithBadCheck struct {
+		Amount int64 `db:"check"`
+	}
+	_, err = parser.Parse(reflect.ValueOf(new(StructWithBadCheck)))
+	assert.Error(t, err)
+}
+
+func TestParseWithIndexOptions(t *testing.T) {
+	parser := NewParser(
+		"db",
+		dialects.QueryDialect("postgres"),
+		names.SnakeMapper{},
+		names.SnakeMapper{},
+		caches.NewManager(),
+	)
+
+	type StructWithIndexOptions struct {
+		Id        int64
+		Email     string `db:"unique(email, expr:'lower(email)', where:'deleted_at IS NULL') comment('user''s email')"`
+		Tenant    int64  `db:"index(tenant, asc) index(created)"`
+		Created   int64  `db:"index(created, desc)"`
+		Name      string `db:"index(tenant, include) index(desc, length:10)"`
+		Tags      string `db:"index(using:gin, where:'tags <> ''''')"`
+		DeletedAt int64
+	}
+
+	table, err := parser.Parse(reflect.ValueOf(new(StructWithIndexOptions)))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 5, len(table.Indexes))
+
+	email := table.Indexes["email"]
+	assert.EqualValues(t, schemas.UniqueType, email.Type)
+	assert.EqualValues(t, []string{"lower(email)"}, email.Cols)
+	assert.EqualValues(t, "deleted_at IS NULL", email.Where)
+	assert.EqualValues(t, "user's email", table.GetColumn("email").Comment)
+	assert.EqualValues(t, schemas.UniqueType, table.GetColumn("email").Indexes["email"])
+
+	tenant := table.Indexes["tenant"]
+	assert.EqualValues(t, []string{"tenant"}, tenant.Cols)
+	assert.EqualValues(t, []string{"name"}, tenant.Include)
+
+	created := table.Indexes["created"]
+	assert.EqualValues(t, []string{"tenant", "created"}, created.Cols)
+	assert.EqualValues(t, map[string]bool{"created": true}, created.Desc)
+
+	name := table.Indexes["name"]
+	assert.EqualValues(t, []string{"name"}, name.Cols)
+	assert.True(t, name.Desc["name"])
+	assert.EqualValues(t, 10, name.Lengths["name"])
+
+	assert.EqualValues(t, "gin", table.Indexes["tags"].Method)
+	assert.EqualValues(t, "tags <> ''", table.Indexes["tags"].Where)
+
+	type StructWithBadIndexOption struct {
+		Name string `db:"index(name, unknown)"`
+	}
+	_, err = parser.Parse(reflect.ValueOf(new(StructWithBadIndexOption)))
+	assert.Error(t, err)
+}
+
+type StructWithTableView struct {
+	Id   int64
+	Name string
+}
+
+func (StructWithTableView) TableView() *schemas.View {
+	return schemas.NewView("SELECT id, name FROM user WHERE active = 1", false)
+}
+
+func TestParseWithTableView(t *testing.T) {
+	parser := NewParser(
+		"db",
+		dialects.QueryDialect("mysql"),
+		names.SnakeMapper{},
+		names.SnakeMapper{},
+		caches.NewManager(),
+	)
+
+	table, err := parser.Parse(reflect.ValueOf(new(StructWithTableView)))
+	assert.NoError(t, err)
+	assert.True(t, table.IsView())
+	assert.EqualValues(t, "SELECT id, name FROM user WHERE active = 1", table.View.Query)
+	assert.False(t, table.View.Materialized)
+
+	table, err = parser.Parse(reflect.ValueOf(new(StructWithTableChecks)))
+	assert.NoError(t, err)
+	assert.False(t, table.IsView())
+}
+
+type StructWithTablePartitioning struct {
+	Id      int64 `db:"pk"`
+	Created int64 `db:"pk"`
+}
+
+func (StructWithTablePartitioning) TablePartitioning() *schemas.Partitioning {
+	return schemas.NewPartitioning(schemas.RangePartitioning, "created").
+		AddPartition(schemas.RangePartition("p0", "", "1000"))
+}
+
+func TestParseWithTablePartitioning(t *testing.T) {
+	parser := NewParser(
+		"db",
+		dialects.QueryDialect("postgres"),
+		names.SnakeMapper{},
+		names.SnakeMapper{},
+		caches.NewManager(),
+	)
+
+	table, err := parser.Parse(reflect.ValueOf(new(StructWithTablePartitioning)))
+	assert.NoError(t, err)
+	assert.True(t, table.IsPartitioned())
+	assert.EqualValues(t, schemas.RangePartitioning, table.Partitioning.Type)
+	assert.EqualValues(t, []string{"created"}, table.Partitioning.Cols)
+	assert.Len(t, table.Partitioning.Partitions, 1)
+	assert.EqualValues(t, []string{"id", "created"}, table.PrimaryKeys)
+
+	_, err = parser.Parse(reflect.ValueOf(new(StructWithBadPartitionKey)))
+	assert.Error(t, err)
+}
+
+type StructWithBadPartitionKey struct {
+	Id      int64 `db:"pk"`
+	Created int64
+}
+
+func (StructWithBadPartitionKey) TablePartitioning() *schemas.Partitioning {
+	return schemas.NewPartitioning(schemas.RangePartitioning, "created")
+}
+
+type StructWithTableRenames struct {
+	Id       int64
+	Nickname string `db:"renamed_from(nick) index"`
+	Email    string
+}
+
+func (StructWithTableRenames) TableRenames() map[string]string {
+	return map[string]string{"email": "mail"}
+}
+
+func TestParseWithRenamedFrom(t *testing.T) {
+	parser := NewParser(
+		"db",
+		dialects.QueryDialect("mysql"),
+		names.SnakeMapper{},
+		names.SnakeMapper{},
+		caches.NewManager(),
+	)
+
+	table, err := parser.Parse(reflect.ValueOf(new(StructWithTableRenames)))
+	assert.NoError(t, err)
+	assert.EqualValues(t, "nick", table.GetColumn("nickname").RenamedFrom)
+	assert.EqualValues(t, "mail", table.GetColumn("email").RenamedFrom)
+	assert.EqualValues(t, "", table.GetColumn("id").RenamedFrom)
+
+	type StructWithBadRenamedFrom struct {
+		Name string `db:"renamed_from"`
+	}
+	_, err = parser.Parse(reflect.ValueOf(new(StructWithBadRenamedFrom)))
+	assert.Error(t, err)
+}
+
+func TestParseWithSeq(t *testing.T) {
+	parser := NewParser(
+		"db",
+		dialects.QueryDialect("oracle"),
+		names.SnakeMapper{},
+		names.GonicMapper{},
+		caches.NewManager(),
+	)
+
+	type StructWithSeq struct {
+		Id   int64 `db:"pk seq(SEQ_USER_ID)"`
+		Name string
+	}
+
+	table, err := parser.Parse(reflect.ValueOf(new(StructWithSeq)))
+	assert.NoError(t, err)
+	assert.EqualValues(t, "id", table.AutoIncrement)
+	col := table.AutoIncrColumn()
+	assert.EqualValues(t, "SEQ_USER_ID", col.Sequence)
+	assert.True(t, col.IsPrimaryKey)
+	assert.False(t, col.Nullable)
+
+	type StructWithEmptySeq struct {
+		Id int64 `db:"pk seq"`
+	}
+	_, err = parser.Parse(reflect.ValueOf(new(StructWithEmptySeq)))
+	assert.Error(t, err)
+}
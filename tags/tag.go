@@ -57,14 +57,14 @@ func splitTag(tagStr string) ([]tag, error) {
 				paramStart = i + 1
 			}
 		case '(':
-			inBigQuote = true
 			if !inQuote {
+				inBigQuote = true
 				curTag.name = tagStr[lastIdx:i]
 				paramStart = i + 1
 			}
 		case ')':
-			inBigQuote = false
 			if !inQuote {
+				inBigQuote = false
 				curTag.params = append(curTag.params, tagStr[paramStart:i])
 			}
 		}
@@ -89,11 +89,16 @@ type Context struct {
 	isIndex         bool
 	isUnique        bool
 	indexNames      map[string]int
+	indexOptions    map[string]*indexOptions
 	parser          *Parser
 	hasCacheTag     bool
 	hasNoCacheTag   bool
 	ignoreNext      bool
 	isUnsigned      bool
+	fk              *schemas.ForeignKey
+	fkOnDelete      string
+	fkOnUpdate      string
+	checks          []*schemas.Check
 }
 
 // Handler describes tag handler for XORM
@@ -109,6 +114,7 @@ var (
 		"NULL":     NULLTagHandler,
 		"NOT":      NotTagHandler,
 		"AUTOINCR": AutoIncrTagHandler,
+		"SEQ":      SeqTagHandler,
 		"DEFAULT":  DefaultTagHandler,
 		"CREATED":  CreatedTagHandler,
 		"UPDATED":  UpdatedTagHandler,
@@ -124,6 +130,15 @@ var (
 		"COMMENT":  CommentTagHandler,
 		"EXTENDS":  ExtendsTagHandler,
 		"UNSIGNED": UnsignedTagHandler,
+
+		"FK":        ForeignKeyTagHandler,
+		"ON_DELETE": OnDeleteTagHandler,
+		"ON_UPDATE": OnUpdateTagHandler,
+		"CHECK":     CheckTagHandler,
+
+		"RENAMED_FROM": RenamedFromTagHandler,
+
+		"PGARRAY": PgArrayTagHandler,
 	}
 )
 
@@ -252,24 +267,152 @@ func DeletedTagHandler(ctx *Context) error {
 
 // IndexTagHandler describes index tag handler
 func IndexTagHandler(ctx *Context) error {
-	if len(ctx.params) > 0 {
-		ctx.indexNames[ctx.params[0]] = schemas.IndexType
+	return indexTagHandler(ctx, schemas.IndexType)
+}
+
+// UniqueTagHandler describes unique tag handler
+func UniqueTagHandler(ctx *Context) error {
+	return indexTagHandler(ctx, schemas.UniqueType)
+}
+
+// indexOptions are the options of the column in the index, i.e. index(idx_name, desc, length:10)
+type indexOptions struct {
+	desc    bool
+	length  int
+	include bool
+	expr    string
+	where   string
+	method  string
+}
+
+// unquoteParam removes the surrounding single quotes of the param, the single quotes
+// inside it are doubled
+func unquoteParam(param string) string {
+	if len(param) >= 2 && param[0] == '\'' && param[len(param)-1] == '\'' {
+		return strings.ReplaceAll(param[1:len(param)-1], "''", "'")
+	}
+	return param
+}
+
+// indexTagHandler parses the index name and the options like desc, asc, include,
+// length:10, using:gin, where:'deleted_at IS NULL' and expr:'lower(email)'
+func indexTagHandler(ctx *Context, indexType int) error {
+	var (
+		name string
+		opts indexOptions
+	)
+	for i, param := range ctx.params {
+		param = strings.TrimSpace(param)
+		var key, value = param, ""
+		if idx := strings.Index(param, ":"); idx > 0 {
+			key, value = strings.TrimSpace(param[:idx]), unquoteParam(strings.TrimSpace(param[idx+1:]))
+		}
+		switch strings.ToLower(key) {
+		case "desc":
+			opts.desc = true
+		case "asc":
+		case "include":
+			opts.include = true
+		case "length":
+			length, err := strconv.Atoi(value)
+			if err != nil || length <= 0 {
+				return fmt.Errorf("index length of field %s should be a positive integer but got %s", ctx.col.FieldName, value)
+			}
+			opts.length = length
+		case "using":
+			opts.method = value
+		case "where":
+			opts.where = value
+		case "expr":
+			opts.expr = value
+		default:
+			if i > 0 || value != "" {
+				return fmt.Errorf("unknown index option %s of field %s", param, ctx.col.FieldName)
+			}
+			name = param
+		}
+	}
+
+	if name == "" {
+		if indexType == schemas.UniqueType {
+			ctx.isUnique = true
+		} else {
+			ctx.isIndex = true
+		}
 	} else {
-		ctx.isIndex = true
+		ctx.indexNames[name] = indexType
+	}
+	if opts != (indexOptions{}) {
+		if ctx.indexOptions == nil {
+			ctx.indexOptions = make(map[string]*indexOptions)
+		}
+		ctx.indexOptions[name] = &opts
 	}
 	return nil
 }
 
-// UniqueTagHandler describes unique tag handler
-func UniqueTagHandler(ctx *Context) error {
+// ForeignKeyTagHandler describes foreign key tag handler, the param is the referenced
+// table and column like fk(user.id), an optional second param names the foreign key so
+// that the columns with the same name will be a composite foreign key.
+func ForeignKeyTagHandler(ctx *Context) error {
+	if len(ctx.params) == 0 {
+		return fmt.Errorf("fk tag of field %s should reference a column like fk(user.id)", ctx.col.FieldName)
+	}
+	ref := strings.Trim(strings.TrimSpace(ctx.params[0]), "'")
+	idx := strings.LastIndex(ref, ".")
+	if idx <= 0 || idx == len(ref)-1 {
+		return fmt.Errorf("fk tag of field %s should reference a column like fk(user.id) but got %s", ctx.col.FieldName, ref)
+	}
+	ctx.fk = schemas.NewForeignKey("", ref[:idx])
+	ctx.fk.RefCols = append(ctx.fk.RefCols, ref[idx+1:])
+	if len(ctx.params) > 1 {
+		ctx.fk.Name = strings.Trim(strings.TrimSpace(ctx.params[1]), "'")
+	}
+	return nil
+}
+
+// OnDeleteTagHandler describes the on delete action of the foreign key
+func OnDeleteTagHandler(ctx *Context) error {
 	if len(ctx.params) > 0 {
-		ctx.indexNames[ctx.params[0]] = schemas.UniqueType
-	} else {
-		ctx.isUnique = true
+		ctx.fkOnDelete = schemas.ReferentialAction(ctx.params[0])
+	}
+	return nil
+}
+
+// OnUpdateTagHandler describes the on update action of the foreign key
+func OnUpdateTagHandler(ctx *Context) error {
+	if len(ctx.params) > 0 {
+		ctx.fkOnUpdate = schemas.ReferentialAction(ctx.params[0])
 	}
 	return nil
 }
 
+// CheckTagHandler describes check constraint tag handler like check('amount >= 0'), an
+// optional second param names the check constraint, otherwise the column name is used and
+// the following unnamed checks of the same column are numbered like amount_2. The single
+// quotes in the expression should be doubled.
+func CheckTagHandler(ctx *Context) error {
+	if len(ctx.params) == 0 {
+		return fmt.Errorf("check tag of field %s should have an expression like check('amount >= 0')", ctx.col.FieldName)
+	}
+	check := schemas.NewCheck("", unquoteParam(strings.TrimSpace(ctx.params[0])))
+	if len(ctx.params) > 1 {
+		check.Name = strings.Trim(strings.TrimSpace(ctx.params[1]), "'")
+	}
+	ctx.checks = append(ctx.checks, check)
+	return nil
+}
+
+// RenamedFromTagHandler describes the old name of the column like renamed_from(old_name),
+// Sync will rename the column if the old column exists
+func RenamedFromTagHandler(ctx *Context) error {
+	if len(ctx.params) == 0 {
+		return fmt.Errorf("renamed_from tag of field %s should have the old column name", ctx.col.FieldName)
+	}
+	ctx.col.RenamedFrom = strings.Trim(strings.TrimSpace(ctx.params[0]), "'")
+	return nil
+}
+
 // UnsignedTagHandler represents the column is unsigned
 func UnsignedTagHandler(ctx *Context) error {
 	ctx.isUnsigned = true
@@ -279,7 +422,7 @@ func UnsignedTagHandler(ctx *Context) error {
 // CommentTagHandler add comment to column
 func CommentTagHandler(ctx *Context) error {
 	if len(ctx.params) > 0 {
-		ctx.col.Comment = strings.Trim(ctx.params[0], "' ")
+		ctx.col.Comment = unquoteParam(strings.TrimSpace(ctx.params[0]))
 	}
 	return nil
 }
@@ -287,7 +430,8 @@ func CommentTagHandler(ctx *Context) error {
 // SQLTypeTagHandler describes SQL Type tag handler
 func SQLTypeTagHandler(ctx *Context) error {
 	ctx.col.SQLType = schemas.SQLType{Name: ctx.tagUname}
-	if ctx.tagUname == "JSON" {
+	switch ctx.tagUname {
+	case schemas.Json, schemas.List, schemas.Struct, schemas.Map:
 		ctx.col.IsJSON = true
 	}
 	if len(ctx.params) == 0 {
@@ -295,6 +439,16 @@ func SQLTypeTagHandler(ctx *Context) error {
 	}
 
 	switch ctx.tagUname {
+	case schemas.List, schemas.Map, schemas.Array:
+		// like LIST(INTEGER), MAP(VARCHAR,INTEGER) or ARRAY(BIGINT)
+		for _, v := range ctx.params {
+			ctx.col.TypeParams = append(ctx.col.TypeParams, strings.ToUpper(strings.Trim(strings.TrimSpace(v), "'")))
+		}
+	case schemas.Struct:
+		// the fields should be quoted like STRUCT('a INTEGER','b VARCHAR')
+		for _, v := range ctx.params {
+			ctx.col.TypeParams = append(ctx.col.TypeParams, strings.Trim(strings.TrimSpace(v), "'"))
+		}
 	case schemas.Enum:
 		ctx.col.EnumOptions = make(map[string]int)
 		for k, v := range ctx.params {
@@ -330,6 +484,56 @@ func SQLTypeTagHandler(ctx *Context) error {
 	return nil
 }
 
+// SeqTagHandler binds the autoincrement column to a named sequence like seq(SEQ_USER_ID),
+// the next values of the sequence are inserted into the column
+func SeqTagHandler(ctx *Context) error {
+	if len(ctx.params) == 0 {
+		return fmt.Errorf("seq tag of field %s should have the sequence name", ctx.col.FieldName)
+	}
+	ctx.col.Sequence = strings.Trim(strings.TrimSpace(ctx.params[0]), "'")
+	ctx.col.IsAutoIncrement = true
+	ctx.col.Nullable = false
+	return nil
+}
+
+// PgArrayTagHandler describes a native postgres array column, the element type is the parameter
+// like pgarray(varchar) or is decided by the element type of the slice
+func PgArrayTagHandler(ctx *Context) error {
+	// the fixed size arrays cannot be scanned from the postgres arrays with any length
+	t := ctx.fieldValue.Type()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Slice {
+		return fmt.Errorf("pgarray tag needs a slice but the field %s is %v", ctx.col.FieldName, t)
+	}
+
+	ctx.col.SQLType = schemas.SQLType{Name: schemas.Array}
+	if len(ctx.params) > 0 {
+		ctx.col.TypeParams = []string{strings.ToUpper(strings.Trim(strings.TrimSpace(ctx.params[0]), "'"))}
+		return nil
+	}
+
+	elem := t.Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+
+	var elemType string
+	switch {
+	case elem.ConvertibleTo(schemas.TimeType):
+		elemType = schemas.TimeStampz
+	case elem.Kind() == reflect.String:
+		elemType = schemas.Text
+	case elem.Kind() == reflect.Int:
+		elemType = schemas.BigInt
+	default:
+		elemType = schemas.Type2SQLType(elem).Name
+	}
+	ctx.col.TypeParams = []string{elemType}
+	return nil
+}
+
 // ExtendsTagHandler describes extends tag handler
 func ExtendsTagHandler(ctx *Context) error {
 	var fieldValue = ctx.fieldValue
@@ -351,7 +555,10 @@ func ExtendsTagHandler(ctx *Context) error {
 		if err != nil {
 			return err
 		}
+		var colNames = make(map[string]*schemas.Column, len(parentTable.Columns()))
 		for _, col := range parentTable.Columns() {
+			var parentName = col.Name
+			colNames[col.Name] = col
 			col.FieldName = fmt.Sprintf("%v.%v", ctx.col.FieldName, col.FieldName)
 			col.FieldIndex = append(ctx.col.FieldIndex, col.FieldIndex...)
 
@@ -374,7 +581,33 @@ func ExtendsTagHandler(ctx *Context) error {
 
 			ctx.table.AddColumn(col)
 			for indexName, indexType := range col.Indexes {
-				addIndex(indexName, ctx.table, col, indexType)
+				var opts *indexOptions
+				if parentIndex, ok := parentTable.Indexes[indexName]; ok {
+					opts = indexOptionsOf(parentIndex, parentName)
+				}
+				addIndex(indexName, ctx.table, col, indexType, opts)
+			}
+		}
+		for _, check := range parentTable.Checks {
+			ctx.table.AddCheck(check)
+		}
+		for _, fk := range parentTable.ForeignKeys {
+			var name = fk.Name
+			if len(fk.Cols) == 1 && fk.Name == fk.Cols[0] {
+				name = "" // the name will be the column's new name
+			}
+			for i, colName := range fk.Cols {
+				col, ok := colNames[colName]
+				if !ok {
+					continue
+				}
+				addForeignKey(ctx.table, col, &schemas.ForeignKey{
+					Name:     name,
+					RefTable: fk.RefTable,
+					RefCols:  fk.RefCols[i : i+1],
+					OnDelete: fk.OnDelete,
+					OnUpdate: fk.OnUpdate,
+				})
 			}
 		}
 	default:
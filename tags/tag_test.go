@@ -74,6 +74,16 @@ func TestSplitTag(t *testing.T) {
 			},
 		},
 		},
+		{"index(email, expr:'lower(email)') notnull", []tag{
+			{
+				name:   "index",
+				params: []string{"email", "expr:'lower(email)'"},
+			},
+			{
+				name: "notnull",
+			},
+		},
+		},
 	}
 
 	for _, kase := range cases {